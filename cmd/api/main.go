@@ -56,15 +56,43 @@ func main() {
 	
 	// Wire up tournament service to game service (breaks circular dependency)
 	gameService.SetTournamentService(tournamentService)
+	gameService.SetRoomService(roomService)
+	gameService.SetReconnectGracePeriod(cfg.ReconnectGracePeriod)
 	
-	// Wire up notification service to tournament service
+	// Wire up notification service to tournament and game services
 	tournamentService.SetNotificationService(notificationService)
+	gameService.SetNotificationService(notificationService)
 
 	// Start matchmaking worker
 	matchmakingCtx, cancelMatchmaking := context.WithCancel(ctx)
 	defer cancelMatchmaking()
 	go matchmakingService.StartMatchmakingWorker(matchmakingCtx)
 
+	// Start clock sweeper (ends games on flag-fall)
+	clockCtx, cancelClock := context.WithCancel(ctx)
+	defer cancelClock()
+	go gameService.StartClockSweeper(clockCtx)
+
+	// Start disconnect sweeper (forfeits or abandons games after the reconnect grace period)
+	disconnectCtx, cancelDisconnect := context.WithCancel(ctx)
+	defer cancelDisconnect()
+	go gameService.StartDisconnectSweeper(disconnectCtx)
+
+	// Start checkpointer (writes in-progress games to Postgres so they survive a Redis restart)
+	checkpointCtx, cancelCheckpoint := context.WithCancel(ctx)
+	defer cancelCheckpoint()
+	go gameService.StartCheckpointer(checkpointCtx)
+
+	// Start game event consumers (records completed games and archives every event)
+	eventsCtx, cancelEvents := context.WithCancel(ctx)
+	defer cancelEvents()
+	go gameService.StartEventConsumers(eventsCtx)
+
+	// Start outbox dispatcher (applies stats, tournament and notification side effects of completed games)
+	outboxCtx, cancelOutbox := context.WithCancel(ctx)
+	defer cancelOutbox()
+	go gameService.StartOutboxDispatcher(outboxCtx)
+
 	// Initialize WebSocket hub
 	hub := ws.NewHub()
 	go hub.Run()
@@ -127,10 +155,35 @@ func main() {
 	games := api.Group("/games", middleware.AuthRequired(authService))
 	games.Post("/create", gameHandler.CreateGame)
 	games.Post("/join", gameHandler.JoinGame)
+	games.Get("/types", gameHandler.ListGameTypes)
+	games.Get("/paused", gameHandler.ListPausedGames)
 	games.Get("/:id", gameHandler.GetGame)
+	games.Get("/:id/moves", gameHandler.GetLegalMoves)
 	games.Post("/:id/spectate", gameHandler.JoinAsSpectator)
 	games.Delete("/:id/spectate", gameHandler.LeaveAsSpectator)
 	games.Get("/:id/spectators", gameHandler.GetSpectators)
+	games.Post("/:id/resign", gameHandler.Resign)
+	games.Post("/:id/draw/offer", gameHandler.OfferDraw)
+	games.Post("/:id/draw/accept", gameHandler.AcceptDraw)
+	games.Post("/:id/draw/decline", gameHandler.DeclineDraw)
+	games.Post("/:id/abort", gameHandler.Abort)
+	games.Post("/:id/takeback/request", gameHandler.RequestTakeback)
+	games.Post("/:id/takeback/accept", gameHandler.AcceptTakeback)
+	games.Post("/:id/takeback/decline", gameHandler.DeclineTakeback)
+	games.Post("/:id/pause/request", gameHandler.RequestPause)
+	games.Post("/:id/pause/accept", gameHandler.AcceptPause)
+	games.Post("/:id/pause/decline", gameHandler.DeclinePause)
+	games.Post("/:id/resume/request", gameHandler.RequestResume)
+	games.Post("/:id/resume/accept", gameHandler.AcceptResume)
+	games.Post("/:id/resume/decline", gameHandler.DeclineResume)
+	games.Post("/:id/admin/pause", gameHandler.AdminPause)
+	games.Post("/:id/admin/resume", gameHandler.AdminResume)
+	games.Post("/:id/rematch/offer", gameHandler.OfferRematch)
+	games.Post("/:id/rematch/accept", gameHandler.AcceptRematch)
+	games.Post("/:id/rematch/decline", gameHandler.DeclineRematch)
+	games.Get("/:id/replay", gameHandler.GetReplay)
+	games.Get("/:id/replay/:ply", gameHandler.GetReplayFrame)
+	games.Get("/:id/events", gameHandler.GetEvents)
 
 	// Stats routes (protected)
 	stats := api.Group("/stats", middleware.AuthRequired(authService))
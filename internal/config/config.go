@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"time"
 )
 
 type Config struct {
@@ -11,6 +13,9 @@ type Config struct {
 	JWTSecret   string
 	CORSOrigins string
 	Environment string
+
+	// How long a disconnected player has to reconnect before forfeiting
+	ReconnectGracePeriod time.Duration
 }
 
 func Load() *Config {
@@ -21,6 +26,8 @@ func Load() *Config {
 		JWTSecret:   getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
 		CORSOrigins: getEnv("CORS_ORIGINS", "http://localhost:5173"),
 		Environment: getEnv("ENVIRONMENT", "development"),
+
+		ReconnectGracePeriod: time.Duration(getEnvInt("RECONNECT_GRACE_SECONDS", 60)) * time.Second,
 	}
 }
 
@@ -31,4 +38,11 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
-
+func getEnvInt(key string, fallback int) int {
+	if value := os.Getenv(key); value != "" {
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	}
+	return fallback
+}
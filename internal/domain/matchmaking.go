@@ -26,12 +26,13 @@ type QueueEntry struct {
 	Status        MatchmakingStatus `json:"status"`
 	QueuedAt      time.Time         `json:"queued_at"`
 	MatchedRoomID *uuid.UUID        `json:"matched_room_id,omitempty"`
+	MatchedBot    string            `json:"matched_bot,omitempty"` // Bot difficulty when backfilled with a bot
 	ExpiresAt     time.Time         `json:"expires_at"`
 }
 
 // MatchmakingRequest represents a matchmaking request
 type MatchmakingRequest struct {
-	GameType string `json:"game_type" validate:"required,oneof=tictactoe connect4 rps dotsandboxes"`
+	GameType string `json:"game_type" validate:"required"` // Must be registered in game.DefaultRegistry
 }
 
 // MatchmakingResponse is the API response for matchmaking
@@ -15,6 +15,7 @@ const (
 	NotificationTypePlayerJoined       NotificationType = "player_joined"       // Player joined tournament you're in
 	NotificationTypeInvitationAccepted NotificationType = "invitation_accepted" // Your invitation was accepted
 	NotificationTypeInvitationDeclined NotificationType = "invitation_declined" // Your invitation was declined
+	NotificationTypeGameResult         NotificationType = "game_result"         // Your tournament game has ended
 )
 
 // Notification represents a user notification
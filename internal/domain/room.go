@@ -48,27 +48,51 @@ type GameSettings struct {
 	
 	// RPS settings
 	RPSBestOf int `json:"rps_best_of,omitempty"` // 3, 5, 7, 9
+	RPSCommitReveal bool `json:"rps_commit_reveal,omitempty"` // Commit to a hash of each choice before revealing it
 	
 	// Dots & Boxes settings
 	DotsGridSize int `json:"dots_grid_size,omitempty"` // 4, 5, 6 (creates (n-1)x(n-1) boxes)
+
+	// Gomoku settings
+	GomokuBoardSize int    `json:"gomoku_board_size,omitempty"` // 15 or 19
+	GomokuRule      string `json:"gomoku_rule,omitempty"`       // "freestyle" or "exact"
+	GomokuOpening   string `json:"gomoku_opening,omitempty"`    // "standard" or "swap2"
+
+	// Othello settings
+	OthelloBoardSize int `json:"othello_board_size,omitempty"` // 6, 8 or 10
+
+	// Multiplayer settings (game types seating more than two players)
+	Players int `json:"players,omitempty"` // Seats in the game; rooms set it to their participant count
+
+	// Time control settings (all game types)
+	TimeControl          string `json:"time_control,omitempty"`           // "none", "total" or "per_move"
+	TimeTotalSeconds     int    `json:"time_total_seconds,omitempty"`     // Clock per player for "total"
+	TimeIncrementSeconds int    `json:"time_increment_seconds,omitempty"` // Fischer increment per move for "total"
+	TimePerMoveSeconds   int    `json:"time_per_move_seconds,omitempty"`  // Time for each move for "per_move"
+
+	// First-mover policy (two-player games)
+	FirstMover string `json:"first_mover,omitempty"` // "host", "random", "alternate" (default) or "lower_rated"
 }
 
 // Room represents a game room
 type Room struct {
-	ID           uuid.UUID         `json:"id"`
-	Type         RoomType          `json:"type"`
-	Status       RoomStatus        `json:"status"`
-	GameType     string            `json:"game_type"`
-	GameSettings *GameSettings     `json:"game_settings,omitempty"`
-	JoinCode     string            `json:"join_code"`
-	HostID       uuid.UUID         `json:"host_id"`
-	GameID       *uuid.UUID        `json:"game_id,omitempty"`
-	MaxPlayers   int               `json:"max_players"`
-	Participants []Participant     `json:"participants"`
-	CreatedAt    time.Time         `json:"created_at"`
-	UpdatedAt    time.Time         `json:"updated_at"`
-	StartedAt    *time.Time        `json:"started_at,omitempty"`
-	ExpiresAt    time.Time         `json:"expires_at"`
+	ID            uuid.UUID     `json:"id"`
+	Type          RoomType      `json:"type"`
+	Status        RoomStatus    `json:"status"`
+	GameType      string        `json:"game_type"`
+	GameSettings  *GameSettings `json:"game_settings,omitempty"`
+	JoinCode      string        `json:"join_code"`
+	HostID        uuid.UUID     `json:"host_id"`
+	GameID        *uuid.UUID    `json:"game_id,omitempty"`
+	MaxPlayers    int           `json:"max_players"`
+	Participants  []Participant `json:"participants"`
+	BotDifficulty string        `json:"bot_difficulty,omitempty"` // Set when the host plays against a bot
+	BotRated      bool          `json:"bot_rated,omitempty"`      // Bot games only affect ELO when explicitly rated
+	SeriesBestOf  int           `json:"series_best_of,omitempty"` // Play a best-of-N series instead of a single game
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+	StartedAt     *time.Time    `json:"started_at,omitempty"`
+	ExpiresAt     time.Time     `json:"expires_at"`
 }
 
 // Participant represents a room participant
@@ -82,10 +106,13 @@ type Participant struct {
 
 // CreateRoomRequest represents a room creation request
 type CreateRoomRequest struct {
-	GameType     string        `json:"game_type" validate:"required,oneof=tictactoe connect4 rps dotsandboxes"`
-	Type         RoomType      `json:"type" validate:"required,oneof=quickplay private ranked"`
-	MaxPlayers   int           `json:"max_players" validate:"required,min=2,max=32"`
-	GameSettings *GameSettings `json:"game_settings,omitempty"`
+	GameType      string        `json:"game_type" validate:"required"` // Must be registered in game.DefaultRegistry
+	Type          RoomType      `json:"type" validate:"required,oneof=quickplay private ranked"`
+	MaxPlayers    int           `json:"max_players" validate:"required,min=2,max=32"`
+	GameSettings  *GameSettings `json:"game_settings,omitempty"`
+	BotDifficulty string        `json:"bot_difficulty,omitempty" validate:"omitempty,oneof=easy medium hard"` // Play against a bot instead of a second player
+	BotRated      bool          `json:"bot_rated,omitempty"`                                                  // Count the bot game towards the host's rating
+	SeriesBestOf  int           `json:"series_best_of,omitempty" validate:"omitempty,oneof=3 5 7"`            // Play a best-of-N series; the seats swap every game
 }
 
 // JoinRoomRequest represents a room join request
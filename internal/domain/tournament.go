@@ -57,6 +57,7 @@ type Tournament struct {
 	Participants    []TournamentParticipant `json:"participants"`
 	CurrentRound    int              `json:"current_round"`
 	TotalRounds     int              `json:"total_rounds"`
+	GameSettings    *GameSettings    `json:"game_settings,omitempty"` // Settings (including time control) for every match
 }
 
 // TournamentParticipant represents a tournament participant
@@ -112,7 +113,7 @@ type TournamentMatch struct {
 // CreateTournamentRequest represents a tournament creation request
 type CreateTournamentRequest struct {
 	Name            string         `json:"name" validate:"required,min=3,max=100"`
-	GameType        string         `json:"game_type" validate:"required,oneof=tictactoe connect4 rps dotsandboxes"`
+	GameType        string         `json:"game_type" validate:"required"` // Must be registered in game.DefaultRegistry
 	TournamentType  TournamentType `json:"tournament_type" validate:"required,oneof=single_elimination"`
 	MaxParticipants int            `json:"max_participants" validate:"required,min=4,max=32"`
 	IsPrivate       bool           `json:"is_private"`
@@ -0,0 +1,443 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+const battleshipBoardSize = 10
+
+// BattleshipPhase is the stage of a Battleship game
+type BattleshipPhase string
+
+const (
+	BattleshipPhaseSetup BattleshipPhase = "setup" // Both players place their fleets at the same time
+	BattleshipPhasePlay  BattleshipPhase = "play"  // Players take turns firing at each other's fleet
+)
+
+// Shot results
+const (
+	BattleshipMiss = "miss"
+	BattleshipHit  = "hit"
+	BattleshipSunk = "sunk"
+)
+
+// battleshipFleet is the ship each player places, by name, and its length
+var battleshipFleet = []struct {
+	name   string
+	length int
+}{
+	{"carrier", 5},
+	{"battleship", 4},
+	{"cruiser", 3},
+	{"submarine", 3},
+	{"destroyer", 2},
+}
+
+// BattleshipState represents the state of a Battleship game. The serialized state holds both
+// fleets; players and spectators are sent StateFor, which only shows a viewer their own fleet.
+type BattleshipState struct {
+	Player1ID     uuid.UUID        `json:"player1_id"`
+	Player2ID     uuid.UUID        `json:"player2_id"`
+	CurrentPlayer uuid.UUID        `json:"current_player"`
+	Phase         BattleshipPhase  `json:"phase"`
+	BoardSize     int              `json:"board_size"`
+	Player1Fleet  []BattleshipShip `json:"player1_fleet,omitempty"` // Empty until player 1 has placed their ships
+	Player2Fleet  []BattleshipShip `json:"player2_fleet,omitempty"` // Empty until player 2 has placed their ships
+	Player1Shots  []BattleshipShot `json:"player1_shots"`           // Shots player 1 fired at player 2's fleet
+	Player2Shots  []BattleshipShot `json:"player2_shots"`           // Shots player 2 fired at player 1's fleet
+	Winner        *uuid.UUID       `json:"winner,omitempty"`        // First player to sink the whole enemy fleet
+}
+
+// BattleshipShip is a placed ship: its top-left square and whether it runs down or across
+type BattleshipShip struct {
+	Name     string `json:"name"`
+	Row      int    `json:"row"`
+	Col      int    `json:"col"`
+	Vertical bool   `json:"vertical"`
+}
+
+// BattleshipShot is a shot fired and its result
+type BattleshipShot struct {
+	Row    int    `json:"row"`
+	Col    int    `json:"col"`
+	Result string `json:"result"`         // "miss", "hit" or "sunk"
+	Ship   string `json:"ship,omitempty"` // Name of the ship a "sunk" shot sank
+}
+
+// BattleshipMove represents a move in Battleship: the whole fleet during setup, then a shot at Row/Col
+type BattleshipMove struct {
+	Ships []BattleshipShip `json:"ships,omitempty"`
+	Row   int              `json:"row"`
+	Col   int              `json:"col"`
+}
+
+// battleshipView is the state as seen by one viewer: only the viewer's own fleet is shown, but
+// everyone can see whether a player has placed their ships
+type battleshipView struct {
+	BattleshipState
+	Player1Ready bool `json:"player1_ready"` // Player 1 has placed their fleet
+	Player2Ready bool `json:"player2_ready"` // Player 2 has placed their fleet
+}
+
+func init() {
+	Register(Definition{
+		Type:        GameTypeBattleship,
+		Name:        "Battleship",
+		Description: "Place your fleet in secret, then take turns firing until one fleet is sunk",
+		MinPlayers:  2,
+		MaxPlayers:  2,
+		Settings:    []SettingSchema{},
+		New: func(player1ID, player2ID uuid.UUID, settings map[string]interface{}) GameState {
+			return NewBattleshipState(player1ID, player2ID)
+		},
+		Decode: decodeState[BattleshipState],
+		Bot:    newBattleshipBot,
+	})
+}
+
+// NewBattleshipState creates a new Battleship game in its setup phase
+func NewBattleshipState(player1ID, player2ID uuid.UUID) *BattleshipState {
+	return &BattleshipState{
+		Player1ID:     player1ID,
+		Player2ID:     player2ID,
+		CurrentPlayer: player1ID,
+		Phase:         BattleshipPhaseSetup,
+		BoardSize:     battleshipBoardSize,
+		Player1Shots:  []BattleshipShot{},
+		Player2Shots:  []BattleshipShot{},
+	}
+}
+
+// ValidateMove checks if a move is valid. During setup either player may place their fleet once.
+func (s *BattleshipState) ValidateMove(playerID uuid.UUID, move interface{}) error {
+	if s.Winner != nil {
+		return ErrGameAlreadyEnded
+	}
+	if playerID != s.Player1ID && playerID != s.Player2ID {
+		return ErrInvalidPlayer
+	}
+
+	battleshipMove, err := parseBattleshipMove(move)
+	if err != nil {
+		return err
+	}
+
+	if s.Phase == BattleshipPhaseSetup {
+		if s.SetupComplete(playerID) {
+			return errors.New("fleet already placed")
+		}
+		return validateFleet(battleshipMove.Ships, s.BoardSize)
+	}
+
+	if playerID != s.CurrentPlayer {
+		return ErrNotYourTurn
+	}
+	if len(battleshipMove.Ships) > 0 {
+		return errors.New("fleet already placed")
+	}
+	if battleshipMove.Row < 0 || battleshipMove.Row >= s.BoardSize || battleshipMove.Col < 0 || battleshipMove.Col >= s.BoardSize {
+		return errors.New("shot out of bounds")
+	}
+	for _, shot := range *s.shotsOf(playerID) {
+		if shot.Row == battleshipMove.Row && shot.Col == battleshipMove.Col {
+			return errors.New("already fired at that square")
+		}
+	}
+
+	return nil
+}
+
+// validateFleet checks that ships are exactly the fleet, inside the board and not overlapping
+func validateFleet(ships []BattleshipShip, boardSize int) error {
+	if len(ships) != len(battleshipFleet) {
+		return fmt.Errorf("place all %d ships", len(battleshipFleet))
+	}
+
+	placed := make(map[string]bool)
+	occupied := make(map[[2]int]bool)
+	for _, ship := range ships {
+		length := battleshipShipLength(ship.Name)
+		if length == 0 {
+			return fmt.Errorf("unknown ship: %s", ship.Name)
+		}
+		if placed[ship.Name] {
+			return fmt.Errorf("%s placed twice", ship.Name)
+		}
+		placed[ship.Name] = true
+
+		for _, square := range shipSquares(ship) {
+			if square[0] < 0 || square[0] >= boardSize || square[1] < 0 || square[1] >= boardSize {
+				return fmt.Errorf("%s does not fit on the board", ship.Name)
+			}
+			if occupied[square] {
+				return fmt.Errorf("%s overlaps another ship", ship.Name)
+			}
+			occupied[square] = true
+		}
+	}
+
+	return nil
+}
+
+// ApplyMove places a fleet during setup, or fires a shot and records whether it hit
+func (s *BattleshipState) ApplyMove(playerID uuid.UUID, move interface{}) error {
+	if err := s.ValidateMove(playerID, move); err != nil {
+		return err
+	}
+
+	battleshipMove, err := parseBattleshipMove(move)
+	if err != nil {
+		return err
+	}
+
+	if s.Phase == BattleshipPhaseSetup {
+		*s.fleetOf(playerID) = append([]BattleshipShip(nil), battleshipMove.Ships...)
+		switch {
+		case !s.SetupComplete(s.Player1ID):
+			s.CurrentPlayer = s.Player1ID
+		case !s.SetupComplete(s.Player2ID):
+			s.CurrentPlayer = s.Player2ID
+		default:
+			// Both fleets are placed: player 1 fires first
+			s.Phase = BattleshipPhasePlay
+			s.CurrentPlayer = s.Player1ID
+		}
+		return nil
+	}
+
+	opponent := s.opponentOf(playerID)
+	shots := s.shotsOf(playerID)
+	shot := BattleshipShot{Row: battleshipMove.Row, Col: battleshipMove.Col, Result: BattleshipMiss}
+	if ship := shipAt(*s.fleetOf(opponent), shot.Row, shot.Col); ship != nil {
+		shot.Result = BattleshipHit
+		if isSunk(*ship, append(*shots, shot)) {
+			shot.Result = BattleshipSunk
+			shot.Ship = ship.Name
+		}
+	}
+	*shots = append(*shots, shot)
+
+	if s.fleetSunk(opponent) {
+		winner := playerID
+		s.Winner = &winner
+	} else {
+		s.CurrentPlayer = opponent
+	}
+
+	return nil
+}
+
+// CheckWinner checks if a fleet has been sunk
+func (s *BattleshipState) CheckWinner() (winner *uuid.UUID, gameOver bool) {
+	if s.Winner != nil {
+		return s.Winner, true
+	}
+	return nil, false
+}
+
+// InSetup reports whether the players are still placing their fleets
+func (s *BattleshipState) InSetup() bool {
+	return s.Phase == BattleshipPhaseSetup
+}
+
+// SetupComplete reports whether a player has placed their fleet
+func (s *BattleshipState) SetupComplete(playerID uuid.UUID) bool {
+	if fleet := s.fleetOf(playerID); fleet != nil {
+		return len(*fleet) > 0
+	}
+	return false
+}
+
+// LegalMoves returns every square the player to move has not fired at yet. Fleet placements
+// during setup are not enumerated.
+func (s *BattleshipState) LegalMoves() []interface{} {
+	moves := []interface{}{}
+	if s.Phase != BattleshipPhasePlay || s.Winner != nil {
+		return moves
+	}
+
+	fired := make(map[[2]int]bool)
+	for _, shot := range *s.shotsOf(s.CurrentPlayer) {
+		fired[[2]int{shot.Row, shot.Col}] = true
+	}
+	for row := 0; row < s.BoardSize; row++ {
+		for col := 0; col < s.BoardSize; col++ {
+			if !fired[[2]int{row, col}] {
+				moves = append(moves, BattleshipMove{Row: row, Col: col})
+			}
+		}
+	}
+	return moves
+}
+
+// fleetSunk reports whether every ship of a player has been sunk
+func (s *BattleshipState) fleetSunk(playerID uuid.UUID) bool {
+	fleet := *s.fleetOf(playerID)
+	shots := *s.shotsOf(s.opponentOf(playerID))
+	for _, ship := range fleet {
+		if !isSunk(ship, shots) {
+			return false
+		}
+	}
+	return len(fleet) > 0
+}
+
+// fleetOf returns a pointer to a player's fleet, or nil for someone who is not a player
+func (s *BattleshipState) fleetOf(playerID uuid.UUID) *[]BattleshipShip {
+	switch playerID {
+	case s.Player1ID:
+		return &s.Player1Fleet
+	case s.Player2ID:
+		return &s.Player2Fleet
+	}
+	return nil
+}
+
+// shotsOf returns a pointer to the shots a player has fired
+func (s *BattleshipState) shotsOf(playerID uuid.UUID) *[]BattleshipShot {
+	if playerID == s.Player1ID {
+		return &s.Player1Shots
+	}
+	return &s.Player2Shots
+}
+
+// opponentOf returns the other player
+func (s *BattleshipState) opponentOf(playerID uuid.UUID) uuid.UUID {
+	if playerID == s.Player1ID {
+		return s.Player2ID
+	}
+	return s.Player1ID
+}
+
+// battleshipShipLength returns the length of a ship in the fleet, or 0 for an unknown name
+func battleshipShipLength(name string) int {
+	for _, ship := range battleshipFleet {
+		if ship.name == name {
+			return ship.length
+		}
+	}
+	return 0
+}
+
+// shipSquares returns the squares a ship covers
+func shipSquares(ship BattleshipShip) [][2]int {
+	length := battleshipShipLength(ship.Name)
+	squares := make([][2]int, length)
+	for i := range squares {
+		if ship.Vertical {
+			squares[i] = [2]int{ship.Row + i, ship.Col}
+		} else {
+			squares[i] = [2]int{ship.Row, ship.Col + i}
+		}
+	}
+	return squares
+}
+
+// shipAt returns the ship covering a square, or nil
+func shipAt(fleet []BattleshipShip, row, col int) *BattleshipShip {
+	for i := range fleet {
+		for _, square := range shipSquares(fleet[i]) {
+			if square[0] == row && square[1] == col {
+				return &fleet[i]
+			}
+		}
+	}
+	return nil
+}
+
+// isSunk reports whether every square of a ship has been fired at
+func isSunk(ship BattleshipShip, shots []BattleshipShot) bool {
+	for _, square := range shipSquares(ship) {
+		hit := false
+		for _, shot := range shots {
+			if shot.Row == square[0] && shot.Col == square[1] {
+				hit = true
+				break
+			}
+		}
+		if !hit {
+			return false
+		}
+	}
+	return true
+}
+
+// GetCurrentPlayer returns the ID of the player whose turn it is; during setup, a player who
+// still has to place their fleet
+func (s *BattleshipState) GetCurrentPlayer() uuid.UUID {
+	return s.CurrentPlayer
+}
+
+// SetPlayer2 seats the second player when they join a waiting game
+func (s *BattleshipState) SetPlayer2(playerID uuid.UUID) {
+	s.Player2ID = playerID
+}
+
+// GetState returns the current game state for serialization
+func (s *BattleshipState) GetState() interface{} {
+	if s.Player1Shots == nil {
+		s.Player1Shots = []BattleshipShot{}
+	}
+	if s.Player2Shots == nil {
+		s.Player2Shots = []BattleshipShot{}
+	}
+	return s
+}
+
+// StateFor hides the opponent's fleet: a player sees their own ships and the results of every
+// shot, spectators see only the shots
+func (s *BattleshipState) StateFor(viewerID uuid.UUID) interface{} {
+	view := battleshipView{
+		BattleshipState: *s.GetState().(*BattleshipState),
+		Player1Ready:    s.SetupComplete(s.Player1ID),
+		Player2Ready:    s.SetupComplete(s.Player2ID),
+	}
+	if viewerID == uuid.Nil || viewerID != s.Player1ID {
+		view.Player1Fleet = nil
+	}
+	if viewerID == uuid.Nil || viewerID != s.Player2ID {
+		view.Player2Fleet = nil
+	}
+	return view
+}
+
+// Clone creates a deep copy of the game state
+func (s *BattleshipState) Clone() GameState {
+	newState := *s
+	newState.Player1Fleet = append([]BattleshipShip(nil), s.Player1Fleet...)
+	newState.Player2Fleet = append([]BattleshipShip(nil), s.Player2Fleet...)
+	newState.Player1Shots = append([]BattleshipShot{}, s.Player1Shots...)
+	newState.Player2Shots = append([]BattleshipShot{}, s.Player2Shots...)
+	if s.Winner != nil {
+		winner := *s.Winner
+		newState.Winner = &winner
+	}
+	return &newState
+}
+
+// parseBattleshipMove parses a move from various formats
+func parseBattleshipMove(move interface{}) (*BattleshipMove, error) {
+	if m, ok := move.(*BattleshipMove); ok {
+		return m, nil
+	}
+
+	if m, ok := move.(BattleshipMove); ok {
+		return &m, nil
+	}
+
+	jsonData, err := json.Marshal(move)
+	if err != nil {
+		return nil, ErrInvalidMove
+	}
+
+	var battleshipMove BattleshipMove
+	if err := json.Unmarshal(jsonData, &battleshipMove); err != nil {
+		return nil, ErrInvalidMove
+	}
+
+	return &battleshipMove, nil
+}
@@ -0,0 +1,228 @@
+package game
+
+import (
+	"math/rand"
+
+	"github.com/google/uuid"
+)
+
+// battleshipBot places a random fleet and then hunts the opponent's ships. It only looks at its
+// own shots and their results, never at the opponent's fleet in the state it is given.
+type battleshipBot struct {
+	difficulty BotDifficulty
+	rng        *rand.Rand
+}
+
+func newBattleshipBot(difficulty BotDifficulty, rng *rand.Rand) Bot {
+	return &battleshipBot{difficulty: difficulty, rng: rng}
+}
+
+// ChooseMove places the bot's fleet during setup, otherwise picks a square to fire at
+func (b *battleshipBot) ChooseMove(state GameState, botID uuid.UUID) (interface{}, error) {
+	s, ok := state.(*BattleshipState)
+	if !ok {
+		return nil, ErrInvalidMove
+	}
+
+	if s.Phase == BattleshipPhaseSetup {
+		if s.SetupComplete(botID) {
+			return nil, ErrNotYourTurn
+		}
+		return BattleshipMove{Ships: b.randomFleet(s.BoardSize)}, nil
+	}
+	if s.CurrentPlayer != botID {
+		return nil, ErrNotYourTurn
+	}
+
+	shots := *s.shotsOf(botID)
+	var target [2]int
+	var found bool
+	switch b.difficulty {
+	case BotEasy:
+		target, found = b.randomSquare(s.BoardSize, shots, false)
+	case BotMedium:
+		target, found = b.huntAndTarget(s.BoardSize, shots)
+	default:
+		target, found = b.mostLikelySquare(s.BoardSize, shots)
+	}
+	if !found {
+		return nil, ErrNoLegalMoves
+	}
+	return BattleshipMove{Row: target[0], Col: target[1]}, nil
+}
+
+// randomFleet places every ship at a random position that fits
+func (b *battleshipBot) randomFleet(boardSize int) []BattleshipShip {
+	for {
+		ships := make([]BattleshipShip, 0, len(battleshipFleet))
+		occupied := make(map[[2]int]bool)
+		for _, spec := range battleshipFleet {
+			for attempt := 0; attempt < 100; attempt++ {
+				ship := BattleshipShip{Name: spec.name, Vertical: b.rng.Intn(2) == 0}
+				if ship.Vertical {
+					ship.Row, ship.Col = b.rng.Intn(boardSize-spec.length+1), b.rng.Intn(boardSize)
+				} else {
+					ship.Row, ship.Col = b.rng.Intn(boardSize), b.rng.Intn(boardSize-spec.length+1)
+				}
+				squares := shipSquares(ship)
+				free := true
+				for _, square := range squares {
+					free = free && !occupied[square]
+				}
+				if free {
+					for _, square := range squares {
+						occupied[square] = true
+					}
+					ships = append(ships, ship)
+					break
+				}
+			}
+		}
+		if len(ships) == len(battleshipFleet) {
+			return ships
+		}
+	}
+}
+
+// randomSquare picks a square not fired at yet, optionally only from a checkerboard pattern,
+// which still finds every ship since none is shorter than two squares
+func (b *battleshipBot) randomSquare(boardSize int, shots []BattleshipShot, parity bool) ([2]int, bool) {
+	fired := firedSquares(shots)
+	var squares [][2]int
+	for row := 0; row < boardSize; row++ {
+		for col := 0; col < boardSize; col++ {
+			if !fired[[2]int{row, col}] && (!parity || (row+col)%2 == 0) {
+				squares = append(squares, [2]int{row, col})
+			}
+		}
+	}
+	if len(squares) == 0 {
+		if parity {
+			return b.randomSquare(boardSize, shots, false)
+		}
+		return [2]int{}, false
+	}
+	return squares[b.rng.Intn(len(squares))], true
+}
+
+// huntAndTarget fires next to hits that have not been accounted for by a sunk ship, and
+// otherwise hunts on a checkerboard pattern
+func (b *battleshipBot) huntAndTarget(boardSize int, shots []BattleshipShot) ([2]int, bool) {
+	if unresolvedHits(shots) {
+		fired := firedSquares(shots)
+		var candidates [][2]int
+		for _, shot := range shots {
+			if shot.Result != BattleshipHit {
+				continue
+			}
+			for _, dir := range [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+				square := [2]int{shot.Row + dir[0], shot.Col + dir[1]}
+				if square[0] >= 0 && square[0] < boardSize && square[1] >= 0 && square[1] < boardSize && !fired[square] {
+					candidates = append(candidates, square)
+				}
+			}
+		}
+		if len(candidates) > 0 {
+			return candidates[b.rng.Intn(len(candidates))], true
+		}
+	}
+	return b.randomSquare(boardSize, shots, true)
+}
+
+// mostLikelySquare counts, for every square, the placements of the ships still afloat that
+// cover it and agree with the shots so far. Placements through unaccounted hits count far more,
+// so the bot finishes off a ship it has found before hunting for the next one.
+func (b *battleshipBot) mostLikelySquare(boardSize int, shots []BattleshipShot) ([2]int, bool) {
+	fired := firedSquares(shots)
+	blocked := make(map[[2]int]bool) // Misses and squares of sunk ships cannot hold a ship afloat
+	hits := make(map[[2]int]bool)
+	sunk := make(map[string]bool)
+	for _, shot := range shots {
+		square := [2]int{shot.Row, shot.Col}
+		switch shot.Result {
+		case BattleshipMiss:
+			blocked[square] = true
+		case BattleshipHit:
+			hits[square] = true
+		case BattleshipSunk:
+			blocked[square] = true
+			sunk[shot.Ship] = true
+		}
+	}
+	targeting := unresolvedHits(shots)
+
+	scores := make(map[[2]int]int)
+	for _, spec := range battleshipFleet {
+		if sunk[spec.name] {
+			continue
+		}
+		for _, vertical := range []bool{false, true} {
+			for row := 0; row < boardSize; row++ {
+				for col := 0; col < boardSize; col++ {
+					squares := shipSquares(BattleshipShip{Name: spec.name, Row: row, Col: col, Vertical: vertical})
+					fits, covered := true, 0
+					for _, square := range squares {
+						if square[0] >= boardSize || square[1] >= boardSize || blocked[square] {
+							fits = false
+							break
+						}
+						if hits[square] {
+							covered++
+						}
+					}
+					if !fits || (targeting && covered == 0) {
+						continue
+					}
+					weight := 1 + 20*covered
+					for _, square := range squares {
+						if !fired[square] {
+							scores[square] += weight
+						}
+					}
+				}
+			}
+		}
+	}
+
+	var best [][2]int
+	bestScore := 0
+	for row := 0; row < boardSize; row++ {
+		for col := 0; col < boardSize; col++ {
+			square := [2]int{row, col}
+			switch score := scores[square]; {
+			case score > bestScore:
+				bestScore, best = score, [][2]int{square}
+			case score == bestScore && score > 0:
+				best = append(best, square)
+			}
+		}
+	}
+	if len(best) == 0 {
+		return b.randomSquare(boardSize, shots, false)
+	}
+	return best[b.rng.Intn(len(best))], true
+}
+
+// unresolvedHits reports whether some hits are not yet explained by the ships sunk so far
+func unresolvedHits(shots []BattleshipShot) bool {
+	hits, sunkLength := 0, 0
+	for _, shot := range shots {
+		switch shot.Result {
+		case BattleshipHit:
+			hits++
+		case BattleshipSunk:
+			hits++
+			sunkLength += battleshipShipLength(shot.Ship)
+		}
+	}
+	return hits > sunkLength
+}
+
+// firedSquares returns the set of squares already fired at
+func firedSquares(shots []BattleshipShot) map[[2]int]bool {
+	fired := make(map[[2]int]bool, len(shots))
+	for _, shot := range shots {
+		fired[[2]int{shot.Row, shot.Col}] = true
+	}
+	return fired
+}
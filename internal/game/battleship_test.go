@@ -0,0 +1,205 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// testFleet returns a valid fleet with every ship across a row of its own, starting at col
+func testFleet(col int) []BattleshipShip {
+	return []BattleshipShip{
+		{Name: "carrier", Row: 0, Col: col},
+		{Name: "battleship", Row: 2, Col: col},
+		{Name: "cruiser", Row: 4, Col: col},
+		{Name: "submarine", Row: 6, Col: col},
+		{Name: "destroyer", Row: 8, Col: col},
+	}
+}
+
+// placedBattleship returns a game in its play phase with both test fleets placed
+func placedBattleship(t *testing.T, player1, player2 uuid.UUID) *BattleshipState {
+	state := NewBattleshipState(player1, player2)
+	assert.NoError(t, state.ApplyMove(player1, BattleshipMove{Ships: testFleet(0)}))
+	assert.NoError(t, state.ApplyMove(player2, BattleshipMove{Ships: testFleet(5)}))
+	return state
+}
+
+// TestBattleshipSetup tests the simultaneous fleet placement phase
+func TestBattleshipSetup(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	t.Run("Either Player Places First", func(t *testing.T) {
+		state := NewBattleshipState(player1, player2)
+		assert.True(t, state.InSetup())
+
+		assert.NoError(t, state.ApplyMove(player2, BattleshipMove{Ships: testFleet(0)}))
+		assert.True(t, state.SetupComplete(player2))
+		assert.False(t, state.SetupComplete(player1))
+		assert.True(t, state.InSetup())
+		assert.Equal(t, player1, state.GetCurrentPlayer())
+
+		assert.NoError(t, state.ApplyMove(player1, BattleshipMove{Ships: testFleet(3)}))
+		assert.False(t, state.InSetup())
+		assert.Equal(t, BattleshipPhasePlay, state.Phase)
+		assert.Equal(t, player1, state.GetCurrentPlayer())
+	})
+
+	t.Run("Waits For The Other Fleet", func(t *testing.T) {
+		state := NewBattleshipState(player1, player2)
+		assert.NoError(t, state.ApplyMove(player1, BattleshipMove{Ships: testFleet(0)}))
+		assert.Equal(t, player2, state.GetCurrentPlayer())
+
+		err := state.ValidateMove(player1, BattleshipMove{Ships: testFleet(0)})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already placed")
+		assert.Error(t, state.ValidateMove(player1, BattleshipMove{Row: 0, Col: 0}))
+		assert.Empty(t, state.LegalMoves())
+	})
+
+	t.Run("Invalid Fleets", func(t *testing.T) {
+		state := NewBattleshipState(player1, player2)
+
+		assert.Error(t, state.ValidateMove(player1, BattleshipMove{Ships: testFleet(0)[:4]}), "missing ship")
+
+		offBoard := testFleet(6) // The carrier needs columns 6-10
+		assert.Contains(t, state.ValidateMove(player1, BattleshipMove{Ships: offBoard}).Error(), "does not fit")
+
+		overlapping := testFleet(0)
+		overlapping[4] = BattleshipShip{Name: "destroyer", Row: 0, Col: 9, Vertical: true}
+		overlapping[0].Col = 5
+		assert.Contains(t, state.ValidateMove(player1, BattleshipMove{Ships: overlapping}).Error(), "overlaps")
+
+		duplicate := testFleet(0)
+		duplicate[4].Name = "cruiser"
+		assert.Contains(t, state.ValidateMove(player1, BattleshipMove{Ships: duplicate}).Error(), "twice")
+
+		unknown := testFleet(0)
+		unknown[4].Name = "rowboat"
+		assert.Contains(t, state.ValidateMove(player1, BattleshipMove{Ships: unknown}).Error(), "unknown ship")
+
+		assert.Equal(t, ErrInvalidPlayer, state.ValidateMove(uuid.New(), BattleshipMove{Ships: testFleet(0)}))
+	})
+}
+
+// TestBattleshipShots tests firing at the enemy fleet
+func TestBattleshipShots(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	t.Run("Miss, Hit And Sunk", func(t *testing.T) {
+		state := placedBattleship(t, player1, player2)
+
+		assert.NoError(t, state.ApplyMove(player1, BattleshipMove{Row: 9, Col: 9}))
+		assert.Equal(t, BattleshipMiss, state.Player1Shots[0].Result)
+		assert.Equal(t, player2, state.CurrentPlayer)
+
+		assert.NoError(t, state.ApplyMove(player2, BattleshipMove{Row: 8, Col: 0}))
+		assert.Equal(t, BattleshipHit, state.Player2Shots[0].Result)
+		assert.Equal(t, player1, state.CurrentPlayer, "turns alternate after a hit")
+
+		assert.NoError(t, state.ApplyMove(player1, BattleshipMove{Row: 9, Col: 8}))
+		assert.NoError(t, state.ApplyMove(player2, BattleshipMove{Row: 8, Col: 1}))
+		assert.Equal(t, BattleshipShot{Row: 8, Col: 1, Result: BattleshipSunk, Ship: "destroyer"}, state.Player2Shots[1])
+	})
+
+	t.Run("Turn And Square Checks", func(t *testing.T) {
+		state := placedBattleship(t, player1, player2)
+
+		assert.Equal(t, ErrNotYourTurn, state.ValidateMove(player2, BattleshipMove{Row: 0, Col: 0}))
+		assert.Contains(t, state.ValidateMove(player1, BattleshipMove{Row: 10, Col: 0}).Error(), "bounds")
+
+		assert.NoError(t, state.ApplyMove(player1, BattleshipMove{Row: 0, Col: 0}))
+		assert.NoError(t, state.ApplyMove(player2, BattleshipMove{Row: 0, Col: 0}))
+		err := state.ValidateMove(player1, BattleshipMove{Row: 0, Col: 0})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already fired")
+		assert.Len(t, state.LegalMoves(), 99)
+	})
+
+	t.Run("Sinking The Fleet Wins", func(t *testing.T) {
+		state := placedBattleship(t, player1, player2)
+
+		var misses []BattleshipMove
+		for col := 0; col < 10; col++ {
+			misses = append(misses, BattleshipMove{Row: 1, Col: col}, BattleshipMove{Row: 3, Col: col})
+		}
+		for _, ship := range testFleet(5) {
+			for _, square := range shipSquares(ship) {
+				_, gameOver := state.CheckWinner()
+				assert.False(t, gameOver)
+				assert.NoError(t, state.ApplyMove(player1, BattleshipMove{Row: square[0], Col: square[1]}))
+				if state.Winner == nil {
+					assert.NoError(t, state.ApplyMove(player2, misses[0]))
+					misses = misses[1:]
+				}
+			}
+		}
+
+		winner, gameOver := state.CheckWinner()
+		assert.True(t, gameOver)
+		assert.Equal(t, player1, *winner)
+		assert.Empty(t, state.LegalMoves())
+		assert.Equal(t, ErrGameAlreadyEnded, state.ValidateMove(player2, BattleshipMove{Row: 5, Col: 5}))
+	})
+
+	t.Run("Move From JSON", func(t *testing.T) {
+		state := NewBattleshipState(player1, player2)
+		var move map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(`{"ships":[
+			{"name":"carrier","row":0,"col":0,"vertical":true},
+			{"name":"battleship","row":0,"col":1,"vertical":true},
+			{"name":"cruiser","row":0,"col":2,"vertical":true},
+			{"name":"submarine","row":0,"col":3,"vertical":true},
+			{"name":"destroyer","row":0,"col":4,"vertical":true}]}`), &move))
+		assert.NoError(t, state.ApplyMove(player1, move))
+		assert.True(t, state.SetupComplete(player1))
+	})
+}
+
+// TestBattleshipStateFor tests that each player only sees their own fleet
+func TestBattleshipStateFor(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+	state := NewBattleshipState(player1, player2)
+	assert.NoError(t, state.ApplyMove(player1, BattleshipMove{Ships: testFleet(0)}))
+
+	view := state.StateFor(player2).(battleshipView)
+	assert.Nil(t, view.Player1Fleet)
+	assert.True(t, view.Player1Ready)
+	assert.False(t, view.Player2Ready)
+
+	assert.NoError(t, state.ApplyMove(player2, BattleshipMove{Ships: testFleet(5)}))
+	assert.NoError(t, state.ApplyMove(player1, BattleshipMove{Row: 0, Col: 5}))
+
+	view = state.StateFor(player1).(battleshipView)
+	assert.Len(t, view.Player1Fleet, 5)
+	assert.Nil(t, view.Player2Fleet)
+	assert.Equal(t, BattleshipHit, view.Player1Shots[0].Result, "hits on the opponent are revealed")
+
+	spectator := state.StateFor(uuid.Nil).(battleshipView)
+	assert.Nil(t, spectator.Player1Fleet)
+	assert.Nil(t, spectator.Player2Fleet)
+
+	data, err := json.Marshal(state.StateFor(player2))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "player1_fleet")
+	assert.Contains(t, string(data), "player2_fleet")
+	assert.Len(t, state.Player1Fleet, 5, "the state itself is not modified")
+}
+
+// TestBattleshipClone tests that clones do not share state
+func TestBattleshipClone(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+	state := placedBattleship(t, player1, player2)
+	clone := state.Clone().(*BattleshipState)
+
+	assert.NoError(t, clone.ApplyMove(player1, BattleshipMove{Row: 0, Col: 5}))
+	assert.Empty(t, state.Player1Shots)
+	clone.Player2Fleet[0].Row = 9
+	assert.Equal(t, 0, state.Player2Fleet[0].Row)
+}
@@ -0,0 +1,257 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/google/uuid"
+)
+
+// BotDifficulty selects how strongly a bot plays
+type BotDifficulty string
+
+const (
+	BotEasy   BotDifficulty = "easy"
+	BotMedium BotDifficulty = "medium"
+	BotHard   BotDifficulty = "hard"
+)
+
+// ErrNoLegalMoves is returned when a bot is asked to move in a position without legal moves
+var ErrNoLegalMoves = errors.New("no legal moves available")
+
+// Bot chooses moves for a computer-controlled player
+type Bot interface {
+	// ChooseMove returns a legal move for botID in state. The state must not be modified.
+	ChooseMove(state GameState, botID uuid.UUID) (interface{}, error)
+}
+
+// BotSeat records a bot occupying a seat in a game
+type BotSeat struct {
+	PlayerID   uuid.UUID     `json:"player_id"`
+	Difficulty BotDifficulty `json:"difficulty"`
+}
+
+// botAccount describes the user row a bot plays as (seeded by migrations/add_bot_users.sql)
+type botAccount struct {
+	id       uuid.UUID
+	username string
+	rating   int // Fixed rating used when a human plays a rated game against the bot
+}
+
+var botAccounts = map[BotDifficulty]botAccount{
+	BotEasy:   {id: uuid.MustParse("00000000-0000-4000-8000-00000000b001"), username: "Bot Easy", rating: 800},
+	BotMedium: {id: uuid.MustParse("00000000-0000-4000-8000-00000000b002"), username: "Bot Medium", rating: 1200},
+	BotHard:   {id: uuid.MustParse("00000000-0000-4000-8000-00000000b003"), username: "Bot Hard", rating: 1600},
+}
+
+// ParseBotDifficulty validates a difficulty name
+func ParseBotDifficulty(value string) (BotDifficulty, error) {
+	difficulty := BotDifficulty(value)
+	if _, ok := botAccounts[difficulty]; !ok {
+		return "", fmt.Errorf("unknown bot difficulty: %s", value)
+	}
+	return difficulty, nil
+}
+
+// BotPlayerID returns the user ID a bot of the given difficulty plays as
+func BotPlayerID(difficulty BotDifficulty) uuid.UUID {
+	return botAccounts[difficulty].id
+}
+
+// BotName returns the display name of a bot
+func BotName(difficulty BotDifficulty) string {
+	return botAccounts[difficulty].username
+}
+
+// BotRating returns the fixed rating of a bot
+func BotRating(difficulty BotDifficulty) int {
+	return botAccounts[difficulty].rating
+}
+
+// IsBotPlayer reports whether a user ID belongs to a bot
+func IsBotPlayer(playerID uuid.UUID) bool {
+	for _, account := range botAccounts {
+		if account.id == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+// NewBot creates a bot for a game type. rng drives any random choices; pass nil for a time-seeded source.
+func (r *Registry) NewBot(gameType GameType, difficulty BotDifficulty, rng *rand.Rand) (Bot, error) {
+	def, ok := r.Lookup(gameType)
+	if !ok {
+		return nil, fmt.Errorf("unsupported game type: %s", gameType)
+	}
+	if def.Bot == nil {
+		return nil, fmt.Errorf("game type %s has no bot", gameType)
+	}
+	if _, err := ParseBotDifficulty(string(difficulty)); err != nil {
+		return nil, err
+	}
+	if rng == nil {
+		rng = rand.New(rand.NewSource(rand.Int63()))
+	}
+	return def.Bot(difficulty, rng), nil
+}
+
+// NewBot creates a bot from the default registry
+func NewBot(gameType GameType, difficulty BotDifficulty, rng *rand.Rand) (Bot, error) {
+	return DefaultRegistry.NewBot(gameType, difficulty, rng)
+}
+
+// HasBot reports whether a game type in the default registry can be played against a bot
+func HasBot(gameType GameType) bool {
+	def, ok := Lookup(gameType)
+	return ok && def.Bot != nil
+}
+
+// winScore is the score of a won position in a search; wins found sooner score higher
+const winScore = 1_000_000
+
+// searchSpec adapts a turn-based game to alphaBeta
+type searchSpec struct {
+	moves    func(state GameState) []interface{}          // Legal moves, best guesses first for better pruning
+	evaluate func(state GameState, botID uuid.UUID) int // Heuristic score of a non-terminal position for botID
+}
+
+// alphaBeta scores state for botID with a depth-limited minimax search. The side to move maximizes
+// when it is the bot, so games where a player can move twice in a row are handled naturally.
+func alphaBeta(state GameState, botID uuid.UUID, spec searchSpec, depth, ply, alpha, beta int) int {
+	if winner, over := state.CheckWinner(); over {
+		switch {
+		case winner == nil:
+			return 0
+		case *winner == botID:
+			return winScore - ply
+		default:
+			return -(winScore - ply)
+		}
+	}
+	if depth == 0 {
+		return spec.evaluate(state, botID)
+	}
+
+	mover := state.GetCurrentPlayer()
+	maximizing := mover == botID
+	best := winScore + 1
+	if maximizing {
+		best = -best
+	}
+
+	for _, move := range spec.moves(state) {
+		child := state.Clone()
+		if err := child.ApplyMove(mover, move); err != nil {
+			continue
+		}
+		score := alphaBeta(child, botID, spec, depth-1, ply+1, alpha, beta)
+		if maximizing {
+			if score > best {
+				best = score
+			}
+			if best > alpha {
+				alpha = best
+			}
+		} else {
+			if score < best {
+				best = score
+			}
+			if best < beta {
+				beta = best
+			}
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	return best
+}
+
+// searchBestMove returns the root move with the best alphaBeta score. Equally scored moves are
+// chosen between at random so bots do not always play the same game.
+func searchBestMove(state GameState, botID uuid.UUID, spec searchSpec, depth int, rng *rand.Rand) (interface{}, error) {
+	var bestMoves []interface{}
+	bestScore := -(winScore + 1)
+
+	for _, move := range spec.moves(state) {
+		child := state.Clone()
+		if err := child.ApplyMove(botID, move); err != nil {
+			continue
+		}
+		score := alphaBeta(child, botID, spec, depth-1, 1, -(winScore + 1), winScore+1)
+		if score > bestScore {
+			bestScore = score
+			bestMoves = []interface{}{move}
+		} else if score == bestScore {
+			bestMoves = append(bestMoves, move)
+		}
+	}
+
+	if len(bestMoves) == 0 {
+		return nil, ErrNoLegalMoves
+	}
+	return bestMoves[rng.Intn(len(bestMoves))], nil
+}
+
+// randomOrWinningMove is the easy bot strategy: play a random move unless one wins on the spot
+func randomOrWinningMove(state GameState, botID uuid.UUID, moves []interface{}, rng *rand.Rand) (interface{}, error) {
+	if len(moves) == 0 {
+		return nil, ErrNoLegalMoves
+	}
+	for _, move := range moves {
+		child := state.Clone()
+		if err := child.ApplyMove(botID, move); err != nil {
+			continue
+		}
+		if winner, over := child.CheckWinner(); over && winner != nil && *winner == botID {
+			return move, nil
+		}
+	}
+	return moves[rng.Intn(len(moves))], nil
+}
+
+// scoreLines is a heuristic for "n in a row" boards: every window of winLength cells that holds
+// only one player's marks scores for that player, growing steeply with the number of marks.
+func scoreLines(board [][]string, winLength int, mine, theirs string) int {
+	score := 0
+	directions := [][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}}
+
+	for row := range board {
+		for col := range board[row] {
+			for _, dir := range directions {
+				endRow := row + dir[0]*(winLength-1)
+				endCol := col + dir[1]*(winLength-1)
+				if endRow < 0 || endRow >= len(board) || endCol < 0 || endCol >= len(board[row]) {
+					continue
+				}
+
+				own, opp := 0, 0
+				for i := 0; i < winLength; i++ {
+					switch board[row+dir[0]*i][col+dir[1]*i] {
+					case mine:
+						own++
+					case theirs:
+						opp++
+					}
+				}
+				if own > 0 && opp == 0 {
+					score += lineWeight(own)
+				} else if opp > 0 && own == 0 {
+					score -= lineWeight(opp)
+				}
+			}
+		}
+	}
+	return score
+}
+
+// lineWeight returns the heuristic value of a window holding n marks of one player
+func lineWeight(n int) int {
+	weight := 1
+	for i := 1; i < n; i++ {
+		weight *= 10
+	}
+	return weight
+}
@@ -0,0 +1,469 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// playBotGame plays a full game between two bots and returns the final state
+func playBotGame(t *testing.T, state GameState, bot1, bot2 Bot, player1, player2 uuid.UUID) GameState {
+	t.Helper()
+	for i := 0; i < 500; i++ {
+		if _, over := state.CheckWinner(); over {
+			return state
+		}
+		mover, bot := player1, bot1
+		if state.GetCurrentPlayer() == player2 {
+			mover, bot = player2, bot2
+		}
+		move, err := bot.ChooseMove(state.Clone(), mover)
+		require.NoError(t, err)
+		require.NoError(t, state.ApplyMove(mover, move))
+	}
+	t.Fatal("game did not finish")
+	return nil
+}
+
+// TestBotRegistry tests creating bots from the registry
+func TestBotRegistry(t *testing.T) {
+	t.Run("Every Built-in Game Has A Bot", func(t *testing.T) {
+		for _, gameType := range RegisteredTypes() {
+			assert.True(t, HasBot(gameType), gameType)
+			for _, difficulty := range []BotDifficulty{BotEasy, BotMedium, BotHard} {
+				bot, err := NewBot(gameType, difficulty, nil)
+				assert.NoError(t, err)
+				assert.NotNil(t, bot)
+			}
+		}
+	})
+
+	t.Run("Unknown Difficulty Rejected", func(t *testing.T) {
+		_, err := NewBot(GameTypeTicTacToe, BotDifficulty("grandmaster"), nil)
+		assert.Error(t, err)
+		_, err = ParseBotDifficulty("grandmaster")
+		assert.Error(t, err)
+	})
+
+	t.Run("Bot Accounts", func(t *testing.T) {
+		id := BotPlayerID(BotHard)
+		assert.True(t, IsBotPlayer(id))
+		assert.False(t, IsBotPlayer(uuid.New()))
+		assert.Equal(t, "Bot Hard", BotName(BotHard))
+		assert.Greater(t, BotRating(BotHard), BotRating(BotEasy))
+	})
+}
+
+// TestTicTacToeBot tests the Tic-Tac-Toe bot
+func TestTicTacToeBot(t *testing.T) {
+	human := uuid.New()
+	botID := BotPlayerID(BotHard)
+	rng := rand.New(rand.NewSource(1))
+
+	t.Run("Takes The Win", func(t *testing.T) {
+		state := NewTicTacToeState(human, botID)
+		state.Board = [][]string{{"X", "X", ""}, {"O", "O", ""}, {"X", "", ""}}
+		state.MoveCount = 5
+		state.CurrentPlayer = botID
+
+		bot, _ := NewBot(GameTypeTicTacToe, BotMedium, rng)
+		move, err := bot.ChooseMove(state, botID)
+		assert.NoError(t, err)
+		assert.Equal(t, TicTacToeMove{Row: 1, Col: 2}, move)
+	})
+
+	t.Run("Blocks A Threat", func(t *testing.T) {
+		state := NewTicTacToeState(human, botID)
+		state.Board = [][]string{{"X", "X", ""}, {"", "O", ""}, {"", "", ""}}
+		state.MoveCount = 3
+		state.CurrentPlayer = botID
+
+		bot, _ := NewBot(GameTypeTicTacToe, BotHard, rng)
+		move, err := bot.ChooseMove(state, botID)
+		assert.NoError(t, err)
+		assert.Equal(t, TicTacToeMove{Row: 0, Col: 2}, move)
+	})
+
+	t.Run("Hard Bots Draw Each Other", func(t *testing.T) {
+		player1 := BotPlayerID(BotHard)
+		player2 := BotPlayerID(BotMedium)
+		hard, _ := NewBot(GameTypeTicTacToe, BotHard, rng)
+		final := playBotGame(t, NewTicTacToeState(player1, player2), hard, hard, player1, player2)
+		winner, over := final.CheckWinner()
+		assert.True(t, over)
+		assert.Nil(t, winner)
+	})
+
+	t.Run("Hard Never Loses To Easy", func(t *testing.T) {
+		easy, _ := NewBot(GameTypeTicTacToe, BotEasy, rng)
+		hard, _ := NewBot(GameTypeTicTacToe, BotHard, rng)
+		for i := 0; i < 10; i++ {
+			final := playBotGame(t, NewTicTacToeState(human, botID), easy, hard, human, botID)
+			winner, _ := final.CheckWinner()
+			if winner != nil {
+				assert.Equal(t, botID, *winner)
+			}
+		}
+	})
+
+	t.Run("Custom Grid", func(t *testing.T) {
+		state := NewTicTacToeStateWithSize(human, botID, 5, 4)
+		medium, _ := NewBot(GameTypeTicTacToe, BotMedium, rng)
+		final := playBotGame(t, state, medium, medium, human, botID)
+		_, over := final.CheckWinner()
+		assert.True(t, over)
+	})
+
+	t.Run("Refuses To Move Out Of Turn", func(t *testing.T) {
+		bot, _ := NewBot(GameTypeTicTacToe, BotHard, rng)
+		_, err := bot.ChooseMove(NewTicTacToeState(human, botID), botID)
+		assert.ErrorIs(t, err, ErrNotYourTurn)
+	})
+}
+
+// TestConnect4Bot tests the Connect-4 bot
+func TestConnect4Bot(t *testing.T) {
+	human := uuid.New()
+	botID := BotPlayerID(BotMedium)
+	rng := rand.New(rand.NewSource(1))
+
+	t.Run("Blocks A Vertical Threat", func(t *testing.T) {
+		state := NewConnect4State(human, botID)
+		state.Board[5][3], state.Board[4][3], state.Board[3][3] = "R", "R", "R"
+		state.Board[5][0], state.Board[5][6] = "Y", "Y"
+		state.MoveCount = 5
+		state.CurrentPlayer = botID
+
+		bot, _ := NewBot(GameTypeConnect4, BotMedium, rng)
+		move, err := bot.ChooseMove(state, botID)
+		assert.NoError(t, err)
+		assert.Equal(t, Connect4Move{Column: 3}, move)
+	})
+
+	t.Run("Custom Board Size And Win Length", func(t *testing.T) {
+		state := NewConnect4StateWithSize(human, botID, 5, 5, 5)
+		easy, _ := NewBot(GameTypeConnect4, BotEasy, rng)
+		medium, _ := NewBot(GameTypeConnect4, BotMedium, rng)
+		final := playBotGame(t, state, easy, medium, human, botID)
+		_, over := final.CheckWinner()
+		assert.True(t, over)
+	})
+}
+
+// TestDotsAndBoxesBot tests the Dots & Boxes bot
+func TestDotsAndBoxesBot(t *testing.T) {
+	human := uuid.New()
+	botID := BotPlayerID(BotHard)
+	rng := rand.New(rand.NewSource(1))
+
+	t.Run("Takes An Offered Box", func(t *testing.T) {
+		state := NewDotsAndBoxesState(human, botID)
+		state.Lines = []Line{
+			{Row: 0, Col: 0, Orientation: LineHorizontal},
+			{Row: 1, Col: 0, Orientation: LineHorizontal},
+			{Row: 0, Col: 0, Orientation: LineVertical},
+		}
+		state.CurrentPlayer = botID
+
+		bot, _ := NewBot(GameTypeDotsAndBoxes, BotMedium, rng)
+		move, err := bot.ChooseMove(state, botID)
+		assert.NoError(t, err)
+		assert.Equal(t, DotsAndBoxesMove{Row: 0, Col: 1, Orientation: LineVertical}, move)
+	})
+
+	t.Run("Does Not Offer A Box While Safe Lines Remain", func(t *testing.T) {
+		state := NewDotsAndBoxesState(human, botID)
+		state.Lines = []Line{
+			{Row: 0, Col: 0, Orientation: LineHorizontal},
+			{Row: 0, Col: 0, Orientation: LineVertical},
+		}
+		state.CurrentPlayer = botID
+
+		bot, _ := NewBot(GameTypeDotsAndBoxes, BotMedium, rng)
+		for i := 0; i < 20; i++ {
+			move, err := bot.ChooseMove(state, botID)
+			assert.NoError(t, err)
+			board := newDotsBoard(state)
+			m := move.(DotsAndBoxesMove)
+			assert.False(t, board.opensScoring(dotsLine{m.Row, m.Col, m.Orientation}))
+		}
+	})
+
+	t.Run("Full Games", func(t *testing.T) {
+		easy, _ := NewBot(GameTypeDotsAndBoxes, BotEasy, rng)
+		hard, _ := NewBot(GameTypeDotsAndBoxes, BotHard, rng)
+		hardWins := 0
+		for i := 0; i < 5; i++ {
+			final := playBotGame(t, NewDotsAndBoxesState(human, botID), easy, hard, human, botID)
+			if winner, _ := final.CheckWinner(); winner != nil && *winner == botID {
+				hardWins++
+			}
+		}
+		assert.GreaterOrEqual(t, hardWins, 4)
+	})
+}
+
+// TestRPSBot tests the Rock-Paper-Scissors bot
+func TestRPSBot(t *testing.T) {
+	human := uuid.New()
+	botID := BotPlayerID(BotMedium)
+	rng := rand.New(rand.NewSource(1))
+
+	t.Run("Counters The Most Frequent Choice", func(t *testing.T) {
+		state := NewRPSStateWithBestOf(human, botID, 9)
+		state.Rounds = []RPSRound{
+			{RoundNumber: 1, Player1Choice: RPSChoiceRock, Player2Choice: RPSChoiceRock},
+			{RoundNumber: 2, Player1Choice: RPSChoiceRock, Player2Choice: RPSChoiceRock},
+			{RoundNumber: 3, Player1Choice: RPSChoiceScissors, Player2Choice: RPSChoiceScissors},
+		}
+		state.CurrentRound = 4
+
+		bot, _ := NewBot(GameTypeRockPaperScissors, BotMedium, rng)
+		move, err := bot.ChooseMove(state, botID)
+		assert.NoError(t, err)
+		assert.Equal(t, RPSMove{Choice: RPSChoicePaper}, move)
+	})
+
+	t.Run("Ignores The Opponent's Pending Choice", func(t *testing.T) {
+		state := NewRPSState(human, botID)
+		state.Player1Choice = RPSChoiceScissors
+
+		bot, _ := NewBot(GameTypeRockPaperScissors, BotMedium, rand.New(rand.NewSource(7)))
+		counts := make(map[RPSChoice]int)
+		for i := 0; i < 60; i++ {
+			move, err := bot.ChooseMove(state, botID)
+			assert.NoError(t, err)
+			counts[move.(RPSMove).Choice]++
+		}
+		assert.Len(t, counts, 3) // No history: plays at random rather than reading the pending choice
+	})
+
+	t.Run("Full Games", func(t *testing.T) {
+		easy, _ := NewBot(GameTypeRockPaperScissors, BotEasy, rng)
+		hard, _ := NewBot(GameTypeRockPaperScissors, BotHard, rng)
+		final := playBotGame(t, NewRPSState(human, botID), easy, hard, human, botID)
+		_, over := final.CheckWinner()
+		assert.True(t, over)
+	})
+}
+
+// TestGomokuBot tests the Gomoku bot
+func TestGomokuBot(t *testing.T) {
+	human := uuid.New()
+	botID := BotPlayerID(BotHard)
+	rng := rand.New(rand.NewSource(1))
+
+	t.Run("Blocks An Open Four", func(t *testing.T) {
+		state := NewGomokuState(human, botID)
+		playGomoku(t, state, [2]int{7, 3}, [2]int{0, 0}, [2]int{7, 4}, [2]int{0, 14}, [2]int{7, 5}, [2]int{14, 0}, [2]int{7, 6})
+
+		bot, _ := NewBot(GameTypeGomoku, BotHard, rng)
+		move, err := bot.ChooseMove(state, botID)
+		assert.NoError(t, err)
+		assert.Contains(t, []interface{}{GomokuMove{Row: 7, Col: 2}, GomokuMove{Row: 7, Col: 7}}, move)
+	})
+
+	t.Run("Takes The Win", func(t *testing.T) {
+		state := NewGomokuState(human, botID)
+		playGomoku(t, state, [2]int{7, 7}, [2]int{0, 0}, [2]int{14, 14}, [2]int{0, 1}, [2]int{8, 8}, [2]int{0, 2}, [2]int{14, 0}, [2]int{0, 3}, [2]int{9, 9})
+
+		bot, _ := NewBot(GameTypeGomoku, BotMedium, rng)
+		move, err := bot.ChooseMove(state, botID)
+		assert.NoError(t, err)
+		assert.Equal(t, GomokuMove{Row: 0, Col: 4}, move)
+	})
+
+	t.Run("Swap2 Opening", func(t *testing.T) {
+		state := NewGomokuStateWithOptions(human, botID, 15, GomokuRuleFreestyle, GomokuOpeningSwap2)
+		easy, _ := NewBot(GameTypeGomoku, BotEasy, rng)
+		medium, _ := NewBot(GameTypeGomoku, BotMedium, rng)
+		final := playBotGame(t, state, easy, medium, human, botID)
+		_, over := final.CheckWinner()
+		assert.True(t, over)
+	})
+}
+
+// TestCheckersBot tests the checkers bot
+func TestCheckersBot(t *testing.T) {
+	human := uuid.New()
+	botID := BotPlayerID(BotHard)
+	rng := rand.New(rand.NewSource(1))
+
+	t.Run("Does Not Hang A Piece", func(t *testing.T) {
+		state := emptyCheckersState(human, botID)
+		state.Board[2][1] = "w"
+		state.Board[0][7] = "w"
+		state.Board[4][3] = "b"
+		state.Board[7][0] = "b"
+		state.CurrentPlayer = botID
+
+		bot, _ := NewBot(GameTypeCheckers, BotMedium, rng)
+		for i := 0; i < 10; i++ {
+			move, err := bot.ChooseMove(state, botID)
+			assert.NoError(t, err)
+			assert.NotEqual(t, checkersPath(2, 1, 3, 2), move) // Black would jump it from 4,3
+		}
+	})
+
+	t.Run("Full Games", func(t *testing.T) {
+		easy, _ := NewBot(GameTypeCheckers, BotEasy, rng)
+		hard, _ := NewBot(GameTypeCheckers, BotHard, rng)
+		hardWins := 0
+		for i := 0; i < 3; i++ {
+			final := playBotGame(t, NewCheckersState(human, botID), easy, hard, human, botID)
+			if winner, _ := final.CheckWinner(); winner != nil && *winner == botID {
+				hardWins++
+			}
+		}
+		assert.GreaterOrEqual(t, hardWins, 2)
+	})
+}
+
+// TestOthelloBot tests the Othello bot
+func TestOthelloBot(t *testing.T) {
+	human := uuid.New()
+	botID := BotPlayerID(BotHard)
+	rng := rand.New(rand.NewSource(1))
+
+	t.Run("Takes A Corner", func(t *testing.T) {
+		state := NewOthelloStateWithSize(human, botID, 6)
+		state.Board = othelloBoard(
+			"......",
+			".B....",
+			"..BW..",
+			"..WW..",
+			"......",
+			"......",
+		)
+		state.BlackCount, state.WhiteCount = 2, 3
+		state.CurrentPlayer = botID
+
+		bot, _ := NewBot(GameTypeOthello, BotMedium, rng)
+		move, err := bot.ChooseMove(state, botID)
+		assert.NoError(t, err)
+		assert.Equal(t, OthelloMove{Row: 0, Col: 0}, move)
+	})
+
+	t.Run("Full Games", func(t *testing.T) {
+		easy, _ := NewBot(GameTypeOthello, BotEasy, rng)
+		hard, _ := NewBot(GameTypeOthello, BotHard, rng)
+		hardWins := 0
+		for i := 0; i < 3; i++ {
+			final := playBotGame(t, NewOthelloState(human, botID), easy, hard, human, botID)
+			if winner, _ := final.CheckWinner(); winner != nil && *winner == botID {
+				hardWins++
+			}
+		}
+		assert.GreaterOrEqual(t, hardWins, 2)
+	})
+
+	t.Run("Large Board", func(t *testing.T) {
+		medium, _ := NewBot(GameTypeOthello, BotMedium, rng)
+		final := playBotGame(t, NewOthelloStateWithSize(human, botID, 10), medium, medium, human, botID)
+		s := final.(*OthelloState)
+		assert.True(t, s.Finished)
+		assert.LessOrEqual(t, s.BlackCount+s.WhiteCount, 100)
+	})
+}
+
+// TestBattleshipBot tests the Battleship bot
+func TestBattleshipBot(t *testing.T) {
+	human := uuid.New()
+	botID := BotPlayerID(BotHard)
+	rng := rand.New(rand.NewSource(1))
+
+	t.Run("Places A Valid Fleet", func(t *testing.T) {
+		state := NewBattleshipState(human, botID)
+		bot, _ := NewBot(GameTypeBattleship, BotHard, rng)
+		for i := 0; i < 20; i++ {
+			move, err := bot.ChooseMove(state, botID)
+			assert.NoError(t, err)
+			assert.NoError(t, state.ValidateMove(botID, move))
+		}
+	})
+
+	t.Run("Finishes Off A Hit Ship", func(t *testing.T) {
+		state := placedBattleship(t, human, botID)
+		state.Player2Shots = []BattleshipShot{
+			{Row: 8, Col: 0, Result: BattleshipHit},
+			{Row: 7, Col: 0, Result: BattleshipMiss},
+			{Row: 9, Col: 0, Result: BattleshipMiss},
+		}
+		state.CurrentPlayer = botID
+
+		for _, difficulty := range []BotDifficulty{BotMedium, BotHard} {
+			bot, _ := NewBot(GameTypeBattleship, difficulty, rng)
+			move, err := bot.ChooseMove(state, botID)
+			assert.NoError(t, err)
+			assert.Equal(t, BattleshipMove{Row: 8, Col: 1}, move, difficulty)
+		}
+	})
+
+	t.Run("Full Games", func(t *testing.T) {
+		easy, _ := NewBot(GameTypeBattleship, BotEasy, rng)
+		hard, _ := NewBot(GameTypeBattleship, BotHard, rng)
+		hardWins := 0
+		for i := 0; i < 5; i++ {
+			final := playBotGame(t, NewBattleshipState(human, botID), easy, hard, human, botID)
+			if winner, _ := final.CheckWinner(); winner != nil && *winner == botID {
+				hardWins++
+			}
+		}
+		assert.GreaterOrEqual(t, hardWins, 4)
+	})
+}
+
+// TestRPSEliminationBot tests the RPS Elimination bot
+func TestRPSEliminationBot(t *testing.T) {
+	players := []uuid.UUID{uuid.New(), uuid.New(), BotPlayerID(BotMedium)}
+	botID := players[2]
+	rng := rand.New(rand.NewSource(1))
+
+	t.Run("Counters The Most Frequent Choice Of The Others", func(t *testing.T) {
+		state := NewRPSEliminationState(players)
+		state.Rounds = []RPSEliminationRound{
+			{RoundNumber: 1, Choices: map[uuid.UUID]RPSChoice{players[0]: RPSChoiceRock, players[1]: RPSChoiceRock, botID: RPSChoiceRock}},
+			{RoundNumber: 2, Choices: map[uuid.UUID]RPSChoice{players[0]: RPSChoiceRock, players[1]: RPSChoiceScissors, botID: RPSChoicePaper}},
+		}
+		state.CurrentRound = 3
+
+		bot, _ := NewBot(GameTypeRPSElimination, BotMedium, rng)
+		move, err := bot.ChooseMove(state, botID)
+		assert.NoError(t, err)
+		assert.Equal(t, RPSMove{Choice: RPSChoicePaper}, move)
+	})
+
+	t.Run("Eliminated Bots Do Not Move", func(t *testing.T) {
+		state := NewRPSEliminationState(players)
+		state.Eliminated[botID] = 1
+
+		bot, _ := NewBot(GameTypeRPSElimination, BotHard, rng)
+		_, err := bot.ChooseMove(state, botID)
+		assert.Error(t, err)
+	})
+
+	t.Run("Full Games", func(t *testing.T) {
+		four := []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+		bots := make(map[uuid.UUID]Bot)
+		for i, difficulty := range []BotDifficulty{BotEasy, BotMedium, BotHard, BotHard} {
+			bots[four[i]], _ = NewBot(GameTypeRPSElimination, difficulty, rng)
+		}
+
+		state := NewRPSEliminationState(four)
+		for i := 0; i < 500; i++ {
+			if _, over := state.CheckWinner(); over {
+				break
+			}
+			mover := state.GetCurrentPlayer()
+			move, err := bots[mover].ChooseMove(state.Clone(), mover)
+			require.NoError(t, err)
+			require.NoError(t, state.ApplyMove(mover, move))
+		}
+		_, over := state.CheckWinner()
+		assert.True(t, over)
+		assert.Len(t, state.Standings()[0], 1)
+	})
+}
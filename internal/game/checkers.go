@@ -0,0 +1,417 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	checkersSize = 8
+
+	// checkersQuietLimit is the 40-move rule: 40 moves by each player without a capture or a man moving
+	checkersQuietLimit = 80
+
+	// checkersRepetitionLimit is how many times a position may occur before the game is drawn
+	checkersRepetitionLimit = 3
+)
+
+// Checkers draw reasons
+const (
+	CheckersDrawRepetition = "repetition"
+	CheckersDrawFortyMove  = "forty_move"
+)
+
+// CheckersState represents the state of an English draughts game. Player 1 plays black, starts
+// on rows 5-7 and moves first towards row 0; player 2 plays white from rows 0-2.
+type CheckersState struct {
+	Board         [][]string      `json:"board"` // "b"/"w" men, "B"/"W" kings, "" empty; only squares with (row+col) odd are used
+	Player1ID     uuid.UUID       `json:"player1_id"`
+	Player2ID     uuid.UUID       `json:"player2_id"`
+	CurrentPlayer uuid.UUID       `json:"current_player"`
+	MoveCount     int             `json:"move_count"`
+	QuietMoves    int             `json:"quiet_moves"`         // Plies since the last capture or man move
+	Positions     PositionHistory `json:"positions"`           // Occurrences of each position since the last capture or man move
+	LastMove      *CheckersMove   `json:"last_move,omitempty"` // Path of the previous move
+	Winner        *uuid.UUID      `json:"winner,omitempty"`
+	DrawReason    string          `json:"draw_reason,omitempty"` // Set when the game is drawn
+	PositionHash  uint64          `json:"position_hash,string"`  // Zobrist hash of the pieces and side to move
+}
+
+// CheckersSquare identifies a square on the board
+type CheckersSquare struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+// CheckersMove represents a move in checkers: the moving piece's square followed by every square
+// it lands on. A multi-jump is a single move with one landing square per capture.
+type CheckersMove struct {
+	Path []CheckersSquare `json:"path"`
+}
+
+func init() {
+	Register(Definition{
+		Type:        GameTypeCheckers,
+		Name:        "Checkers",
+		Description: "English draughts: capture every enemy piece or leave your opponent without a move",
+		MinPlayers:  2,
+		MaxPlayers:  2,
+		Settings:    []SettingSchema{},
+		New: func(player1ID, player2ID uuid.UUID, settings map[string]interface{}) GameState {
+			return NewCheckersState(player1ID, player2ID)
+		},
+		Decode: decodeState[CheckersState],
+		Bot:    newCheckersBot,
+	})
+}
+
+// NewCheckersState creates a new checkers game in the starting position
+func NewCheckersState(player1ID, player2ID uuid.UUID) *CheckersState {
+	board := make([][]string, checkersSize)
+	for row := range board {
+		board[row] = make([]string, checkersSize)
+		for col := range board[row] {
+			if (row+col)%2 == 0 {
+				continue
+			}
+			switch {
+			case row < 3:
+				board[row][col] = "w"
+			case row >= checkersSize-3:
+				board[row][col] = "b"
+			}
+		}
+	}
+
+	state := &CheckersState{
+		Board:         board,
+		Player1ID:     player1ID,
+		Player2ID:     player2ID,
+		CurrentPlayer: player1ID,
+		Positions:     make(PositionHistory),
+	}
+	state.PositionHash = state.hashPosition()
+	state.Positions.Record(state.PositionHash)
+	return state
+}
+
+// ValidateMove checks if a move is valid. Captures are mandatory and a jump must continue while
+// the piece can keep capturing, so a move is valid exactly when it is one of LegalMoves.
+func (s *CheckersState) ValidateMove(playerID uuid.UUID, move interface{}) error {
+	if playerID != s.CurrentPlayer {
+		return ErrNotYourTurn
+	}
+	if _, gameOver := s.CheckWinner(); gameOver {
+		return ErrGameAlreadyEnded
+	}
+
+	checkersMove, err := parseCheckersMove(move)
+	if err != nil {
+		return err
+	}
+	if len(checkersMove.Path) < 2 {
+		return errors.New("a move needs a starting square and at least one landing square")
+	}
+	from := checkersMove.Path[0]
+	if !onCheckersBoard(from.Row, from.Col) || s.Board[from.Row][from.Col] == "" {
+		return errors.New("no piece on the starting square")
+	}
+	if !strings.EqualFold(s.Board[from.Row][from.Col], s.colorOf(playerID)) {
+		return errors.New("that is not your piece")
+	}
+
+	legal := s.legalPaths()
+	for _, path := range legal {
+		if samePath(path, checkersMove.Path) {
+			return nil
+		}
+	}
+	if len(legal) > 0 && isJump(legal[0][0], legal[0][1]) {
+		if !isJump(checkersMove.Path[0], checkersMove.Path[1]) {
+			return errors.New("a capture is available and must be taken")
+		}
+		return errors.New("invalid jump: every capture in the sequence must be taken")
+	}
+	return errors.New("invalid move")
+}
+
+// ApplyMove applies a move to the game state
+func (s *CheckersState) ApplyMove(playerID uuid.UUID, move interface{}) error {
+	if err := s.ValidateMove(playerID, move); err != nil {
+		return err
+	}
+
+	checkersMove, err := parseCheckersMove(move)
+	if err != nil {
+		return err
+	}
+	path := checkersMove.Path
+
+	from := path[0]
+	piece := s.Board[from.Row][from.Col]
+	irreversible := piece == "b" || piece == "w"
+	s.Board[from.Row][from.Col] = ""
+	for i := 1; i < len(path); i++ {
+		if isJump(path[i-1], path[i]) {
+			s.Board[(path[i-1].Row+path[i].Row)/2][(path[i-1].Col+path[i].Col)/2] = ""
+			irreversible = true
+		}
+	}
+	to := path[len(path)-1]
+	if (piece == "b" && to.Row == 0) || (piece == "w" && to.Row == checkersSize-1) {
+		piece = strings.ToUpper(piece)
+	}
+	s.Board[to.Row][to.Col] = piece
+
+	s.MoveCount++
+	s.LastMove = &CheckersMove{Path: append([]CheckersSquare(nil), path...)}
+	s.CurrentPlayer = s.opponentOf(playerID)
+
+	// Positions before an irreversible move can never occur again
+	if irreversible {
+		s.QuietMoves = 0
+		s.Positions = make(PositionHistory)
+	} else {
+		s.QuietMoves++
+	}
+	if s.Positions == nil {
+		s.Positions = make(PositionHistory)
+	}
+	s.PositionHash = s.hashPosition()
+	repetitions := s.Positions.Record(s.PositionHash)
+
+	switch {
+	case len(s.legalPaths()) == 0:
+		// The opponent has no pieces or no way to move them
+		winner := playerID
+		s.Winner = &winner
+	case repetitions >= checkersRepetitionLimit:
+		s.DrawReason = CheckersDrawRepetition
+	case s.QuietMoves >= checkersQuietLimit:
+		s.DrawReason = CheckersDrawFortyMove
+	}
+
+	return nil
+}
+
+// CheckWinner checks if there's a winner or if the game is a draw
+func (s *CheckersState) CheckWinner() (winner *uuid.UUID, gameOver bool) {
+	if s.Winner != nil {
+		return s.Winner, true
+	}
+	if s.DrawReason != "" {
+		return nil, true
+	}
+	return nil, false
+}
+
+// LegalMoves returns every legal move for the player to move. When a capture is available only
+// captures are listed, each as the complete jump sequence.
+func (s *CheckersState) LegalMoves() []interface{} {
+	moves := []interface{}{}
+	if _, gameOver := s.CheckWinner(); gameOver {
+		return moves
+	}
+	for _, path := range s.legalPaths() {
+		moves = append(moves, CheckersMove{Path: path})
+	}
+	return moves
+}
+
+// legalPaths returns the paths of every legal move for the player to move
+func (s *CheckersState) legalPaths() [][]CheckersSquare {
+	color := s.colorOf(s.CurrentPlayer)
+	var jumps, steps [][]CheckersSquare
+
+	for row := 0; row < checkersSize; row++ {
+		for col := 0; col < checkersSize; col++ {
+			if !strings.EqualFold(s.Board[row][col], color) {
+				continue
+			}
+			jumps = append(jumps, s.jumpSequences(row, col, []CheckersSquare{{row, col}})...)
+			if len(jumps) > 0 {
+				continue
+			}
+			for _, dir := range checkersDirections(s.Board[row][col]) {
+				r, c := row+dir[0], col+dir[1]
+				if onCheckersBoard(r, c) && s.Board[r][c] == "" {
+					steps = append(steps, []CheckersSquare{{row, col}, {r, c}})
+				}
+			}
+		}
+	}
+
+	if len(jumps) > 0 {
+		return jumps
+	}
+	return steps
+}
+
+// jumpSequences returns every complete jump sequence for the piece at (row, col), reached by path.
+// The board is modified while searching and restored before returning.
+func (s *CheckersState) jumpSequences(row, col int, path []CheckersSquare) [][]CheckersSquare {
+	piece := s.Board[row][col]
+	var sequences [][]CheckersSquare
+
+	for _, dir := range checkersDirections(piece) {
+		midRow, midCol := row+dir[0], col+dir[1]
+		toRow, toCol := row+2*dir[0], col+2*dir[1]
+		if !onCheckersBoard(toRow, toCol) || s.Board[toRow][toCol] != "" {
+			continue
+		}
+		captured := s.Board[midRow][midCol]
+		if captured == "" || strings.EqualFold(captured, piece) {
+			continue
+		}
+
+		next := append(append([]CheckersSquare(nil), path...), CheckersSquare{toRow, toCol})
+		crowned := (piece == "b" && toRow == 0) || (piece == "w" && toRow == checkersSize-1)
+
+		// Crowning ends the move; otherwise the piece must keep jumping while it can
+		s.Board[row][col], s.Board[midRow][midCol], s.Board[toRow][toCol] = "", "", piece
+		var further [][]CheckersSquare
+		if !crowned {
+			further = s.jumpSequences(toRow, toCol, next)
+		}
+		s.Board[row][col], s.Board[midRow][midCol], s.Board[toRow][toCol] = piece, captured, ""
+
+		if len(further) > 0 {
+			sequences = append(sequences, further...)
+		} else {
+			sequences = append(sequences, next)
+		}
+	}
+	return sequences
+}
+
+// checkersDirections returns the diagonal directions a piece moves in
+func checkersDirections(piece string) [][2]int {
+	switch piece {
+	case "b":
+		return [][2]int{{-1, -1}, {-1, 1}}
+	case "w":
+		return [][2]int{{1, -1}, {1, 1}}
+	}
+	return [][2]int{{-1, -1}, {-1, 1}, {1, -1}, {1, 1}}
+}
+
+// colorOf returns the man symbol of a player's pieces
+func (s *CheckersState) colorOf(playerID uuid.UUID) string {
+	if playerID == s.Player1ID {
+		return "b"
+	}
+	return "w"
+}
+
+// opponentOf returns the other player
+func (s *CheckersState) opponentOf(playerID uuid.UUID) uuid.UUID {
+	if playerID == s.Player1ID {
+		return s.Player2ID
+	}
+	return s.Player1ID
+}
+
+// Fingerprint returns the Zobrist hash of the position, see Fingerprinter
+func (s *CheckersState) Fingerprint() uint64 {
+	if s.PositionHash == 0 {
+		return s.hashPosition() // Saved before position hashes were stored
+	}
+	return s.PositionHash
+}
+
+// hashPosition computes the Zobrist hash of the pieces and the side to move, which is what
+// identifies a position for repetition
+func (s *CheckersState) hashPosition() uint64 {
+	return zobristBoard(zobristSalt(GameTypeCheckers), s.Board, sideToMove(s.CurrentPlayer, s.Player1ID))
+}
+
+// GetCurrentPlayer returns the ID of the player whose turn it is
+func (s *CheckersState) GetCurrentPlayer() uuid.UUID {
+	return s.CurrentPlayer
+}
+
+// SetPlayer2 seats the second player when they join a waiting game
+func (s *CheckersState) SetPlayer2(playerID uuid.UUID) {
+	s.Player2ID = playerID
+}
+
+// GetState returns the current game state for serialization
+func (s *CheckersState) GetState() interface{} {
+	if s.Positions == nil {
+		s.Positions = make(PositionHistory)
+	}
+	return s
+}
+
+// StateFor returns the full state: checkers has no hidden information
+func (s *CheckersState) StateFor(viewerID uuid.UUID) interface{} {
+	return s.GetState()
+}
+
+// Clone creates a deep copy of the game state
+func (s *CheckersState) Clone() GameState {
+	newState := *s
+	newState.Board = make([][]string, len(s.Board))
+	for i := range s.Board {
+		newState.Board[i] = make([]string, len(s.Board[i]))
+		copy(newState.Board[i], s.Board[i])
+	}
+	newState.Positions = s.Positions.Clone()
+	if s.LastMove != nil {
+		newState.LastMove = &CheckersMove{Path: append([]CheckersSquare(nil), s.LastMove.Path...)}
+	}
+	if s.Winner != nil {
+		winner := *s.Winner
+		newState.Winner = &winner
+	}
+	return &newState
+}
+
+// onCheckersBoard reports whether a square is on the board
+func onCheckersBoard(row, col int) bool {
+	return row >= 0 && row < checkersSize && col >= 0 && col < checkersSize
+}
+
+// isJump reports whether a step between two squares is a capture
+func isJump(from, to CheckersSquare) bool {
+	return to.Row-from.Row == 2 || from.Row-to.Row == 2
+}
+
+// samePath reports whether two paths visit the same squares
+func samePath(a, b []CheckersSquare) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// parseCheckersMove parses a move from various formats
+func parseCheckersMove(move interface{}) (*CheckersMove, error) {
+	if m, ok := move.(*CheckersMove); ok {
+		return m, nil
+	}
+
+	if m, ok := move.(CheckersMove); ok {
+		return &m, nil
+	}
+
+	jsonData, err := json.Marshal(move)
+	if err != nil {
+		return nil, ErrInvalidMove
+	}
+
+	var checkersMove CheckersMove
+	if err := json.Unmarshal(jsonData, &checkersMove); err != nil {
+		return nil, ErrInvalidMove
+	}
+
+	return &checkersMove, nil
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"math/rand"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// checkersBot plays checkers with an alpha-beta search over the legal moves
+type checkersBot struct {
+	difficulty BotDifficulty
+	rng        *rand.Rand
+}
+
+func newCheckersBot(difficulty BotDifficulty, rng *rand.Rand) Bot {
+	return &checkersBot{difficulty: difficulty, rng: rng}
+}
+
+// Material values used by the evaluation
+const (
+	checkersManValue  = 100
+	checkersKingValue = 160
+)
+
+var checkersSearch = searchSpec{
+	moves: func(state GameState) []interface{} {
+		return state.LegalMoves()
+	},
+	evaluate: checkersEvaluate,
+}
+
+// checkersEvaluate scores a position for botID by material, with a small bonus for men that are
+// close to being crowned
+func checkersEvaluate(state GameState, botID uuid.UUID) int {
+	s := state.(*CheckersState)
+	mine := s.colorOf(botID)
+
+	score := 0
+	for row := 0; row < checkersSize; row++ {
+		for col := 0; col < checkersSize; col++ {
+			piece := s.Board[row][col]
+			value := 0
+			switch piece {
+			case "":
+				continue
+			case "b":
+				value = checkersManValue + 2*(checkersSize-1-row)
+			case "w":
+				value = checkersManValue + 2*row
+			default:
+				value = checkersKingValue
+			}
+			if piece == mine || piece == strings.ToUpper(mine) {
+				score += value
+			} else {
+				score -= value
+			}
+		}
+	}
+	return score
+}
+
+// ChooseMove picks a move for the bot
+func (b *checkersBot) ChooseMove(state GameState, botID uuid.UUID) (interface{}, error) {
+	s, ok := state.(*CheckersState)
+	if !ok {
+		return nil, ErrInvalidMove
+	}
+	if s.CurrentPlayer != botID {
+		return nil, ErrNotYourTurn
+	}
+
+	switch b.difficulty {
+	case BotEasy:
+		return randomOrWinningMove(s, botID, s.LegalMoves(), b.rng)
+	case BotMedium:
+		return searchBestMove(s, botID, checkersSearch, 4, b.rng)
+	default:
+		return searchBestMove(s, botID, checkersSearch, 6, b.rng)
+	}
+}
@@ -0,0 +1,336 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// emptyCheckersState returns a game with no pieces on the board, ready for a custom position
+func emptyCheckersState(player1, player2 uuid.UUID) *CheckersState {
+	state := NewCheckersState(player1, player2)
+	for row := range state.Board {
+		for col := range state.Board[row] {
+			state.Board[row][col] = ""
+		}
+	}
+	state.Positions = make(PositionHistory)
+	return state
+}
+
+// checkersPath builds a move from row, col pairs
+func checkersPath(squares ...int) CheckersMove {
+	var path []CheckersSquare
+	for i := 0; i+1 < len(squares); i += 2 {
+		path = append(path, CheckersSquare{Row: squares[i], Col: squares[i+1]})
+	}
+	return CheckersMove{Path: path}
+}
+
+// TestNewCheckersState tests creating a new checkers game
+func TestNewCheckersState(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+	state := NewCheckersState(player1, player2)
+
+	assert.Len(t, state.Board, 8)
+	assert.Equal(t, player1, state.CurrentPlayer)
+
+	black, white := 0, 0
+	for row := range state.Board {
+		for col, piece := range state.Board[row] {
+			if piece != "" {
+				assert.Equal(t, 1, (row+col)%2, "pieces only stand on dark squares")
+			}
+			switch piece {
+			case "b":
+				black++
+			case "w":
+				white++
+			}
+		}
+	}
+	assert.Equal(t, 12, black)
+	assert.Equal(t, 12, white)
+	assert.Len(t, state.LegalMoves(), 7)
+}
+
+// TestCheckersValidateMove tests move validation
+func TestCheckersValidateMove(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	t.Run("Valid Move", func(t *testing.T) {
+		state := NewCheckersState(player1, player2)
+		assert.NoError(t, state.ValidateMove(player1, checkersPath(5, 0, 4, 1)))
+	})
+
+	t.Run("Wrong Player Turn", func(t *testing.T) {
+		state := NewCheckersState(player1, player2)
+		err := state.ValidateMove(player2, checkersPath(2, 1, 3, 0))
+		assert.Equal(t, ErrNotYourTurn, err)
+	})
+
+	t.Run("Men Cannot Move Backwards", func(t *testing.T) {
+		state := emptyCheckersState(player1, player2)
+		state.Board[4][3] = "b"
+		state.Board[0][1] = "w"
+		assert.Error(t, state.ValidateMove(player1, checkersPath(4, 3, 5, 4)))
+		assert.NoError(t, state.ValidateMove(player1, checkersPath(4, 3, 3, 4)))
+	})
+
+	t.Run("Cannot Move Opponent's Piece", func(t *testing.T) {
+		state := NewCheckersState(player1, player2)
+		err := state.ValidateMove(player1, checkersPath(2, 1, 3, 0))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not your piece")
+	})
+
+	t.Run("Capture Is Mandatory", func(t *testing.T) {
+		state := emptyCheckersState(player1, player2)
+		state.Board[5][2] = "b"
+		state.Board[4][3] = "w"
+		state.Board[7][6] = "b"
+
+		err := state.ValidateMove(player1, checkersPath(7, 6, 6, 5))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "must be taken")
+		assert.NoError(t, state.ValidateMove(player1, checkersPath(5, 2, 3, 4)))
+	})
+
+	t.Run("Multi-Jump Must Be Completed", func(t *testing.T) {
+		state := emptyCheckersState(player1, player2)
+		state.Board[7][0] = "b"
+		state.Board[6][1] = "w"
+		state.Board[4][3] = "w"
+
+		assert.Error(t, state.ValidateMove(player1, checkersPath(7, 0, 5, 2)))
+		assert.NoError(t, state.ValidateMove(player1, checkersPath(7, 0, 5, 2, 3, 4)))
+	})
+
+	t.Run("Malformed Path", func(t *testing.T) {
+		state := NewCheckersState(player1, player2)
+		assert.Error(t, state.ValidateMove(player1, checkersPath(5, 0)))
+		assert.Error(t, state.ValidateMove(player1, checkersPath(9, 0, 8, 1)))
+	})
+}
+
+// TestCheckersApplyMove tests applying moves
+func TestCheckersApplyMove(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	t.Run("Simple Move", func(t *testing.T) {
+		state := NewCheckersState(player1, player2)
+		assert.NoError(t, state.ApplyMove(player1, checkersPath(5, 0, 4, 1)))
+		assert.Equal(t, "", state.Board[5][0])
+		assert.Equal(t, "b", state.Board[4][1])
+		assert.Equal(t, player2, state.CurrentPlayer)
+		assert.Equal(t, 1, state.MoveCount)
+	})
+
+	t.Run("Multi-Jump Removes Every Captured Piece", func(t *testing.T) {
+		state := emptyCheckersState(player1, player2)
+		state.Board[7][0] = "b"
+		state.Board[6][1] = "w"
+		state.Board[4][3] = "w"
+		state.Board[0][7] = "w"
+
+		assert.NoError(t, state.ApplyMove(player1, checkersPath(7, 0, 5, 2, 3, 4)))
+		assert.Equal(t, "", state.Board[6][1])
+		assert.Equal(t, "", state.Board[4][3])
+		assert.Equal(t, "b", state.Board[3][4])
+		assert.Equal(t, 0, state.QuietMoves)
+	})
+
+	t.Run("Kinging", func(t *testing.T) {
+		state := emptyCheckersState(player1, player2)
+		state.Board[1][2] = "b"
+		state.Board[0][7] = "w"
+
+		assert.NoError(t, state.ApplyMove(player1, checkersPath(1, 2, 0, 1)))
+		assert.Equal(t, "B", state.Board[0][1])
+	})
+
+	t.Run("Crowning Ends The Jump", func(t *testing.T) {
+		state := emptyCheckersState(player1, player2)
+		state.Board[2][3] = "b"
+		state.Board[1][2] = "w"
+		state.Board[1][0] = "w" // Would be capturable by a king landing on 0,1
+		state.Board[7][0] = "w"
+
+		moves := state.LegalMoves()
+		assert.Equal(t, []interface{}{checkersPath(2, 3, 0, 1)}, moves)
+		assert.NoError(t, state.ApplyMove(player1, checkersPath(2, 3, 0, 1)))
+		assert.Equal(t, "B", state.Board[0][1])
+		assert.Equal(t, "w", state.Board[1][0])
+	})
+
+	t.Run("Kings Move Backwards", func(t *testing.T) {
+		state := emptyCheckersState(player1, player2)
+		state.Board[3][2] = "B"
+		state.Board[0][7] = "w"
+
+		assert.NoError(t, state.ApplyMove(player1, checkersPath(3, 2, 4, 3)))
+		assert.Equal(t, 1, state.QuietMoves)
+	})
+
+	t.Run("Move From JSON", func(t *testing.T) {
+		state := NewCheckersState(player1, player2)
+		var move map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(`{"path":[{"row":5,"col":2},{"row":4,"col":3}]}`), &move))
+		assert.NoError(t, state.ApplyMove(player1, move))
+		assert.Equal(t, "b", state.Board[4][3])
+	})
+}
+
+// TestCheckersCheckWinner tests game endings
+func TestCheckersCheckWinner(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	t.Run("Capturing The Last Piece Wins", func(t *testing.T) {
+		state := emptyCheckersState(player1, player2)
+		state.Board[5][2] = "b"
+		state.Board[4][3] = "w"
+
+		assert.NoError(t, state.ApplyMove(player1, checkersPath(5, 2, 3, 4)))
+		winner, gameOver := state.CheckWinner()
+		assert.True(t, gameOver)
+		assert.Equal(t, player1, *winner)
+		assert.Empty(t, state.LegalMoves())
+	})
+
+	t.Run("Blocked Player Loses", func(t *testing.T) {
+		state := emptyCheckersState(player1, player2)
+		state.Board[0][1] = "w"
+		state.Board[1][0] = "b"
+		state.Board[1][2] = "b"
+		state.Board[3][4] = "b"
+
+		// Black moves 3,4 to 2,3 and white's only man is left without a move
+		assert.NoError(t, state.ApplyMove(player1, checkersPath(3, 4, 2, 3)))
+		winner, gameOver := state.CheckWinner()
+		assert.True(t, gameOver)
+		assert.Equal(t, player1, *winner)
+	})
+
+	t.Run("Draw By Repetition", func(t *testing.T) {
+		state := emptyCheckersState(player1, player2)
+		state.Board[7][0] = "B"
+		state.Board[0][7] = "W"
+		state.Positions.Record(state.hashPosition())
+
+		shuffle := []struct {
+			player uuid.UUID
+			move   CheckersMove
+		}{
+			{player1, checkersPath(7, 0, 6, 1)},
+			{player2, checkersPath(0, 7, 1, 6)},
+			{player1, checkersPath(6, 1, 7, 0)},
+			{player2, checkersPath(1, 6, 0, 7)},
+		}
+		for i := 0; i < 2; i++ {
+			for _, step := range shuffle {
+				_, gameOver := state.CheckWinner()
+				assert.False(t, gameOver)
+				assert.NoError(t, state.ApplyMove(step.player, step.move))
+			}
+		}
+
+		winner, gameOver := state.CheckWinner()
+		assert.True(t, gameOver)
+		assert.Nil(t, winner)
+		assert.Equal(t, CheckersDrawRepetition, state.DrawReason)
+	})
+
+	t.Run("Draw By Forty-Move Rule", func(t *testing.T) {
+		state := emptyCheckersState(player1, player2)
+		state.Board[3][2] = "B"
+		state.Board[0][7] = "W"
+		state.QuietMoves = 79
+
+		assert.NoError(t, state.ApplyMove(player1, checkersPath(3, 2, 4, 3)))
+		winner, gameOver := state.CheckWinner()
+		assert.True(t, gameOver)
+		assert.Nil(t, winner)
+		assert.Equal(t, CheckersDrawFortyMove, state.DrawReason)
+	})
+
+	t.Run("Man Move Resets The Count", func(t *testing.T) {
+		state := NewCheckersState(player1, player2)
+		state.QuietMoves = 79
+
+		assert.NoError(t, state.ApplyMove(player1, checkersPath(5, 0, 4, 1)))
+		assert.Equal(t, 0, state.QuietMoves)
+		_, gameOver := state.CheckWinner()
+		assert.False(t, gameOver)
+	})
+
+	t.Run("No Moves After Game Ends", func(t *testing.T) {
+		state := NewCheckersState(player1, player2)
+		state.DrawReason = CheckersDrawFortyMove
+		assert.Equal(t, ErrGameAlreadyEnded, state.ValidateMove(player1, checkersPath(5, 0, 4, 1)))
+	})
+}
+
+// TestCheckersLegalMoves tests legal move enumeration
+func TestCheckersLegalMoves(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	t.Run("Only Captures When One Is Available", func(t *testing.T) {
+		state := emptyCheckersState(player1, player2)
+		state.Board[5][2] = "b"
+		state.Board[4][3] = "w"
+		state.Board[4][1] = "w"
+		state.Board[7][6] = "b"
+
+		moves := state.LegalMoves()
+		assert.ElementsMatch(t, []interface{}{checkersPath(5, 2, 3, 4), checkersPath(5, 2, 3, 0)}, moves)
+		for _, move := range moves {
+			assert.NoError(t, state.ValidateMove(player1, move))
+		}
+	})
+
+	t.Run("Branching Multi-Jumps", func(t *testing.T) {
+		state := emptyCheckersState(player1, player2)
+		state.Board[7][2] = "b"
+		state.Board[6][3] = "w"
+		state.Board[4][5] = "w"
+		state.Board[4][3] = "w"
+
+		assert.ElementsMatch(t, []interface{}{
+			checkersPath(7, 2, 5, 4, 3, 6),
+			checkersPath(7, 2, 5, 4, 3, 2),
+		}, state.LegalMoves())
+	})
+}
+
+// TestCheckersClone tests that clones do not share state
+func TestCheckersClone(t *testing.T) {
+	state := NewCheckersState(uuid.New(), uuid.New())
+	clone := state.Clone().(*CheckersState)
+
+	assert.NoError(t, clone.ApplyMove(clone.Player1ID, checkersPath(5, 0, 4, 1)))
+	assert.Equal(t, "b", state.Board[5][0])
+	assert.Equal(t, 0, state.MoveCount)
+	assert.Len(t, state.Positions, 1)
+	assert.Nil(t, state.LastMove)
+}
+
+// TestCheckersDecode tests restoring a checkers game from its serialized state
+func TestCheckersDecode(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+	state := NewCheckersState(player1, player2)
+	assert.NoError(t, state.ApplyMove(player1, checkersPath(5, 0, 4, 1)))
+
+	data, err := json.Marshal(state.GetState())
+	assert.NoError(t, err)
+	decoded, err := DefaultRegistry.DecodeState(GameTypeCheckers, data)
+	assert.NoError(t, err)
+	assert.Equal(t, state, decoded)
+}
@@ -0,0 +1,190 @@
+package game
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TimeControlType selects how a game's clocks run
+type TimeControlType string
+
+const (
+	TimeControlNone    TimeControlType = "none"     // No clocks
+	TimeControlTotal   TimeControlType = "total"    // Total time per player, with an optional Fischer increment per move
+	TimeControlPerMove TimeControlType = "per_move" // Fixed time for every move
+)
+
+// Time control setting keys, shared by every game type
+const (
+	SettingTimeControl   = "time_control"
+	SettingTimeTotal     = "time_total_seconds"
+	SettingTimeIncrement = "time_increment_seconds"
+	SettingTimePerMove   = "time_per_move_seconds"
+)
+
+// ErrTimeExpired is returned when a player moves after their clock has run out
+var ErrTimeExpired = errors.New("time has expired")
+
+// timeControlSettings are validated for every registered game type
+var timeControlSettings = []SettingSchema{
+	{Key: SettingTimeControl, Label: "Time control", Type: SettingTypeString, Default: string(TimeControlNone),
+		Options: []interface{}{string(TimeControlNone), string(TimeControlTotal), string(TimeControlPerMove)}},
+	{Key: SettingTimeTotal, Label: "Time per player (seconds)", Type: SettingTypeInt, Default: 300, Min: intPtr(30), Max: intPtr(7200)},
+	{Key: SettingTimeIncrement, Label: "Increment per move (seconds)", Type: SettingTypeInt, Default: 0, Min: intPtr(0), Max: intPtr(60)},
+	{Key: SettingTimePerMove, Label: "Time per move (seconds)", Type: SettingTypeInt, Default: 30, Min: intPtr(5), Max: intPtr(600)},
+}
+
+// CommonSettings returns the settings accepted by every game type: the time control and the
+// first-mover policy
+func CommonSettings() []SettingSchema {
+	settings := make([]SettingSchema, 0, len(timeControlSettings)+len(firstMoverSettings))
+	settings = append(settings, timeControlSettings...)
+	return append(settings, firstMoverSettings...)
+}
+
+// TimeControl describes the clock rules for a game
+type TimeControl struct {
+	Type             TimeControlType `json:"type"`
+	TotalSeconds     int             `json:"total_seconds,omitempty"`
+	IncrementSeconds int             `json:"increment_seconds,omitempty"`
+	PerMoveSeconds   int             `json:"per_move_seconds,omitempty"`
+}
+
+// TimeControlFromSettings reads the time control from validated game settings
+func TimeControlFromSettings(settings map[string]interface{}) TimeControl {
+	controlType, _ := settings[SettingTimeControl].(string)
+	switch TimeControlType(controlType) {
+	case TimeControlTotal:
+		total, _ := IntSetting(settings, SettingTimeTotal)
+		increment, _ := IntSetting(settings, SettingTimeIncrement)
+		return TimeControl{Type: TimeControlTotal, TotalSeconds: total, IncrementSeconds: increment}
+	case TimeControlPerMove:
+		perMove, _ := IntSetting(settings, SettingTimePerMove)
+		return TimeControl{Type: TimeControlPerMove, PerMoveSeconds: perMove}
+	}
+	return TimeControl{Type: TimeControlNone}
+}
+
+// GameClock tracks server-authoritative remaining time for each player.
+// Only one clock runs at a time; RemainingMs is as of TurnStartedAt for the running clock.
+type GameClock struct {
+	Control       TimeControl         `json:"control"`
+	RemainingMs   map[uuid.UUID]int64 `json:"remaining_ms"`
+	Turn          uuid.UUID           `json:"turn"`                      // Player whose clock is running (uuid.Nil when stopped)
+	TurnStartedAt *time.Time          `json:"turn_started_at,omitempty"` // When the running clock was started
+}
+
+// NewGameClock creates a stopped clock for the given players, or nil if the control has no clock
+func NewGameClock(control TimeControl, playerIDs ...uuid.UUID) *GameClock {
+	if control.Type != TimeControlTotal && control.Type != TimeControlPerMove {
+		return nil
+	}
+
+	clock := &GameClock{
+		Control:     control,
+		RemainingMs: make(map[uuid.UUID]int64),
+	}
+	for _, playerID := range playerIDs {
+		clock.AddPlayer(playerID)
+	}
+	return clock
+}
+
+// AddPlayer gives a player a full clock (used when an opponent joins a waiting game)
+func (c *GameClock) AddPlayer(playerID uuid.UUID) {
+	if playerID == uuid.Nil {
+		return
+	}
+	c.RemainingMs[playerID] = c.budgetMs()
+}
+
+// budgetMs returns the time a player starts with (or gets for each move)
+func (c *GameClock) budgetMs() int64 {
+	if c.Control.Type == TimeControlPerMove {
+		return int64(c.Control.PerMoveSeconds) * 1000
+	}
+	return int64(c.Control.TotalSeconds) * 1000
+}
+
+// Running reports whether a player's clock is currently running
+func (c *GameClock) Running() bool {
+	return c.Turn != uuid.Nil && c.TurnStartedAt != nil
+}
+
+// Start starts a player's clock
+func (c *GameClock) Start(playerID uuid.UUID, now time.Time) {
+	if c.Control.Type == TimeControlPerMove {
+		c.RemainingMs[playerID] = c.budgetMs()
+	}
+	c.Turn = playerID
+	c.TurnStartedAt = &now
+}
+
+// Resume restarts a player's stopped clock with the time they had left, without resetting the
+// time for the move (used when a paused game is resumed)
+func (c *GameClock) Resume(playerID uuid.UUID, now time.Time) {
+	c.Turn = playerID
+	c.TurnStartedAt = &now
+}
+
+// Stop charges the running player for the time used and stops their clock
+func (c *GameClock) Stop(now time.Time) {
+	if !c.Running() {
+		return
+	}
+	c.RemainingMs[c.Turn] = c.Remaining(c.Turn, now)
+	c.Turn = uuid.Nil
+	c.TurnStartedAt = nil
+}
+
+// Switch ends the running player's turn (adding any increment) and starts nextID's clock
+func (c *GameClock) Switch(nextID uuid.UUID, now time.Time) {
+	if c.Running() {
+		moverID := c.Turn
+		c.Stop(now)
+		if c.Control.Type == TimeControlTotal {
+			c.RemainingMs[moverID] += int64(c.Control.IncrementSeconds) * 1000
+		}
+	}
+	c.Start(nextID, now)
+}
+
+// Remaining returns a player's remaining time in milliseconds at the given moment
+func (c *GameClock) Remaining(playerID uuid.UUID, now time.Time) int64 {
+	remaining := c.RemainingMs[playerID]
+	if c.Running() && playerID == c.Turn {
+		remaining -= now.Sub(*c.TurnStartedAt).Milliseconds()
+	}
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// RemainingAll returns every player's remaining time in milliseconds at the given moment
+func (c *GameClock) RemainingAll(now time.Time) map[uuid.UUID]int64 {
+	remaining := make(map[uuid.UUID]int64, len(c.RemainingMs))
+	for playerID := range c.RemainingMs {
+		remaining[playerID] = c.Remaining(playerID, now)
+	}
+	return remaining
+}
+
+// Deadline returns when the running player's time runs out
+func (c *GameClock) Deadline() (time.Time, bool) {
+	if !c.Running() {
+		return time.Time{}, false
+	}
+	return c.TurnStartedAt.Add(time.Duration(c.RemainingMs[c.Turn]) * time.Millisecond), true
+}
+
+// Expired returns the player whose time has run out, if any
+func (c *GameClock) Expired(now time.Time) (uuid.UUID, bool) {
+	deadline, running := c.Deadline()
+	if !running || now.Before(deadline) {
+		return uuid.Nil, false
+	}
+	return c.Turn, true
+}
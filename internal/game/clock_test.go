@@ -0,0 +1,108 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTimeControlFromSettings tests reading time controls from validated settings
+func TestTimeControlFromSettings(t *testing.T) {
+	t.Run("Defaults To No Clock", func(t *testing.T) {
+		settings, _ := DefaultRegistry.DefaultSettings(GameTypeTicTacToe)
+		control := TimeControlFromSettings(settings)
+		assert.Equal(t, TimeControlNone, control.Type)
+		assert.Nil(t, NewGameClock(control, uuid.New(), uuid.New()))
+	})
+
+	t.Run("Total With Increment", func(t *testing.T) {
+		settings, _ := DefaultRegistry.ValidateSettings(GameTypeConnect4, map[string]interface{}{
+			"time_control":           "total",
+			"time_total_seconds":     float64(60),
+			"time_increment_seconds": float64(2),
+		})
+		control := TimeControlFromSettings(settings)
+		assert.Equal(t, TimeControl{Type: TimeControlTotal, TotalSeconds: 60, IncrementSeconds: 2}, control)
+	})
+
+	t.Run("Invalid Values Replaced", func(t *testing.T) {
+		settings, _ := DefaultRegistry.ValidateSettings(GameTypeConnect4, map[string]interface{}{
+			"time_control":          "per_move",
+			"time_per_move_seconds": float64(1), // Below minimum
+		})
+		control := TimeControlFromSettings(settings)
+		assert.Equal(t, 30, control.PerMoveSeconds)
+	})
+}
+
+// TestGameClock tests running, switching and expiring clocks
+func TestGameClock(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+	start := time.Now()
+
+	t.Run("Total Time With Increment", func(t *testing.T) {
+		clock := NewGameClock(TimeControl{Type: TimeControlTotal, TotalSeconds: 60, IncrementSeconds: 2}, player1, player2)
+		assert.False(t, clock.Running())
+
+		clock.Start(player1, start)
+		assert.Equal(t, int64(50000), clock.Remaining(player1, start.Add(10*time.Second)))
+		assert.Equal(t, int64(60000), clock.Remaining(player2, start.Add(10*time.Second)))
+
+		clock.Switch(player2, start.Add(10*time.Second))
+		assert.Equal(t, player2, clock.Turn)
+		assert.Equal(t, int64(52000), clock.Remaining(player1, start.Add(20*time.Second)))
+		assert.Equal(t, int64(50000), clock.Remaining(player2, start.Add(20*time.Second)))
+	})
+
+	t.Run("Per Move Resets Each Turn", func(t *testing.T) {
+		clock := NewGameClock(TimeControl{Type: TimeControlPerMove, PerMoveSeconds: 15}, player1, player2)
+		clock.Start(player1, start)
+		clock.Switch(player2, start.Add(10*time.Second))
+		clock.Switch(player1, start.Add(20*time.Second))
+		assert.Equal(t, int64(15000), clock.Remaining(player1, start.Add(20*time.Second)))
+	})
+
+	t.Run("Flag Fall", func(t *testing.T) {
+		clock := NewGameClock(TimeControl{Type: TimeControlTotal, TotalSeconds: 30}, player1, player2)
+		clock.Start(player1, start)
+
+		_, expired := clock.Expired(start.Add(29 * time.Second))
+		assert.False(t, expired)
+
+		flagged, expired := clock.Expired(start.Add(30 * time.Second))
+		assert.True(t, expired)
+		assert.Equal(t, player1, flagged)
+		assert.Equal(t, int64(0), clock.Remaining(player1, start.Add(time.Minute)))
+
+		deadline, running := clock.Deadline()
+		assert.True(t, running)
+		assert.Equal(t, start.Add(30*time.Second), deadline)
+	})
+
+	t.Run("Stopped Clock Never Expires", func(t *testing.T) {
+		clock := NewGameClock(TimeControl{Type: TimeControlTotal, TotalSeconds: 30}, player1, player2)
+		clock.Start(player1, start)
+		clock.Stop(start.Add(5 * time.Second))
+
+		_, expired := clock.Expired(start.Add(time.Hour))
+		assert.False(t, expired)
+		assert.Equal(t, int64(25000), clock.Remaining(player1, start.Add(time.Hour)))
+	})
+
+	t.Run("Round Trip Through JSON", func(t *testing.T) {
+		clock := NewGameClock(TimeControl{Type: TimeControlTotal, TotalSeconds: 60}, player1, player2)
+		clock.Start(player2, start)
+
+		data, err := json.Marshal(clock)
+		assert.NoError(t, err)
+
+		var decoded GameClock
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, player2, decoded.Turn)
+		assert.Equal(t, int64(60000), decoded.RemainingMs[player1])
+	})
+}
@@ -0,0 +1,230 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// conformanceSpec describes the turn structure of an engine for the conformance suite. Engines
+// without an entry get the zero spec: turn-based, without requiring the turn to pass every move.
+type conformanceSpec struct {
+	Alternates   bool // Every move passes the turn to another player
+	Simultaneous bool // Players other than GetCurrentPlayer may move as well
+}
+
+var conformanceSpecs = map[GameType]conformanceSpec{
+	GameTypeTicTacToe:         {Alternates: true},
+	GameTypeConnect4:          {Alternates: true},
+	GameTypeCheckers:          {Alternates: true},
+	GameTypeRockPaperScissors: {Simultaneous: true},
+	GameTypeRPSElimination:    {Simultaneous: true},
+}
+
+const (
+	conformancePlayouts = 3    // Random playouts per configuration
+	conformanceMaxPlies = 2000 // A playout still going after this many moves has failed to terminate
+)
+
+// conformanceConfig is one way of setting up a game for the conformance suite
+type conformanceConfig struct {
+	name     string
+	players  int
+	settings map[string]interface{}
+}
+
+// conformanceConfigs returns the default game for every seat count the engine allows, and a game
+// for each option (or the bounds) of each of its own settings
+func conformanceConfigs(def *Definition) []conformanceConfig {
+	var configs []conformanceConfig
+	for players := def.MinPlayers; players <= def.MaxPlayers; players++ {
+		configs = append(configs, conformanceConfig{name: fmt.Sprintf("%d players", players), players: players})
+	}
+
+	for _, schema := range def.Settings {
+		if schema.Key == SettingPlayers {
+			continue
+		}
+		values := schema.Options
+		if len(values) == 0 && schema.Min != nil && schema.Max != nil {
+			values = []interface{}{*schema.Min, *schema.Max}
+		}
+		for _, value := range values {
+			if value == schema.Default {
+				continue
+			}
+			configs = append(configs, conformanceConfig{
+				name:     fmt.Sprintf("%s=%v", schema.Key, value),
+				players:  def.MinPlayers,
+				settings: map[string]interface{}{schema.Key: value},
+			})
+		}
+	}
+	return configs
+}
+
+// TestConformance runs the conformance suite against every registered engine. An engine passes
+// when random playouts always end, clones are independent, states survive the JSON round trip
+// the services make, turns are kept and nothing can be played once the game is over.
+func TestConformance(t *testing.T) {
+	for _, def := range DefaultRegistry.Definitions() {
+		def := def
+		t.Run(string(def.Type), func(t *testing.T) {
+			for _, config := range conformanceConfigs(def) {
+				config := config
+				t.Run(config.name, func(t *testing.T) {
+					for seed := int64(1); seed <= conformancePlayouts; seed++ {
+						runConformance(t, DefaultRegistry, def, config, rand.New(rand.NewSource(seed)))
+					}
+				})
+			}
+		})
+	}
+}
+
+// runConformance plays one random game from a fresh state, checking every move
+func runConformance(t *testing.T, registry *Registry, def *Definition, config conformanceConfig, rng *rand.Rand) {
+	t.Helper()
+	spec := conformanceSpecs[def.Type]
+
+	players := make([]uuid.UUID, config.players)
+	for i := range players {
+		players[i] = uuid.New()
+	}
+	state, err := registry.NewStateForPlayers(def.Type, players, config.settings)
+	require.NoError(t, err)
+	require.Equal(t, players[0], state.GetCurrentPlayer(), "player 1 moves first")
+
+	var lastLegal []interface{}
+	for ply := 0; ply < conformanceMaxPlies; ply++ {
+		if _, gameOver := state.CheckWinner(); gameOver {
+			checkGameOver(t, state, players, lastLegal)
+			return
+		}
+
+		mover, move, legal := conformanceMove(t, def, players, state, rng)
+		if !spec.Simultaneous && !inSetup(state) {
+			for _, other := range players {
+				if other != mover {
+					assert.Error(t, state.ValidateMove(other, move), "a player moved out of turn at ply %d", ply)
+				}
+			}
+		}
+
+		// The move is played on a clone, which must leave the original untouched
+		before := canonicalJSON(t, state)
+		next := state.Clone()
+		require.NoError(t, next.ApplyMove(mover, move), "legal move %v rejected at ply %d", move, ply)
+		require.Equal(t, before, canonicalJSON(t, state), "playing on a clone changed the original at ply %d", ply)
+
+		// A state restored from its JSON plays on exactly like the original
+		decoded := checkRoundTrip(t, registry, def.Type, state)
+		require.NoError(t, decoded.ApplyMove(mover, move), "decoded state rejected move %v at ply %d", move, ply)
+		require.Equal(t, canonicalJSON(t, next), canonicalJSON(t, decoded), "decoded state diverged at ply %d", ply)
+
+		if _, gameOver := next.CheckWinner(); !gameOver && !inSetup(next) {
+			assert.Contains(t, players, next.GetCurrentPlayer(), "the player to move is not seated")
+			if spec.Alternates {
+				assert.NotEqual(t, mover, next.GetCurrentPlayer(), "the turn did not pass at ply %d", ply)
+			}
+		}
+
+		state, lastLegal = next, legal
+	}
+	t.Fatalf("%s did not end within %d random moves", def.Type, conformanceMaxPlies)
+}
+
+// conformanceMove picks a random legal move for the player to move, or a setup move from the
+// engine's bot for a player still setting up. Moves are passed through JSON, as the services
+// receive them.
+func conformanceMove(t *testing.T, def *Definition, players []uuid.UUID, state GameState, rng *rand.Rand) (uuid.UUID, interface{}, []interface{}) {
+	t.Helper()
+	if setup, ok := state.(SetupPhase); ok && setup.InSetup() {
+		require.NotNil(t, def.Bot, "setup moves are generated by the engine's bot")
+		for _, playerID := range players {
+			if setup.SetupComplete(playerID) {
+				continue
+			}
+			move, err := def.Bot(BotEasy, rng).ChooseMove(state, playerID)
+			require.NoError(t, err)
+			move = wireMove(t, move)
+			require.NoError(t, state.ValidateMove(playerID, move))
+			return playerID, move, []interface{}{move}
+		}
+		t.Fatal("setup phase running with every player set up")
+	}
+
+	mover := state.GetCurrentPlayer()
+	legal := state.LegalMoves()
+	require.NotEmpty(t, legal, "the player to move has no legal move")
+	for i := range legal {
+		legal[i] = wireMove(t, legal[i])
+	}
+	move := legal[rng.Intn(len(legal))]
+	require.NoError(t, state.ValidateMove(mover, move), "LegalMoves listed %v", move)
+	return mover, move, legal
+}
+
+// checkGameOver verifies that a finished game offers and accepts no moves
+func checkGameOver(t *testing.T, state GameState, players []uuid.UUID, lastLegal []interface{}) {
+	t.Helper()
+	assert.Empty(t, state.LegalMoves(), "moves offered after the game ended")
+
+	before := canonicalJSON(t, state)
+	for _, playerID := range players {
+		for _, move := range lastLegal {
+			if !assert.Error(t, state.ValidateMove(playerID, move), "move %v accepted after the game ended", move) {
+				return
+			}
+			clone := state.Clone()
+			if !assert.Error(t, clone.ApplyMove(playerID, move), "move %v applied after the game ended", move) {
+				return
+			}
+		}
+	}
+	assert.Equal(t, before, canonicalJSON(t, state))
+}
+
+// checkRoundTrip serializes a state and decodes it the way the services restore games, returning
+// the decoded state
+func checkRoundTrip(t *testing.T, registry *Registry, gameType GameType, state GameState) GameState {
+	t.Helper()
+	data := canonicalJSON(t, state)
+	fingerprint, err := StateFingerprint(state)
+	require.NoError(t, err)
+
+	decoded, err := registry.DecodeState(gameType, []byte(data))
+	require.NoError(t, err)
+	require.NoError(t, VerifyState(decoded, fingerprint))
+	require.Equal(t, data, canonicalJSON(t, decoded), "the state changed in the JSON round trip")
+	return decoded
+}
+
+// canonicalJSON returns a state's canonical serialization as a string for readable diffs
+func canonicalJSON(t *testing.T, state GameState) string {
+	t.Helper()
+	data, err := CanonicalState(state)
+	require.NoError(t, err)
+	return string(data)
+}
+
+// wireMove converts a move to the generic form it arrives in from a client
+func wireMove(t *testing.T, move interface{}) interface{} {
+	t.Helper()
+	data, err := json.Marshal(move)
+	require.NoError(t, err)
+	var decoded interface{}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	return decoded
+}
+
+// inSetup reports whether a state is in its setup phase
+func inSetup(state GameState) bool {
+	setup, ok := state.(SetupPhase)
+	return ok && setup.InSetup()
+}
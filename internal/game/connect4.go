@@ -20,9 +20,30 @@ type Connect4State struct {
 	Player2ID     uuid.UUID  `json:"player2_id"`
 	CurrentPlayer uuid.UUID  `json:"current_player"`
 	MoveCount     int        `json:"move_count"`
-	Rows          int        `json:"rows"`       // Number of rows (4-10)
-	Cols          int        `json:"cols"`       // Number of columns (4-10)
-	WinLength     int        `json:"win_length"` // Number in a row to win (4-6)
+	Rows          int        `json:"rows"`                 // Number of rows (4-10)
+	Cols          int        `json:"cols"`                 // Number of columns (4-10)
+	WinLength     int        `json:"win_length"`           // Number in a row to win (4-6)
+	PositionHash  uint64     `json:"position_hash,string"` // Zobrist hash of the board and side to move
+}
+
+func init() {
+	Register(Definition{
+		Type:        GameTypeConnect4,
+		Name:        "Connect 4",
+		Description: "Drop discs into columns and connect a line before your opponent",
+		MinPlayers:  2,
+		MaxPlayers:  2,
+		Settings: []SettingSchema{
+			{Key: "connect4_rows", Label: "Rows", Type: SettingTypeInt, Default: DefaultConnect4Rows, Min: intPtr(4), Max: intPtr(10)},
+			{Key: "connect4_cols", Label: "Columns", Type: SettingTypeInt, Default: DefaultConnect4Cols, Min: intPtr(4), Max: intPtr(10)},
+			{Key: "connect4_win_length", Label: "Win length", Description: "How many in a row to win", Type: SettingTypeInt, Default: DefaultConnect4WinLength, Min: intPtr(4), Max: intPtr(6)},
+		},
+		New: func(player1ID, player2ID uuid.UUID, settings map[string]interface{}) GameState {
+			return NewConnect4StateWithSettings(player1ID, player2ID, settings)
+		},
+		Decode: decodeState[Connect4State],
+		Bot:    newConnect4Bot,
+	})
 }
 
 // Connect4Move represents a move in Connect-4
@@ -42,20 +63,14 @@ func NewConnect4StateWithSettings(player1ID, player2ID uuid.UUID, settings inter
 	winLength := DefaultConnect4WinLength
 	
 	if settingsMap, ok := settings.(map[string]interface{}); ok {
-		if val, exists := settingsMap["connect4_rows"]; exists {
-			if rowsFloat, ok := val.(float64); ok {
-				rows = int(rowsFloat)
-			}
+		if val, exists := IntSetting(settingsMap, "connect4_rows"); exists {
+			rows = val
 		}
-		if val, exists := settingsMap["connect4_cols"]; exists {
-			if colsFloat, ok := val.(float64); ok {
-				cols = int(colsFloat)
-			}
+		if val, exists := IntSetting(settingsMap, "connect4_cols"); exists {
+			cols = val
 		}
-		if val, exists := settingsMap["connect4_win_length"]; exists {
-			if lengthFloat, ok := val.(float64); ok {
-				winLength = int(lengthFloat)
-			}
+		if val, exists := IntSetting(settingsMap, "connect4_win_length"); exists {
+			winLength = val
 		}
 	}
 	
@@ -99,16 +114,18 @@ func NewConnect4StateWithSize(player1ID, player2ID uuid.UUID, rows, cols, winLen
 		board[i] = make([]string, cols)
 	}
 	
-	return &Connect4State{
+	state := &Connect4State{
 		Board:         board,
 		Player1ID:     player1ID,
 		Player2ID:     player2ID,
-		CurrentPlayer: player1ID, // Player 1 always starts
+		CurrentPlayer: player1ID, // The first mover is seated as player 1, see FirstMoverPolicy
 		MoveCount:     0,
 		Rows:          rows,
 		Cols:          cols,
 		WinLength:     winLength,
 	}
+	state.PositionHash = state.hashPosition()
+	return state
 }
 
 // ValidateMove checks if a move is valid
@@ -117,6 +134,9 @@ func (s *Connect4State) ValidateMove(playerID uuid.UUID, move interface{}) error
 	if playerID != s.CurrentPlayer {
 		return ErrNotYourTurn
 	}
+	if _, gameOver := s.CheckWinner(); gameOver {
+		return ErrGameAlreadyEnded
+	}
 
 	// Parse move
 	connect4Move, err := parseConnect4Move(move)
@@ -172,10 +192,24 @@ func (s *Connect4State) ApplyMove(playerID uuid.UUID, move interface{}) error {
 	} else {
 		s.CurrentPlayer = s.Player1ID
 	}
+	s.PositionHash = s.hashPosition()
 
 	return nil
 }
 
+// Fingerprint returns the Zobrist hash of the position, see Fingerprinter
+func (s *Connect4State) Fingerprint() uint64 {
+	if s.PositionHash == 0 {
+		return s.hashPosition() // Saved before position hashes were stored
+	}
+	return s.PositionHash
+}
+
+// hashPosition computes the Zobrist hash of the board and the side to move
+func (s *Connect4State) hashPosition() uint64 {
+	return zobristBoard(zobristSalt(GameTypeConnect4, s.Rows, s.Cols, s.WinLength), s.Board, sideToMove(s.CurrentPlayer, s.Player1ID))
+}
+
 // CheckWinner checks if there's a winner or if the game is a draw
 func (s *Connect4State) CheckWinner() (winner *uuid.UUID, gameOver bool) {
 	// Check horizontal wins
@@ -257,11 +291,30 @@ func (s *Connect4State) checkLineFromPoint(startRow, startCol, dRow, dCol int) b
 	return true
 }
 
+// LegalMoves returns every column that is not full, left to right
+func (s *Connect4State) LegalMoves() []interface{} {
+	moves := []interface{}{}
+	if _, gameOver := s.CheckWinner(); gameOver {
+		return moves
+	}
+	for col := 0; col < s.Cols; col++ {
+		if s.Board[0][col] == "" {
+			moves = append(moves, Connect4Move{Column: col})
+		}
+	}
+	return moves
+}
+
 // GetCurrentPlayer returns the ID of the player whose turn it is
 func (s *Connect4State) GetCurrentPlayer() uuid.UUID {
 	return s.CurrentPlayer
 }
 
+// SetPlayer2 seats the second player when they join a waiting game
+func (s *Connect4State) SetPlayer2(playerID uuid.UUID) {
+	s.Player2ID = playerID
+}
+
 // GetState returns the current game state for serialization
 func (s *Connect4State) GetState() interface{} {
 	// Defensive: Ensure board is never nil
@@ -274,6 +327,11 @@ func (s *Connect4State) GetState() interface{} {
 	return s
 }
 
+// StateFor returns the full state: Connect-4 has no hidden information
+func (s *Connect4State) StateFor(viewerID uuid.UUID) interface{} {
+	return s.GetState()
+}
+
 // Clone creates a deep copy of the game state
 func (s *Connect4State) Clone() GameState {
 	newState := *s
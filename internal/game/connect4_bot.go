@@ -0,0 +1,82 @@
+package game
+
+import (
+	"math/rand"
+
+	"github.com/google/uuid"
+)
+
+// connect4Bot plays Connect-4 with an alpha-beta search on any board size and win length
+type connect4Bot struct {
+	difficulty BotDifficulty
+	rng        *rand.Rand
+}
+
+func newConnect4Bot(difficulty BotDifficulty, rng *rand.Rand) Bot {
+	return &connect4Bot{difficulty: difficulty, rng: rng}
+}
+
+var connect4Search = searchSpec{
+	moves: func(state GameState) []interface{} {
+		return connect4Moves(state.(*Connect4State))
+	},
+	evaluate: func(state GameState, botID uuid.UUID) int {
+		s := state.(*Connect4State)
+		mine, theirs := "R", "Y"
+		if botID == s.Player2ID {
+			mine, theirs = "Y", "R"
+		}
+		return scoreLines(s.Board, s.WinLength, mine, theirs)
+	},
+}
+
+// connect4Book holds the bot's replies in the best-known openings of the standard board. Positions
+// are looked up by fingerprint, so other board sizes never match.
+var connect4Book = newConnect4Book()
+
+// newConnect4Book builds connect4Book: take the centre column, and answer a centre opening on top of it
+func newConnect4Book() OpeningBook {
+	book := OpeningBook{}
+	state := NewConnect4State(uuid.New(), uuid.New())
+	book.Add(state, Connect4Move{Column: 3})
+	state.ApplyMove(state.Player1ID, Connect4Move{Column: 3})
+	book.Add(state, Connect4Move{Column: 3})
+	return book
+}
+
+// ChooseMove picks a column for the bot
+func (b *connect4Bot) ChooseMove(state GameState, botID uuid.UUID) (interface{}, error) {
+	s, ok := state.(*Connect4State)
+	if !ok {
+		return nil, ErrInvalidMove
+	}
+	if s.CurrentPlayer != botID {
+		return nil, ErrNotYourTurn
+	}
+
+	if b.difficulty != BotEasy {
+		if move, ok := connect4Book.Lookup(s); ok {
+			return move, nil
+		}
+	}
+
+	switch b.difficulty {
+	case BotEasy:
+		return randomOrWinningMove(s, botID, connect4Moves(s), b.rng)
+	case BotMedium:
+		return searchBestMove(s, botID, connect4Search, 4, b.rng)
+	default:
+		return searchBestMove(s, botID, connect4Search, 6, b.rng)
+	}
+}
+
+// connect4Moves lists the columns that are not full, centre first so the search prunes earlier
+func connect4Moves(s *Connect4State) []interface{} {
+	moves := make([]interface{}, 0, s.Cols)
+	for _, cell := range cellsByDistance(1, s.Cols, 0, float64(s.Cols-1)/2) {
+		if s.Board[0][cell[1]] == "" {
+			moves = append(moves, Connect4Move{Column: cell[1]})
+		}
+	}
+	return moves
+}
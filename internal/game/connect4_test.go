@@ -71,9 +71,9 @@ func TestConnect4ValidateMove(t *testing.T) {
 	})
 
 	t.Run("Column Full", func(t *testing.T) {
-		// Fill column 0
+		// Fill column 0 without four in a row, which would end the game
 		for i := 0; i < 6; i++ {
-			state.Board[i][0] = "R"
+			state.Board[i][0] = []string{"R", "Y"}[i%2]
 		}
 
 		move := Connect4Move{Column: 0}
@@ -227,3 +227,19 @@ func TestConnect4FullGame(t *testing.T) {
 	}
 }
 
+
+// TestConnect4LegalMoves tests legal move enumeration
+func TestConnect4LegalMoves(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+	state := NewConnect4State(player1, player2)
+	assert.Len(t, state.LegalMoves(), 7)
+
+	// Fill column 2 without anyone winning
+	for i := 0; i < 6; i++ {
+		assert.NoError(t, state.ApplyMove(state.CurrentPlayer, Connect4Move{Column: 2}))
+	}
+	moves := state.LegalMoves()
+	assert.Len(t, moves, 6)
+	assert.NotContains(t, moves, Connect4Move{Column: 2})
+}
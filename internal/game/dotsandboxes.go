@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/google/uuid"
 )
@@ -37,13 +38,16 @@ type Box struct {
 	OwnerID uuid.UUID `json:"owner_id"`
 }
 
-// DotsAndBoxesState represents the state of a Dots & Boxes game
+// DotsAndBoxesState represents the state of a Dots & Boxes game for two to four players.
+// Players take turns in seat order; Player1ID/Player2ID and their scores mirror seats 0 and 1.
 type DotsAndBoxesState struct {
 	Player1ID     uuid.UUID       `json:"player1_id"`
 	Player2ID     uuid.UUID       `json:"player2_id"`
+	PlayerIDs     []uuid.UUID     `json:"players"`         // Every player in turn order
 	CurrentPlayer uuid.UUID       `json:"current_player"`
 	Lines         []Line          `json:"lines"`           // All drawn lines
 	Boxes         []Box           `json:"boxes"`           // All completed boxes
+	Scores        []int           `json:"scores"`          // Boxes owned by each player, by seat
 	Player1Score  int             `json:"player1_score"`   // Number of boxes owned by player 1
 	Player2Score  int             `json:"player2_score"`   // Number of boxes owned by player 2
 	TotalBoxes    int             `json:"total_boxes"`     // Total possible boxes (4x4 = 16)
@@ -59,6 +63,27 @@ type DotsAndBoxesMove struct {
 	Orientation LineOrientation `json:"orientation"`
 }
 
+func init() {
+	Register(Definition{
+		Type:        GameTypeDotsAndBoxes,
+		Name:        "Dots & Boxes",
+		Description: "Take turns drawing lines; complete a box to score and move again",
+		MinPlayers:  2,
+		MaxPlayers:  4,
+		Settings: []SettingSchema{
+			{Key: "dots_grid_size", Label: "Dots per side", Description: "An n x n grid of dots creates (n-1) x (n-1) boxes", Type: SettingTypeInt, Default: DefaultDotsRows, Min: intPtr(4), Max: intPtr(8)},
+		},
+		New: func(player1ID, player2ID uuid.UUID, settings map[string]interface{}) GameState {
+			return NewDotsAndBoxesStateWithSettings(player1ID, player2ID, settings)
+		},
+		NewForPlayers: func(playerIDs []uuid.UUID, settings map[string]interface{}) GameState {
+			return NewDotsAndBoxesStateForPlayers(playerIDs, settings)
+		},
+		Decode: decodeDotsAndBoxesState,
+		Bot:    newDotsAndBoxesBot,
+	})
+}
+
 // NewDotsAndBoxesState creates a new Dots & Boxes game state with default 5x5 grid
 func NewDotsAndBoxesState(player1ID, player2ID uuid.UUID) *DotsAndBoxesState {
 	return NewDotsAndBoxesStateWithGridSize(player1ID, player2ID, DefaultDotsRows, DefaultDotsCols)
@@ -69,16 +94,32 @@ func NewDotsAndBoxesStateWithSettings(player1ID, player2ID uuid.UUID, settings i
 	gridSize := DefaultDotsRows // default
 	
 	if settingsMap, ok := settings.(map[string]interface{}); ok {
-		if val, exists := settingsMap["dots_grid_size"]; exists {
-			if sizeFloat, ok := val.(float64); ok {
-				gridSize = int(sizeFloat)
-			}
+		if val, exists := IntSetting(settingsMap, "dots_grid_size"); exists {
+			gridSize = val
 		}
 	}
 	
 	return NewDotsAndBoxesStateWithGridSize(player1ID, player2ID, gridSize, gridSize)
 }
 
+// NewDotsAndBoxesStateForPlayers creates a Dots & Boxes game for the players in seat order
+func NewDotsAndBoxesStateForPlayers(playerIDs []uuid.UUID, settings map[string]interface{}) *DotsAndBoxesState {
+	gridSize := DefaultDotsRows
+	if val, exists := IntSetting(settings, "dots_grid_size"); exists {
+		gridSize = val
+	}
+
+	state := NewDotsAndBoxesStateWithGridSize(uuid.Nil, uuid.Nil, gridSize, gridSize)
+	state.PlayerIDs = append([]uuid.UUID{}, playerIDs...)
+	for len(state.PlayerIDs) < 2 {
+		state.PlayerIDs = append(state.PlayerIDs, uuid.Nil) // Seats still waiting for a player
+	}
+	state.Scores = make([]int, len(state.PlayerIDs))
+	state.Player1ID, state.Player2ID = state.PlayerIDs[0], state.PlayerIDs[1]
+	state.CurrentPlayer = state.PlayerIDs[0]
+	return state
+}
+
 // NewDotsAndBoxesStateWithGridSize creates a new Dots & Boxes game state with specified grid size
 func NewDotsAndBoxesStateWithGridSize(player1ID, player2ID uuid.UUID, rows, cols int) *DotsAndBoxesState {
 	// Validate grid size
@@ -98,9 +139,11 @@ func NewDotsAndBoxesStateWithGridSize(player1ID, player2ID uuid.UUID, rows, cols
 	return &DotsAndBoxesState{
 		Player1ID:     player1ID,
 		Player2ID:     player2ID,
-		CurrentPlayer: player1ID, // Player 1 always starts
+		PlayerIDs:     []uuid.UUID{player1ID, player2ID},
+		CurrentPlayer: player1ID, // The first mover is seated as player 1, see FirstMoverPolicy
 		Lines:         []Line{},
 		Boxes:         []Box{},
+		Scores:        []int{0, 0},
 		Player1Score:  0,
 		Player2Score:  0,
 		TotalBoxes:    (rows - 1) * (cols - 1),
@@ -116,6 +159,9 @@ func (s *DotsAndBoxesState) ValidateMove(playerID uuid.UUID, move interface{}) e
 	if playerID != s.CurrentPlayer {
 		return ErrNotYourTurn
 	}
+	if _, gameOver := s.CheckWinner(); gameOver {
+		return ErrGameAlreadyEnded
+	}
 
 	// Parse move
 	dotsMove, err := parseDotsAndBoxesMove(move)
@@ -180,11 +226,8 @@ func (s *DotsAndBoxesState) ApplyMove(playerID uuid.UUID, move interface{}) erro
 	
 	if pointsScored > 0 {
 		// Award points and claim boxes
-		if playerID == s.Player1ID {
-			s.Player1Score += pointsScored
-		} else {
-			s.Player2Score += pointsScored
-		}
+		s.Scores[s.seatOf(playerID)] += pointsScored
+		s.Player1Score, s.Player2Score = s.Scores[0], s.Scores[1]
 		
 		// Add all newly claimed 1×1 boxes
 		s.Boxes = append(s.Boxes, completedBoxes...)
@@ -194,12 +237,8 @@ func (s *DotsAndBoxesState) ApplyMove(playerID uuid.UUID, move interface{}) erro
 		// Player gets another turn if they completed a square
 	} else {
 		s.LastMoveBoxed = false
-		// Switch turn
-		if s.CurrentPlayer == s.Player1ID {
-			s.CurrentPlayer = s.Player2ID
-		} else {
-			s.CurrentPlayer = s.Player1ID
-		}
+		// Pass the turn to the next seat
+		s.CurrentPlayer = s.PlayerIDs[(s.seatOf(playerID)+1)%len(s.PlayerIDs)]
 	}
 
 	return nil
@@ -380,40 +419,103 @@ func (s *DotsAndBoxesState) getAdjacentBoxes(row, col int, orientation LineOrien
 
 // CheckWinner checks if there's a winner
 // Game ends when:
-// 1. A player has more than half the boxes (mathematically won)
-// 2. All boxes are filled
+// 1. The leader has more boxes than anyone else could still reach (mathematically won)
+// 2. All boxes are filled; a shared lead is a draw
 func (s *DotsAndBoxesState) CheckWinner() (winner *uuid.UUID, gameOver bool) {
-	halfBoxes := s.TotalBoxes / 2
-	
-	// Check if either player has won by getting more than half the boxes
-	if s.Player1Score > halfBoxes {
-		fmt.Printf("Player 1 wins with %d boxes (more than half of %d)\n", s.Player1Score, s.TotalBoxes)
-		return &s.Player1ID, true
+	leader, best, second := -1, -1, -1
+	for seat, score := range s.Scores {
+		switch {
+		case score > best:
+			leader, best, second = seat, score, best
+		case score > second:
+			second = score
+		}
 	}
-	if s.Player2Score > halfBoxes {
-		fmt.Printf("Player 2 wins with %d boxes (more than half of %d)\n", s.Player2Score, s.TotalBoxes)
-		return &s.Player2ID, true
+	if leader < 0 {
+		return nil, false
 	}
-	
-	// Game is over when all boxes are filled
-	if len(s.Boxes) >= s.TotalBoxes {
-		if s.Player1Score > s.Player2Score {
-			return &s.Player1ID, true
-		} else if s.Player2Score > s.Player1Score {
-			return &s.Player2ID, true
-		}
-		// Draw (exactly half each)
-		return nil, true
+
+	remaining := s.TotalBoxes - len(s.Boxes)
+	if best > second+remaining {
+		return &s.PlayerIDs[leader], true
+	}
+	if remaining <= 0 {
+		return nil, true // Draw: the lead is shared
 	}
 
 	return nil, false
 }
 
+// Players returns the seated players in turn order
+func (s *DotsAndBoxesState) Players() []uuid.UUID {
+	return append([]uuid.UUID{}, s.PlayerIDs...)
+}
+
+// Standings groups the players by boxes owned, most first
+func (s *DotsAndBoxesState) Standings() [][]uuid.UUID {
+	seats := make([]int, len(s.PlayerIDs))
+	for i := range seats {
+		seats[i] = i
+	}
+	sort.SliceStable(seats, func(a, b int) bool {
+		return s.Scores[seats[a]] > s.Scores[seats[b]]
+	})
+
+	var standings [][]uuid.UUID
+	for i, seat := range seats {
+		if i > 0 && s.Scores[seat] == s.Scores[seats[i-1]] {
+			standings[len(standings)-1] = append(standings[len(standings)-1], s.PlayerIDs[seat])
+			continue
+		}
+		standings = append(standings, []uuid.UUID{s.PlayerIDs[seat]})
+	}
+	return standings
+}
+
+// seatOf returns a player's seat index, or -1 if they are not playing
+func (s *DotsAndBoxesState) seatOf(playerID uuid.UUID) int {
+	for seat, id := range s.PlayerIDs {
+		if id == playerID {
+			return seat
+		}
+	}
+	return -1
+}
+
+// LegalMoves returns every undrawn line that still borders an unclaimed box,
+// horizontal lines first, each row by row
+func (s *DotsAndBoxesState) LegalMoves() []interface{} {
+	moves := []interface{}{}
+	if _, gameOver := s.CheckWinner(); gameOver {
+		return moves
+	}
+	for _, orientation := range []LineOrientation{LineHorizontal, LineVertical} {
+		rows, cols := s.GridRows, s.GridCols-1
+		if orientation == LineVertical {
+			rows, cols = s.GridRows-1, s.GridCols
+		}
+		for row := 0; row < rows; row++ {
+			for col := 0; col < cols; col++ {
+				if !s.hasLine(row, col, orientation) && !s.isLineUseless(row, col, orientation) {
+					moves = append(moves, DotsAndBoxesMove{Row: row, Col: col, Orientation: orientation})
+				}
+			}
+		}
+	}
+	return moves
+}
+
 // GetCurrentPlayer returns the ID of the player whose turn it is
 func (s *DotsAndBoxesState) GetCurrentPlayer() uuid.UUID {
 	return s.CurrentPlayer
 }
 
+// SetPlayer2 seats the second player when they join a waiting game
+func (s *DotsAndBoxesState) SetPlayer2(playerID uuid.UUID) {
+	s.Player2ID = playerID
+	s.PlayerIDs[1] = playerID
+}
+
 // GetState returns the current game state for serialization
 func (s *DotsAndBoxesState) GetState() interface{} {
 	// Defensive: Ensure slices are never nil
@@ -426,6 +528,11 @@ func (s *DotsAndBoxesState) GetState() interface{} {
 	return s
 }
 
+// StateFor returns the full state: Dots & Boxes has no hidden information
+func (s *DotsAndBoxesState) StateFor(viewerID uuid.UUID) interface{} {
+	return s.GetState()
+}
+
 // Clone creates a deep copy of the game state
 func (s *DotsAndBoxesState) Clone() GameState {
 	newState := *s
@@ -434,9 +541,25 @@ func (s *DotsAndBoxesState) Clone() GameState {
 	copy(newState.Lines, s.Lines)
 	newState.Boxes = make([]Box, len(s.Boxes))
 	copy(newState.Boxes, s.Boxes)
+	newState.PlayerIDs = append([]uuid.UUID{}, s.PlayerIDs...)
+	newState.Scores = append([]int{}, s.Scores...)
 	return &newState
 }
 
+// decodeDotsAndBoxesState restores a state, seating the two players of states saved before
+// games could have more than two players
+func decodeDotsAndBoxesState(data []byte) (GameState, error) {
+	var state DotsAndBoxesState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	if len(state.PlayerIDs) == 0 {
+		state.PlayerIDs = []uuid.UUID{state.Player1ID, state.Player2ID}
+		state.Scores = []int{state.Player1Score, state.Player2Score}
+	}
+	return &state, nil
+}
+
 // parseDotsAndBoxesMove parses a move from various formats
 func parseDotsAndBoxesMove(move interface{}) (*DotsAndBoxesMove, error) {
 	// Try to parse as DotsAndBoxesMove struct
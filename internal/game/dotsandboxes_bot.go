@@ -0,0 +1,337 @@
+package game
+
+import (
+	"math/rand"
+
+	"github.com/google/uuid"
+)
+
+// dotsAndBoxesBot plays Dots & Boxes by chain counting rather than search: it takes what is
+// on offer, avoids handing out boxes while safe lines remain, and when forced to give something
+// away it opens the smallest chain. The hard bot also declines the last two boxes of a chain
+// (a "double-dealing" move) to keep control when more chains are still to come.
+type dotsAndBoxesBot struct {
+	difficulty BotDifficulty
+	rng        *rand.Rand
+}
+
+func newDotsAndBoxesBot(difficulty BotDifficulty, rng *rand.Rand) Bot {
+	return &dotsAndBoxesBot{difficulty: difficulty, rng: rng}
+}
+
+// ChooseMove picks a line for the bot
+func (b *dotsAndBoxesBot) ChooseMove(state GameState, botID uuid.UUID) (interface{}, error) {
+	s, ok := state.(*DotsAndBoxesState)
+	if !ok {
+		return nil, ErrInvalidMove
+	}
+	if s.CurrentPlayer != botID {
+		return nil, ErrNotYourTurn
+	}
+
+	board := newDotsBoard(s)
+	lines := board.legalLines()
+	if len(lines) == 0 {
+		return nil, ErrNoLegalMoves
+	}
+
+	var scoring, safe []dotsLine
+	bestGain := 0
+	for _, line := range lines {
+		if gain := board.gain(line); gain > 0 {
+			if gain > bestGain {
+				bestGain = gain
+				scoring = scoring[:0]
+			}
+			if gain == bestGain {
+				scoring = append(scoring, line)
+			}
+			continue
+		}
+		if !board.opensScoring(line) {
+			safe = append(safe, line)
+		}
+	}
+
+	if b.difficulty == BotEasy {
+		if len(scoring) > 0 && b.rng.Intn(2) == 0 {
+			return b.pick(scoring), nil
+		}
+		return b.pick(lines), nil
+	}
+
+	if len(scoring) > 0 {
+		if b.difficulty == BotHard && len(safe) == 0 {
+			if line, ok := board.doubleDeal(scoring); ok {
+				return line.move(), nil
+			}
+		}
+		return b.pick(scoring), nil
+	}
+	if len(safe) > 0 {
+		return b.pick(safe), nil
+	}
+
+	// Every line gives something away: give away as little as possible
+	var cheapest []dotsLine
+	fewest := -1
+	for _, line := range lines {
+		after := board.clone()
+		after.draw(line)
+		given := after.greedyCapture()
+		if fewest == -1 || given < fewest {
+			fewest = given
+			cheapest = cheapest[:0]
+		}
+		if given == fewest {
+			cheapest = append(cheapest, line)
+		}
+	}
+	return b.pick(cheapest), nil
+}
+
+// pick returns a random line from lines as a move
+func (b *dotsAndBoxesBot) pick(lines []dotsLine) DotsAndBoxesMove {
+	return lines[b.rng.Intn(len(lines))].move()
+}
+
+// dotsLine identifies a line on the board
+type dotsLine struct {
+	row, col    int
+	orientation LineOrientation
+}
+
+func (l dotsLine) move() DotsAndBoxesMove {
+	return DotsAndBoxesMove{Row: l.row, Col: l.col, Orientation: l.orientation}
+}
+
+// dotsBoard is a lookup-friendly copy of a Dots & Boxes position used for the bot's analysis
+type dotsBoard struct {
+	rows, cols int // Dots per side
+	drawn      map[dotsLine]bool
+	claimed    map[[2]int]bool
+}
+
+func newDotsBoard(s *DotsAndBoxesState) *dotsBoard {
+	board := &dotsBoard{
+		rows:    s.GridRows,
+		cols:    s.GridCols,
+		drawn:   make(map[dotsLine]bool, len(s.Lines)),
+		claimed: make(map[[2]int]bool, len(s.Boxes)),
+	}
+	for _, line := range s.Lines {
+		board.drawn[dotsLine{line.Row, line.Col, line.Orientation}] = true
+	}
+	for _, box := range s.Boxes {
+		board.claimed[[2]int{box.Row, box.Col}] = true
+	}
+	return board
+}
+
+func (b *dotsBoard) clone() *dotsBoard {
+	clone := &dotsBoard{
+		rows:    b.rows,
+		cols:    b.cols,
+		drawn:   make(map[dotsLine]bool, len(b.drawn)),
+		claimed: make(map[[2]int]bool, len(b.claimed)),
+	}
+	for line := range b.drawn {
+		clone.drawn[line] = true
+	}
+	for box := range b.claimed {
+		clone.claimed[box] = true
+	}
+	return clone
+}
+
+// legalLines returns every undrawn line that still borders an unclaimed box
+func (b *dotsBoard) legalLines() []dotsLine {
+	var lines []dotsLine
+	for row := 0; row < b.rows; row++ {
+		for col := 0; col < b.cols; col++ {
+			for _, line := range []dotsLine{{row, col, LineHorizontal}, {row, col, LineVertical}} {
+				if line.orientation == LineHorizontal && col >= b.cols-1 {
+					continue
+				}
+				if line.orientation == LineVertical && row >= b.rows-1 {
+					continue
+				}
+				if !b.drawn[line] && b.bordersOpenBox(line) {
+					lines = append(lines, line)
+				}
+			}
+		}
+	}
+	return lines
+}
+
+// bordersOpenBox reports whether a line touches at least one unclaimed box
+func (b *dotsBoard) bordersOpenBox(line dotsLine) bool {
+	var boxes [][2]int
+	if line.orientation == LineHorizontal {
+		boxes = [][2]int{{line.row - 1, line.col}, {line.row, line.col}}
+	} else {
+		boxes = [][2]int{{line.row, line.col - 1}, {line.row, line.col}}
+	}
+	for _, box := range boxes {
+		if box[0] >= 0 && box[0] < b.rows-1 && box[1] >= 0 && box[1] < b.cols-1 && !b.claimed[box] {
+			return true
+		}
+	}
+	return false
+}
+
+// maxSquareSize returns the side of the largest square on the board
+func (b *dotsBoard) maxSquareSize() int {
+	if b.rows < b.cols {
+		return b.rows - 1
+	}
+	return b.cols - 1
+}
+
+// onPerimeter reports whether a line is part of the outline of a square
+func onPerimeter(line dotsLine, row, col, size int) bool {
+	if line.orientation == LineHorizontal {
+		return (line.row == row || line.row == row+size) && line.col >= col && line.col < col+size
+	}
+	return (line.col == col || line.col == col+size) && line.row >= row && line.row < row+size
+}
+
+// missingSides counts the undrawn lines on a square's outline, returning one of them.
+// Squares containing a claimed box can no longer score and report ok=false.
+func (b *dotsBoard) missingSides(row, col, size int) (missing int, last dotsLine, ok bool) {
+	for r := row; r < row+size; r++ {
+		for c := col; c < col+size; c++ {
+			if b.claimed[[2]int{r, c}] {
+				return 0, dotsLine{}, false
+			}
+		}
+	}
+	for i := 0; i < size; i++ {
+		for _, line := range []dotsLine{
+			{row, col + i, LineHorizontal},
+			{row + size, col + i, LineHorizontal},
+			{row + i, col, LineVertical},
+			{row + i, col + size, LineVertical},
+		} {
+			if !b.drawn[line] {
+				missing++
+				last = line
+			}
+		}
+	}
+	return missing, last, true
+}
+
+// completedBy returns the squares of the largest size that drawing line would complete, mirroring
+// how the engine scores a move
+func (b *dotsBoard) completedBy(line dotsLine) (size int, squares [][2]int) {
+	for size := b.maxSquareSize(); size >= 1; size-- {
+		for row := 0; row <= b.rows-1-size; row++ {
+			for col := 0; col <= b.cols-1-size; col++ {
+				if !onPerimeter(line, row, col, size) {
+					continue
+				}
+				if missing, last, ok := b.missingSides(row, col, size); ok && missing == 1 && last == line {
+					squares = append(squares, [2]int{row, col})
+				}
+			}
+		}
+		if len(squares) > 0 {
+			return size, squares
+		}
+	}
+	return 0, nil
+}
+
+// gain returns the points scored by drawing line
+func (b *dotsBoard) gain(line dotsLine) int {
+	size, squares := b.completedBy(line)
+	return size * size * len(squares)
+}
+
+// draw draws a line and claims any boxes it completes, returning the points scored
+func (b *dotsBoard) draw(line dotsLine) int {
+	size, squares := b.completedBy(line)
+	b.drawn[line] = true
+	for _, square := range squares {
+		for r := square[0]; r < square[0]+size; r++ {
+			for c := square[1]; c < square[1]+size; c++ {
+				b.claimed[[2]int{r, c}] = true
+			}
+		}
+	}
+	return size * size * len(squares)
+}
+
+// scoringLine returns a line that would score right now, if any
+func (b *dotsBoard) scoringLine() (dotsLine, bool) {
+	for size := b.maxSquareSize(); size >= 1; size-- {
+		for row := 0; row <= b.rows-1-size; row++ {
+			for col := 0; col <= b.cols-1-size; col++ {
+				if missing, last, ok := b.missingSides(row, col, size); ok && missing == 1 {
+					return last, true
+				}
+			}
+		}
+	}
+	return dotsLine{}, false
+}
+
+// opensScoring reports whether drawing a (non-scoring) line would let the opponent score
+func (b *dotsBoard) opensScoring(line dotsLine) bool {
+	b.drawn[line] = true
+	defer delete(b.drawn, line)
+	_, ok := b.scoringLine()
+	return ok
+}
+
+// greedyCapture returns how many points the player to move collects by taking every scoring
+// line available, one after another
+func (b *dotsBoard) greedyCapture() int {
+	total := 0
+	for {
+		line, ok := b.scoringLine()
+		if !ok {
+			return total
+		}
+		total += b.draw(line)
+	}
+}
+
+// unclaimedBoxes counts the boxes nobody owns yet
+func (b *dotsBoard) unclaimedBoxes() int {
+	return (b.rows-1)*(b.cols-1) - len(b.claimed)
+}
+
+// doubleDeal looks for the end of a chain where exactly one box can be taken and taking it
+// would offer exactly one more. Instead of taking both, drawing the far side of the second box
+// hands the pair to the opponent, who then has to open the next chain. It is only worth it
+// when more than the two sacrificed boxes remain to be won.
+func (b *dotsBoard) doubleDeal(scoring []dotsLine) (dotsLine, bool) {
+	if len(scoring) != 1 || b.gain(scoring[0]) != 1 {
+		return dotsLine{}, false
+	}
+
+	after := b.clone()
+	after.draw(scoring[0])
+	far, ok := after.scoringLine()
+	if !ok || after.gain(far) != 1 {
+		return dotsLine{}, false
+	}
+	after.draw(far)
+	if _, more := after.scoringLine(); more || after.unclaimedBoxes() <= 2 {
+		return dotsLine{}, false
+	}
+
+	// The handout must itself score nothing and leave exactly the two boxes on offer
+	if b.drawn[far] || b.gain(far) != 0 {
+		return dotsLine{}, false
+	}
+	handout := b.clone()
+	handout.draw(far)
+	if handout.greedyCapture() != 2 {
+		return dotsLine{}, false
+	}
+	return far, true
+}
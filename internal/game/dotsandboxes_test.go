@@ -0,0 +1,115 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// dotsWithScores returns a 4x4-dot (9 box) game for the players with the given scores and as
+// many claimed boxes as the scores add up to
+func dotsWithScores(players []uuid.UUID, scores ...int) *DotsAndBoxesState {
+	state := NewDotsAndBoxesStateForPlayers(players, map[string]interface{}{"dots_grid_size": 4})
+	copy(state.Scores, scores)
+	for _, score := range scores {
+		for i := 0; i < score; i++ {
+			state.Boxes = append(state.Boxes, Box{Row: len(state.Boxes) / 3, Col: len(state.Boxes) % 3})
+		}
+	}
+	return state
+}
+
+// TestDotsAndBoxesMultiplayer tests Dots & Boxes with more than two players
+func TestDotsAndBoxesMultiplayer(t *testing.T) {
+	players := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+
+	t.Run("Turns Rotate Through Every Seat", func(t *testing.T) {
+		state := NewDotsAndBoxesStateForPlayers(players, nil)
+		assert.Equal(t, players, state.Players())
+		assert.Equal(t, players[0], state.Player1ID)
+		assert.Equal(t, players[1], state.Player2ID)
+
+		assert.NoError(t, state.ApplyMove(players[0], DotsAndBoxesMove{Row: 0, Col: 0, Orientation: LineHorizontal}))
+		assert.Equal(t, players[1], state.GetCurrentPlayer())
+		assert.NoError(t, state.ApplyMove(players[1], DotsAndBoxesMove{Row: 1, Col: 0, Orientation: LineHorizontal}))
+		assert.Equal(t, players[2], state.GetCurrentPlayer())
+		assert.Equal(t, ErrNotYourTurn, state.ValidateMove(players[0], DotsAndBoxesMove{Row: 0, Col: 0, Orientation: LineVertical}))
+		assert.NoError(t, state.ApplyMove(players[2], DotsAndBoxesMove{Row: 0, Col: 0, Orientation: LineVertical}))
+		assert.Equal(t, players[0], state.GetCurrentPlayer(), "the turn wraps around to the first seat")
+
+		// Completing a box scores and keeps the turn
+		assert.NoError(t, state.ApplyMove(players[0], DotsAndBoxesMove{Row: 0, Col: 1, Orientation: LineVertical}))
+		assert.Equal(t, []int{1, 0, 0}, state.Scores)
+		assert.Equal(t, 1, state.Player1Score)
+		assert.Equal(t, players[0], state.GetCurrentPlayer())
+	})
+
+	t.Run("Leader Wins Once Nobody Can Catch Up", func(t *testing.T) {
+		_, gameOver := dotsWithScores(players, 3, 2, 2).CheckWinner()
+		assert.False(t, gameOver, "two boxes left could still change the lead")
+
+		winner, gameOver := dotsWithScores(players, 5, 1, 1).CheckWinner()
+		assert.True(t, gameOver)
+		assert.Equal(t, players[0], *winner)
+
+		winner, gameOver = dotsWithScores(players, 2, 4, 3).CheckWinner()
+		assert.True(t, gameOver)
+		assert.Equal(t, players[1], *winner)
+	})
+
+	t.Run("Shared Lead Is A Draw", func(t *testing.T) {
+		state := dotsWithScores(players, 4, 4, 1)
+		winner, gameOver := state.CheckWinner()
+		assert.True(t, gameOver)
+		assert.Nil(t, winner)
+		assert.Equal(t, [][]uuid.UUID{{players[0], players[1]}, {players[2]}}, state.Standings())
+	})
+
+	t.Run("Standings Order By Boxes", func(t *testing.T) {
+		four := append(players, uuid.New())
+		state := dotsWithScores(four, 1, 3, 2, 3)
+		assert.Equal(t, [][]uuid.UUID{{four[1], four[3]}, {four[2]}, {four[0]}}, state.Standings())
+	})
+
+	t.Run("Registry Seats The Players Setting", func(t *testing.T) {
+		def, _ := Lookup(GameTypeDotsAndBoxes)
+		assert.Equal(t, 4, def.MaxPlayers)
+
+		settings, _ := DefaultRegistry.ValidateSettings(GameTypeDotsAndBoxes, map[string]interface{}{SettingPlayers: float64(3)})
+		assert.Equal(t, 3, PlayersFromSettings(settings))
+		settings, _ = DefaultRegistry.ValidateSettings(GameTypeDotsAndBoxes, map[string]interface{}{SettingPlayers: float64(5)})
+		assert.Equal(t, 2, PlayersFromSettings(settings), "out of range falls back to the default")
+
+		state, err := DefaultRegistry.NewStateForPlayers(GameTypeDotsAndBoxes, players, settings)
+		assert.NoError(t, err)
+		assert.Len(t, state.(*DotsAndBoxesState).Scores, 3)
+	})
+
+	t.Run("Decodes Two-Player States Saved Without Seats", func(t *testing.T) {
+		legacy := NewDotsAndBoxesState(players[0], players[1])
+		legacy.Player2Score = 2
+		data, _ := json.Marshal(legacy)
+		var raw map[string]interface{}
+		json.Unmarshal(data, &raw)
+		delete(raw, "players")
+		delete(raw, "scores")
+		data, _ = json.Marshal(raw)
+
+		decoded, err := DefaultRegistry.DecodeState(GameTypeDotsAndBoxes, data)
+		assert.NoError(t, err)
+		state := decoded.(*DotsAndBoxesState)
+		assert.Equal(t, []uuid.UUID{players[0], players[1]}, state.Players())
+		assert.Equal(t, []int{0, 2}, state.Scores)
+	})
+
+	t.Run("Clone Copies Seats And Scores", func(t *testing.T) {
+		state := NewDotsAndBoxesStateForPlayers(players, nil)
+		clone := state.Clone().(*DotsAndBoxesState)
+		clone.Scores[0] = 5
+		clone.PlayerIDs[0] = uuid.Nil
+		assert.Equal(t, 0, state.Scores[0])
+		assert.Equal(t, players[0], state.PlayerIDs[0])
+	})
+}
@@ -0,0 +1,395 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType identifies a change in a game's lifecycle
+type EventType string
+
+const (
+	EventCreated           EventType = "created"          // Data: the new game, see NewCreatedEvent
+	EventJoined            EventType = "joined"           // Data: JoinedData
+	EventStarted           EventType = "started"          // Data: StartedData
+	EventMove              EventType = "move"             // Data: the move as submitted
+	EventSpectatorJoined   EventType = "spectator_joined" // Data: Spectator
+	EventSpectatorLeft     EventType = "spectator_left"   // PlayerID is the spectator
+	EventResigned          EventType = "resigned"
+	EventTimedOut          EventType = "timed_out" // PlayerID is the player whose clock ran out
+	EventDrawOffered       EventType = "draw_offered"
+	EventDrawDeclined      EventType = "draw_declined"
+	EventDrawAgreed        EventType = "draw_agreed"
+	EventTakebackRequested EventType = "takeback_requested"
+	EventTakebackDeclined  EventType = "takeback_declined"
+	EventTakenBack         EventType = "taken_back" // PlayerID is the player who asked for the takeback
+	EventAborted           EventType = "aborted"
+	EventDisconnected      EventType = "disconnected"
+	EventReconnected       EventType = "reconnected"
+	EventForfeited         EventType = "forfeited" // Rated game lost by a player who did not reconnect
+	EventAbandoned         EventType = "abandoned" // Casual game abandoned by a player who did not reconnect
+	EventRematchOffered    EventType = "rematch_offered"
+	EventRematchDeclined   EventType = "rematch_declined"
+	EventNextGame          EventType = "next_game"          // Data: NextGameData; a rematch or the next series game began
+	EventNextGameReleased  EventType = "next_game_released" // The next game failed to start and can be tried again
+	EventPauseRequested    EventType = "pause_requested"
+	EventPauseDeclined     EventType = "pause_declined"
+	EventPaused            EventType = "paused" // Data: PausedData; PlayerID is the player who asked for the pause or the admin
+	EventResumeRequested   EventType = "resume_requested"
+	EventResumeDeclined    EventType = "resume_declined"
+	EventResumed           EventType = "resumed"
+)
+
+// Event is one change to a game. Applying a game's events in order, starting from its
+// EventCreated, rebuilds the game exactly (see Rebuild). Unless noted, PlayerID is the player
+// who acted.
+type Event struct {
+	GameID   uuid.UUID       `json:"game_id"`
+	Version  int64           `json:"version"` // Version of the game once the save that added the event succeeded
+	Index    int             `json:"index"`   // Position among the events of that save
+	Type     EventType       `json:"type"`
+	PlayerID uuid.UUID       `json:"player_id"`
+	At       time.Time       `json:"at"`
+	Data     json.RawMessage `json:"data,omitempty"`
+	EndsGame bool            `json:"ends_game,omitempty"` // The event finished the game (completed or abandoned)
+}
+
+// JoinedData is the data of an EventJoined
+type JoinedData struct {
+	Name string   `json:"name"`
+	Bot  *BotSeat `json:"bot,omitempty"` // Set when the player joining is a bot
+}
+
+// StartedData is the data of an EventStarted
+type StartedData struct {
+	FirstPlayerID *uuid.UUID `json:"first_player_id,omitempty"` // Picked by the first-mover policy; nil for multiplayer games
+}
+
+// PausedData is the data of an EventPaused
+type PausedData struct {
+	Admin bool `json:"admin,omitempty"` // Paused by a tournament admin
+}
+
+// NextGameData is the data of an EventNextGame
+type NextGameData struct {
+	GameID uuid.UUID `json:"game_id"`
+}
+
+// NewEvent builds an event, serializing data (which may be nil) as its data
+func NewEvent(gameID uuid.UUID, eventType EventType, playerID uuid.UUID, at time.Time, data interface{}) (Event, error) {
+	event := Event{GameID: gameID, Type: eventType, PlayerID: playerID, At: at}
+	if data != nil {
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return Event{}, fmt.Errorf("failed to marshal %s event: %w", eventType, err)
+		}
+		event.Data = encoded
+	}
+	return event, nil
+}
+
+// NewCreatedEvent builds the event that starts a game's event stream, holding the new game
+// with its initial state
+func NewCreatedEvent(g *Game) (Event, error) {
+	created := *g
+	if g.State != nil {
+		stateData, err := json.Marshal(g.State.GetState())
+		if err != nil {
+			return Event{}, fmt.Errorf("failed to marshal game state: %w", err)
+		}
+		created.StateData = stateData
+	}
+	return NewEvent(g.ID, EventCreated, g.Player1ID, g.CreatedAt, &created)
+}
+
+// Finished reports whether a game is over, whether completed or abandoned
+func (s GameStatus) Finished() bool {
+	return s == GameStatusCompleted || s == GameStatusAbandoned
+}
+
+// Apply changes the game as the event describes, returning the record the event adds to the move
+// log (for EventMove and EventTakenBack, otherwise nil). moveLog is the move log before the
+// event and is only read by EventTakenBack. Apply does not check that the event is allowed;
+// the services do that before adding it. A game left half-changed by an error is discarded.
+func (g *Game) Apply(e Event, moveLog []MoveRecord) (*MoveRecord, error) {
+	playerID := e.PlayerID
+	at := e.At
+
+	switch e.Type {
+	case EventCreated:
+		var created Game
+		if err := json.Unmarshal(e.Data, &created); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal created game: %w", err)
+		}
+		state, err := DefaultRegistry.DecodeState(created.Type, created.StateData)
+		if err != nil {
+			return nil, err
+		}
+		*g = created
+		g.State = state
+
+	case EventJoined:
+		var data JoinedData
+		if err := decodeEventData(e, &data); err != nil {
+			return nil, err
+		}
+		if data.Bot != nil {
+			bot := *data.Bot
+			g.Bot = &bot
+		}
+		if err := g.AddPlayer(playerID, data.Name); err != nil {
+			return nil, err
+		}
+		g.UpdatedAt = at
+		if g.Clock != nil {
+			g.Clock.AddPlayer(playerID)
+		}
+
+	case EventStarted:
+		var data StartedData
+		if err := decodeEventData(e, &data); err != nil {
+			return nil, err
+		}
+		// Two-player states seat the opponent and give the first move to the policy's pick;
+		// multiplayer states are built once everyone is seated
+		if g.IsMultiplayer() {
+			state, err := DefaultRegistry.NewStateForPlayers(g.Type, g.PlayerIDs(), g.Settings)
+			if err != nil {
+				return nil, err
+			}
+			g.State = state
+		} else {
+			if data.FirstPlayerID == nil {
+				return nil, errors.New("two-player games start with a first mover")
+			}
+			if setter, ok := g.State.(OpponentSetter); ok {
+				setter.SetPlayer2(g.Player2ID)
+			}
+			if err := g.SeatFirstMover(*data.FirstPlayerID); err != nil {
+				return nil, err
+			}
+		}
+		g.CurrentTurn = g.State.GetCurrentPlayer()
+		g.Begin(at)
+
+		// Start the clock for whoever moves first; games with a setup phase start it once play begins
+		if g.Clock != nil && g.Status == GameStatusActive {
+			g.Clock.Start(g.CurrentTurn, at)
+		}
+
+	case EventMove:
+		return g.applyMove(playerID, e.Data, at)
+
+	case EventSpectatorJoined:
+		var spectator Spectator
+		if err := decodeEventData(e, &spectator); err != nil {
+			return nil, err
+		}
+		g.Spectators = append(g.Spectators, spectator)
+		g.UpdatedAt = at
+
+	case EventSpectatorLeft:
+		spectators := make([]Spectator, 0, len(g.Spectators))
+		for _, spectator := range g.Spectators {
+			if spectator.UserID != playerID {
+				spectators = append(spectators, spectator)
+			}
+		}
+		g.Spectators = spectators
+		g.UpdatedAt = at
+
+	case EventResigned:
+		g.Forfeit(playerID, EndReasonResignation, at)
+	case EventTimedOut:
+		g.Forfeit(playerID, EndReasonTimeout, at)
+	case EventForfeited:
+		g.Forfeit(playerID, EndReasonDisconnect, at)
+	case EventAbandoned:
+		g.End(GameStatusAbandoned, nil, EndReasonDisconnect, at)
+	case EventAborted:
+		g.End(GameStatusAbandoned, nil, EndReasonAborted, at)
+	case EventDrawAgreed:
+		g.End(GameStatusCompleted, nil, EndReasonDrawAgreed, at)
+
+	case EventDrawOffered:
+		g.DrawOfferedBy = &playerID
+		g.UpdatedAt = at
+	case EventDrawDeclined:
+		g.DrawOfferedBy = nil
+		g.UpdatedAt = at
+	case EventTakebackRequested:
+		g.TakebackRequestedBy = &playerID
+		g.UpdatedAt = at
+	case EventTakebackDeclined:
+		g.TakebackRequestedBy = nil
+		g.UpdatedAt = at
+	case EventRematchOffered:
+		g.RematchOfferedBy = &playerID
+		g.UpdatedAt = at
+	case EventRematchDeclined:
+		g.RematchOfferedBy = nil
+		g.UpdatedAt = at
+
+	case EventPauseRequested:
+		g.PauseRequestedBy = &playerID
+		g.UpdatedAt = at
+	case EventPauseDeclined:
+		g.PauseRequestedBy = nil
+		g.UpdatedAt = at
+	case EventResumeRequested:
+		g.ResumeRequestedBy = &playerID
+		g.UpdatedAt = at
+	case EventResumeDeclined:
+		g.ResumeRequestedBy = nil
+		g.UpdatedAt = at
+
+	case EventPaused:
+		var data PausedData
+		if err := decodeEventData(e, &data); err != nil {
+			return nil, err
+		}
+		return nil, g.Pause(playerID, data.Admin, at)
+	case EventResumed:
+		return nil, g.Resume(at)
+
+	case EventTakenBack:
+		record, err := g.TakeBack(playerID, moveLog, at)
+		if err != nil {
+			return nil, err
+		}
+		record.Timestamp = at
+		return record, nil
+
+	case EventDisconnected:
+		if g.Disconnected == nil {
+			g.Disconnected = make(map[uuid.UUID]time.Time)
+		}
+		g.Disconnected[playerID] = at
+		g.UpdatedAt = at
+	case EventReconnected:
+		delete(g.Disconnected, playerID)
+		g.UpdatedAt = at
+
+	case EventNextGame:
+		var data NextGameData
+		if err := decodeEventData(e, &data); err != nil {
+			return nil, err
+		}
+		g.RematchOfferedBy = nil
+		g.NextGameID = &data.GameID
+		g.UpdatedAt = at
+	case EventNextGameReleased:
+		g.NextGameID = nil
+
+	default:
+		return nil, fmt.Errorf("unknown game event %q", e.Type)
+	}
+	return nil, nil
+}
+
+// applyMove plays a move, ending the game or handing the clock over as the move requires
+func (g *Game) applyMove(playerID uuid.UUID, moveData json.RawMessage, at time.Time) (*MoveRecord, error) {
+	// Engines receive moves in the generic form they arrive in from clients
+	var move interface{}
+	if err := json.Unmarshal(moveData, &move); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal move: %w", err)
+	}
+
+	// Apply move, rejecting one that leaves the position as it was (a frame applied twice)
+	before, err := Fingerprint(g.State)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fingerprint state: %w", err)
+	}
+	if err := g.State.ApplyMove(playerID, move); err != nil {
+		return nil, err
+	}
+	after, err := Fingerprint(g.State)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fingerprint state: %w", err)
+	}
+	if after == before {
+		return nil, ErrDuplicateFrame
+	}
+
+	// Record the accepted move in the move log
+	g.MoveCount++
+	record, err := NewMoveRecord(g.MoveCount, playerID, move, g.State)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record move: %w", err)
+	}
+	record.Timestamp = at
+
+	// Moving answers (declines) the opponent's draw offer
+	if g.DrawOfferedBy != nil && *g.DrawOfferedBy != playerID {
+		g.DrawOfferedBy = nil
+	}
+	// Any move withdraws or declines a pending takeback request
+	g.TakebackRequestedBy = nil
+
+	g.CurrentTurn = g.State.GetCurrentPlayer()
+	g.UpdatedAt = at
+
+	// Check for winner, otherwise hand the clock to the next player. The clock first starts
+	// when the last setup move ends the setup phase.
+	winner, gameOver := g.State.CheckWinner()
+	switch {
+	case gameOver:
+		g.End(GameStatusCompleted, winner, EndReasonNormal, at)
+	case g.Status == GameStatusSetup:
+		if g.FinishSetup() && g.Clock != nil {
+			g.Clock.Start(g.CurrentTurn, at)
+		}
+	case g.Clock != nil:
+		g.Clock.Switch(g.CurrentTurn, at)
+	}
+	return record, nil
+}
+
+// decodeEventData unmarshals the data of an event
+func decodeEventData(e Event, data interface{}) error {
+	if len(e.Data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(e.Data, data); err != nil {
+		return fmt.Errorf("failed to unmarshal %s event: %w", e.Type, err)
+	}
+	return nil
+}
+
+// Rebuild replays a game's events, which must start with its EventCreated, and returns the
+// game as it was last saved
+func Rebuild(events []Event) (*Game, error) {
+	if len(events) == 0 || events[0].Type != EventCreated {
+		return nil, errors.New("a game's events start with its creation")
+	}
+
+	g := &Game{}
+	var moveLog []MoveRecord
+	for _, event := range events {
+		record, err := g.Apply(event, moveLog)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply %s event at version %d: %w", event.Type, event.Version, err)
+		}
+		if record != nil {
+			moveLog = append(moveLog, *record)
+		}
+		g.Version = event.Version
+	}
+
+	stateData, err := json.Marshal(g.State.GetState())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal game state: %w", err)
+	}
+	g.StateData = stateData
+	if g.StateFingerprint, err = StateFingerprint(g.State); err != nil {
+		return nil, err
+	}
+	if g.Spectators == nil {
+		g.Spectators = []Spectator{}
+	}
+	return g, nil
+}
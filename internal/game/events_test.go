@@ -0,0 +1,199 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// eventSourcedGame is a game changed only through events, together with its event stream and
+// move log
+type eventSourcedGame struct {
+	*Game
+	events []Event
+	log    []MoveRecord
+	now    time.Time
+}
+
+// newEventSourcedGame creates a game of gameType the way the game service does, with player1
+// waiting for an opponent
+func newEventSourcedGame(t *testing.T, gameType GameType, player1 uuid.UUID, settings map[string]interface{}) *eventSourcedGame {
+	t.Helper()
+	settings, err := DefaultRegistry.ValidateSettings(gameType, settings)
+	require.NoError(t, err)
+	state, err := DefaultRegistry.NewState(gameType, player1, uuid.Nil, settings)
+	require.NoError(t, err)
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	g := &Game{
+		ID: uuid.New(), Type: gameType, Status: GameStatusWaiting,
+		Player1ID: player1, Player1Name: "Alice",
+		Seats:       []Seat{{Index: 0, PlayerID: player1, Name: "Alice"}},
+		SeatCount:   PlayersFromSettings(settings),
+		CurrentTurn: player1, State: state, Settings: settings,
+		Clock:      NewGameClock(TimeControlFromSettings(settings), player1),
+		Spectators: []Spectator{},
+		CreatedAt:  now, UpdatedAt: now,
+	}
+	created, err := NewCreatedEvent(g)
+	require.NoError(t, err)
+
+	sg := &eventSourcedGame{Game: g, now: now}
+	require.NoError(t, sg.store(created))
+	return sg
+}
+
+// store stamps an event as saved and adds it to the stream as it would be read back
+func (sg *eventSourcedGame) store(e Event) error {
+	e.Version = int64(len(sg.events) + 1)
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	var stored Event
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+	sg.events = append(sg.events, stored)
+	sg.Version = e.Version
+	return nil
+}
+
+// emit applies an event to the live game and stores it
+func (sg *eventSourcedGame) emit(t *testing.T, eventType EventType, playerID uuid.UUID, data interface{}) {
+	t.Helper()
+	require.NoError(t, sg.tryEmit(eventType, playerID, data))
+}
+
+// tryEmit applies an event to the live game, storing it if it applied
+func (sg *eventSourcedGame) tryEmit(eventType EventType, playerID uuid.UUID, data interface{}) error {
+	sg.now = sg.now.Add(time.Second)
+	e, err := NewEvent(sg.ID, eventType, playerID, sg.now, data)
+	if err != nil {
+		return err
+	}
+	record, err := sg.Apply(e, sg.log)
+	if err != nil {
+		return err
+	}
+	if record != nil {
+		sg.log = append(sg.log, *record)
+	}
+	return sg.store(e)
+}
+
+// assertRebuilt checks that rebuilding the stream gives the live game
+func (sg *eventSourcedGame) assertRebuilt(t *testing.T) *Game {
+	t.Helper()
+	rebuilt, err := Rebuild(sg.events)
+	require.NoError(t, err)
+
+	live := *sg.Game
+	live.StateData, err = json.Marshal(live.State.GetState())
+	require.NoError(t, err)
+	live.StateFingerprint, err = StateFingerprint(live.State)
+	require.NoError(t, err)
+
+	want, err := json.Marshal(&live)
+	require.NoError(t, err)
+	got, err := json.Marshal(rebuilt)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(want), string(got))
+	return rebuilt
+}
+
+// TestRebuild tests that a game rebuilt from its events matches the game they were applied to
+func TestRebuild(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	t.Run("Casual Game With Draw Offer, Takeback And Resignation", func(t *testing.T) {
+		sg := newEventSourcedGame(t, GameTypeTicTacToe, player1, nil)
+		sg.emit(t, EventJoined, player2, JoinedData{Name: "Bob"})
+		sg.emit(t, EventStarted, uuid.Nil, StartedData{FirstPlayerID: &player1})
+		sg.emit(t, EventSpectatorJoined, uuid.Nil, Spectator{UserID: uuid.New(), Username: "carol"})
+		sg.emit(t, EventMove, player1, TicTacToeMove{Row: 1, Col: 1})
+		sg.emit(t, EventMove, player2, TicTacToeMove{Row: 0, Col: 0})
+		sg.emit(t, EventDrawOffered, player1, nil)
+		sg.emit(t, EventDrawDeclined, player2, nil)
+		sg.emit(t, EventMove, player1, TicTacToeMove{Row: 2, Col: 2})
+		sg.emit(t, EventTakebackRequested, player1, nil)
+		sg.emit(t, EventTakenBack, player1, nil)
+		sg.emit(t, EventMove, player1, TicTacToeMove{Row: 0, Col: 2})
+		assert.Equal(t, GameStatusActive, sg.Status)
+		sg.assertRebuilt(t)
+
+		sg.emit(t, EventResigned, player2, nil)
+		rebuilt := sg.assertRebuilt(t)
+		assert.Equal(t, GameStatusCompleted, rebuilt.Status)
+		assert.Equal(t, &player1, rebuilt.WinnerID)
+		assert.Equal(t, EndReasonResignation, rebuilt.EndReason)
+		assert.Equal(t, 3, rebuilt.MoveCount)
+		assert.Len(t, rebuilt.Spectators, 1)
+		assert.Equal(t, int64(len(sg.events)), rebuilt.Version)
+	})
+
+	t.Run("Game Won On The Board With A Clock", func(t *testing.T) {
+		sg := newEventSourcedGame(t, GameTypeConnect4, player1, map[string]interface{}{
+			"time_control":           "total",
+			"time_total_seconds":     float64(60),
+			"time_increment_seconds": float64(2),
+		})
+		sg.emit(t, EventJoined, player2, JoinedData{Name: "Bob"})
+		sg.emit(t, EventStarted, uuid.Nil, StartedData{FirstPlayerID: &player2})
+		for i := 0; i < 3; i++ {
+			sg.emit(t, EventMove, player2, Connect4Move{Column: 0})
+			sg.emit(t, EventMove, player1, Connect4Move{Column: 1})
+		}
+		sg.emit(t, EventMove, player2, Connect4Move{Column: 0})
+
+		rebuilt := sg.assertRebuilt(t)
+		assert.Equal(t, GameStatusCompleted, rebuilt.Status)
+		assert.Equal(t, &player2, rebuilt.WinnerID)
+		assert.Equal(t, player2, rebuilt.FirstMover())
+	})
+
+	t.Run("Bot Seat", func(t *testing.T) {
+		sg := newEventSourcedGame(t, GameTypeTicTacToe, player1, nil)
+		bot := &BotSeat{PlayerID: uuid.New(), Difficulty: BotEasy}
+		sg.emit(t, EventJoined, bot.PlayerID, JoinedData{Name: "Bot", Bot: bot})
+		sg.emit(t, EventStarted, uuid.Nil, StartedData{FirstPlayerID: &player1})
+		sg.emit(t, EventMove, player1, TicTacToeMove{Row: 0, Col: 0})
+
+		rebuilt := sg.assertRebuilt(t)
+		require.NotNil(t, rebuilt.Bot)
+		assert.Equal(t, bot.PlayerID, rebuilt.Player2ID)
+	})
+
+	t.Run("Rejected Events Are Not Stored", func(t *testing.T) {
+		sg := newEventSourcedGame(t, GameTypeTicTacToe, player1, nil)
+		sg.emit(t, EventJoined, player2, JoinedData{Name: "Bob"})
+		sg.emit(t, EventStarted, uuid.Nil, StartedData{FirstPlayerID: &player1})
+
+		assert.Error(t, sg.tryEmit(EventMove, player2, TicTacToeMove{Row: 0, Col: 0}), "not their turn")
+		assert.Error(t, sg.tryEmit(EventType("teleported"), player1, nil))
+		assert.Len(t, sg.events, 3)
+	})
+
+	t.Run("Events Start With Creation", func(t *testing.T) {
+		sg := newEventSourcedGame(t, GameTypeTicTacToe, player1, nil)
+		sg.emit(t, EventJoined, player2, JoinedData{Name: "Bob"})
+
+		_, err := Rebuild(sg.events[1:])
+		assert.Error(t, err)
+		_, err = Rebuild(nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("Unknown Event", func(t *testing.T) {
+		sg := newEventSourcedGame(t, GameTypeTicTacToe, player1, nil)
+		sg.events = append(sg.events, Event{GameID: sg.ID, Version: 2, Type: "teleported"})
+
+		_, err := Rebuild(sg.events)
+		assert.ErrorContains(t, err, "teleported")
+	})
+}
@@ -0,0 +1,180 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/google/uuid"
+)
+
+// ErrStateCorrupted is returned when a restored state does not match the fingerprint it was saved with
+var ErrStateCorrupted = errors.New("game state does not match its fingerprint")
+
+// ErrDuplicateFrame is returned for a move that leaves the position exactly as it was, such as
+// the same move frame being applied twice
+var ErrDuplicateFrame = errors.New("move does not change the position")
+
+// Fingerprinter is implemented by game states that keep a Zobrist hash of their position up to
+// date on every ApplyMove. The same position reached through a different move order has the same
+// fingerprint, which makes it suitable for repetition rules and opening books.
+type Fingerprinter interface {
+	Fingerprint() uint64
+}
+
+// zobristHashed is implemented by the board engines, which store their position hash alongside
+// the board so it can be checked against the board when the state is restored
+type zobristHashed interface {
+	Fingerprinter
+	hashPosition() uint64 // Recomputes the hash from the board
+}
+
+// Fingerprint returns a 64-bit fingerprint of a state. Board games use their Zobrist hash; other
+// games hash their canonical serialization.
+func Fingerprint(state GameState) (uint64, error) {
+	if fingerprinter, ok := state.(Fingerprinter); ok {
+		return fingerprinter.Fingerprint(), nil
+	}
+	data, err := CanonicalState(state)
+	if err != nil {
+		return 0, err
+	}
+	hash := fnv.New64a()
+	hash.Write(data)
+	return hash.Sum64(), nil
+}
+
+// FingerprintKey formats a fingerprint as fixed-width hex, the form used in JSON and map keys
+func FingerprintKey(fingerprint uint64) string {
+	return fmt.Sprintf("%016x", fingerprint)
+}
+
+// StateFingerprint returns the FingerprintKey of a state
+func StateFingerprint(state GameState) (string, error) {
+	fingerprint, err := Fingerprint(state)
+	if err != nil {
+		return "", err
+	}
+	return FingerprintKey(fingerprint), nil
+}
+
+// CanonicalState returns the canonical serialization of a state. Struct fields are written in
+// declaration order and map keys sorted, so equal states always serialize to the same bytes.
+func CanonicalState(state GameState) ([]byte, error) {
+	return json.Marshal(state.GetState())
+}
+
+// VerifyState checks a state restored from storage: a board game's stored position hash must
+// match its board, and the state must match expected (a StateFingerprint) unless that is empty
+func VerifyState(state GameState, expected string) error {
+	if hashed, ok := state.(zobristHashed); ok && hashed.Fingerprint() != hashed.hashPosition() {
+		return ErrStateCorrupted
+	}
+	if expected == "" {
+		return nil
+	}
+	fingerprint, err := StateFingerprint(state)
+	if err != nil {
+		return err
+	}
+	if fingerprint != expected {
+		return ErrStateCorrupted
+	}
+	return nil
+}
+
+// PositionHistory counts how often each position has occurred, keyed by FingerprintKey, for
+// repetition draw rules
+type PositionHistory map[string]int
+
+// Record counts another occurrence of a position and returns how often it has occurred
+func (h PositionHistory) Record(fingerprint uint64) int {
+	key := FingerprintKey(fingerprint)
+	h[key]++
+	return h[key]
+}
+
+// Count returns how often a position has occurred
+func (h PositionHistory) Count(fingerprint uint64) int {
+	return h[FingerprintKey(fingerprint)]
+}
+
+// Clone creates a copy of the history
+func (h PositionHistory) Clone() PositionHistory {
+	clone := make(PositionHistory, len(h))
+	for key, count := range h {
+		clone[key] = count
+	}
+	return clone
+}
+
+// OpeningBook maps positions, by fingerprint, to the move a bot plays in them
+type OpeningBook map[uint64]interface{}
+
+// Add records the move to play in a position
+func (b OpeningBook) Add(state GameState, move interface{}) error {
+	fingerprint, err := Fingerprint(state)
+	if err != nil {
+		return err
+	}
+	b[fingerprint] = move
+	return nil
+}
+
+// Lookup returns the book move for a position, if there is one
+func (b OpeningBook) Lookup(state GameState) (interface{}, bool) {
+	fingerprint, err := Fingerprint(state)
+	if err != nil {
+		return nil, false
+	}
+	move, ok := b[fingerprint]
+	return move, ok
+}
+
+// zobristSalt seeds the Zobrist keys of a game type and its board parameters, so that positions
+// of different games or board sizes never share keys
+func zobristSalt(gameType GameType, params ...interface{}) uint64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(gameType))
+	for _, param := range params {
+		fmt.Fprintf(hash, ":%v", param)
+	}
+	return hash.Sum64()
+}
+
+// zobristKey returns the key of a piece (a single-letter board value) on a square. Keys are
+// derived with splitmix64 rather than drawn from a random table, so fingerprints are the same in
+// every process and can be stored.
+func zobristKey(salt uint64, square int, piece byte) uint64 {
+	return splitmix64(salt ^ uint64(square)<<8 ^ uint64(piece))
+}
+
+// zobristBoard hashes the pieces on a board and the side to move
+func zobristBoard(salt uint64, board [][]string, side int) uint64 {
+	hash := zobristKey(salt, -1, byte(side))
+	for row := range board {
+		for col, piece := range board[row] {
+			if piece != "" {
+				hash ^= zobristKey(salt, row*len(board[row])+col, piece[0])
+			}
+		}
+	}
+	return hash
+}
+
+// sideToMove returns the seat index of the player to move in a two-player game
+func sideToMove(currentPlayer, player1ID uuid.UUID) int {
+	if currentPlayer == player1ID {
+		return 0
+	}
+	return 1
+}
+
+// splitmix64 scrambles x into a well-distributed 64-bit value
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
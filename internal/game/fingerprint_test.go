@@ -0,0 +1,175 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFingerprint tests position fingerprints and the Zobrist hashes of the board engines
+func TestFingerprint(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	t.Run("Move Order Does Not Matter", func(t *testing.T) {
+		a := NewTicTacToeState(player1, player2)
+		b := NewTicTacToeState(player1, player2)
+		for _, move := range []TicTacToeMove{{Row: 0, Col: 0}, {Row: 1, Col: 1}, {Row: 2, Col: 2}} {
+			assert.NoError(t, a.ApplyMove(a.CurrentPlayer, move))
+		}
+		for _, move := range []TicTacToeMove{{Row: 2, Col: 2}, {Row: 1, Col: 1}, {Row: 0, Col: 0}} {
+			assert.NoError(t, b.ApplyMove(b.CurrentPlayer, move))
+		}
+		assert.Equal(t, a.Fingerprint(), b.Fingerprint())
+
+		other := NewTicTacToeState(uuid.New(), uuid.New())
+		for _, move := range []TicTacToeMove{{Row: 0, Col: 0}, {Row: 1, Col: 1}, {Row: 2, Col: 2}} {
+			assert.NoError(t, other.ApplyMove(other.CurrentPlayer, move))
+		}
+		assert.Equal(t, a.Fingerprint(), other.Fingerprint(), "fingerprints depend on seats, not player IDs")
+	})
+
+	t.Run("Games And Sizes Never Share Positions", func(t *testing.T) {
+		seen := make(map[uint64]string)
+		for name, state := range map[string]GameState{
+			"tictactoe 3":  NewTicTacToeStateWithSize(player1, player2, 3, 3),
+			"tictactoe 4":  NewTicTacToeStateWithSize(player1, player2, 4, 3),
+			"connect4 6x7": NewConnect4State(player1, player2),
+			"connect4 7x7": NewConnect4StateWithSize(player1, player2, 7, 7, 4),
+			"gomoku":       NewGomokuState(player1, player2),
+			"othello":      NewOthelloState(player1, player2),
+			"checkers":     NewCheckersState(player1, player2),
+		} {
+			fingerprint, err := Fingerprint(state)
+			assert.NoError(t, err)
+			assert.NotContains(t, seen, fingerprint, name)
+			seen[fingerprint] = name
+		}
+	})
+
+	t.Run("Every Move Updates The Hash", func(t *testing.T) {
+		for _, gameType := range []GameType{GameTypeTicTacToe, GameTypeConnect4, GameTypeGomoku, GameTypeOthello, GameTypeCheckers} {
+			state, err := DefaultRegistry.NewState(gameType, player1, player2, nil)
+			assert.NoError(t, err)
+			seen := map[uint64]bool{state.(Fingerprinter).Fingerprint(): true}
+			for i := 0; i < 6; i++ {
+				moves := state.LegalMoves()
+				assert.NotEmpty(t, moves, gameType)
+				assert.NoError(t, state.ApplyMove(state.GetCurrentPlayer(), moves[len(moves)/2]))
+
+				hashed := state.(zobristHashed)
+				assert.Equal(t, hashed.hashPosition(), hashed.Fingerprint(), gameType)
+				assert.False(t, seen[hashed.Fingerprint()], gameType)
+				seen[hashed.Fingerprint()] = true
+			}
+		}
+	})
+
+	t.Run("Games Without A Board Hash Their Serialization", func(t *testing.T) {
+		state := NewRPSState(player1, player2)
+		before, err := Fingerprint(state)
+		assert.NoError(t, err)
+		again, _ := Fingerprint(state.Clone())
+		assert.Equal(t, before, again)
+
+		assert.NoError(t, state.ApplyMove(player1, RPSMove{Choice: RPSChoiceRock}))
+		after, _ := Fingerprint(state)
+		assert.NotEqual(t, before, after)
+	})
+
+	t.Run("Swap2 Phases Are Distinct Positions", func(t *testing.T) {
+		state := NewGomokuStateWithOptions(player1, player2, 15, GomokuRuleFreestyle, GomokuOpeningSwap2)
+		for _, move := range []GomokuMove{{Row: 7, Col: 7}, {Row: 7, Col: 8}, {Row: 8, Col: 7}} {
+			assert.NoError(t, state.ApplyMove(state.CurrentPlayer, move))
+		}
+		before := state.Fingerprint()
+		assert.NoError(t, state.ApplyMove(player2, GomokuMove{Choice: GomokuChoiceSwap}))
+		assert.NotEqual(t, before, state.Fingerprint(), "a choice changes the position without placing a stone")
+	})
+}
+
+// TestVerifyState tests the integrity checks made when a state is restored
+func TestVerifyState(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+	state := NewConnect4State(player1, player2)
+	assert.NoError(t, state.ApplyMove(player1, Connect4Move{Column: 2}))
+	expected, err := StateFingerprint(state)
+	assert.NoError(t, err)
+	data, _ := json.Marshal(state.GetState())
+
+	t.Run("Round Trip Verifies", func(t *testing.T) {
+		decoded, err := DefaultRegistry.DecodeState(GameTypeConnect4, data)
+		assert.NoError(t, err)
+		assert.NoError(t, VerifyState(decoded, expected))
+		assert.NoError(t, VerifyState(decoded, ""))
+	})
+
+	t.Run("Tampered Board Detected", func(t *testing.T) {
+		decoded, _ := DefaultRegistry.DecodeState(GameTypeConnect4, data)
+		decoded.(*Connect4State).Board[5][3] = "Y"
+		assert.ErrorIs(t, VerifyState(decoded, ""), ErrStateCorrupted)
+	})
+
+	t.Run("Wrong Expected Fingerprint Detected", func(t *testing.T) {
+		rps := NewRPSState(player1, player2)
+		other := NewRPSState(player1, player2)
+		assert.NoError(t, other.ApplyMove(player2, RPSMove{Choice: RPSChoicePaper}))
+		otherFingerprint, _ := StateFingerprint(other)
+		assert.ErrorIs(t, VerifyState(rps, otherFingerprint), ErrStateCorrupted)
+	})
+
+	t.Run("States Saved Without A Hash Still Verify", func(t *testing.T) {
+		var raw map[string]interface{}
+		json.Unmarshal(data, &raw)
+		delete(raw, "position_hash")
+		legacy, _ := json.Marshal(raw)
+
+		decoded, err := DefaultRegistry.DecodeState(GameTypeConnect4, legacy)
+		assert.NoError(t, err)
+		assert.NoError(t, VerifyState(decoded, expected))
+	})
+}
+
+// TestOpeningBook tests looking up bot moves by position
+func TestOpeningBook(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	t.Run("Lookup By Position", func(t *testing.T) {
+		book := OpeningBook{}
+		state := NewTicTacToeState(player1, player2)
+		assert.NoError(t, book.Add(state, TicTacToeMove{Row: 1, Col: 1}))
+
+		move, ok := book.Lookup(NewTicTacToeState(uuid.New(), uuid.New()))
+		assert.True(t, ok)
+		assert.Equal(t, TicTacToeMove{Row: 1, Col: 1}, move)
+
+		_, ok = book.Lookup(NewTicTacToeStateWithSize(player1, player2, 4, 4))
+		assert.False(t, ok)
+	})
+
+	t.Run("Connect4 Bot Opens In The Centre", func(t *testing.T) {
+		state := NewConnect4State(player1, player2)
+		bot, _ := NewBot(GameTypeConnect4, BotHard, nil)
+		move, err := bot.ChooseMove(state, player1)
+		assert.NoError(t, err)
+		assert.Equal(t, Connect4Move{Column: 3}, move)
+	})
+}
+
+// TestPositionHistory tests counting repeated positions
+func TestPositionHistory(t *testing.T) {
+	history := PositionHistory{}
+	assert.Equal(t, 1, history.Record(42))
+	assert.Equal(t, 2, history.Record(42))
+	assert.Equal(t, 0, history.Count(7))
+
+	clone := history.Clone()
+	clone.Record(42)
+	assert.Equal(t, 2, history.Count(42))
+	assert.Contains(t, history, FingerprintKey(42))
+	assert.Equal(t, "000000000000002a", FingerprintKey(42))
+}
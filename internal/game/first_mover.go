@@ -0,0 +1,92 @@
+package game
+
+import (
+	"math/rand"
+
+	"github.com/google/uuid"
+)
+
+// FirstMoverPolicy decides which player of a two-player game moves first. Engines give the
+// first move to seat 0, so the chosen player is seated there.
+type FirstMoverPolicy string
+
+const (
+	FirstMoverHost       FirstMoverPolicy = "host"        // Player 1 (room host, first matchmaking entry, upper bracket slot) always moves first
+	FirstMoverRandom     FirstMoverPolicy = "random"      // A coin flip for every game
+	FirstMoverAlternate  FirstMoverPolicy = "alternate"   // Player 1 moves first, then the players take turns in rematches and series games
+	FirstMoverLowerRated FirstMoverPolicy = "lower_rated" // The lower-rated player moves first; equal ratings are a coin flip
+)
+
+// SettingFirstMover is the setting key of the first-mover policy, shared by every game type
+const SettingFirstMover = "first_mover"
+
+// firstMoverSettings are validated for every registered game type
+var firstMoverSettings = []SettingSchema{
+	{Key: SettingFirstMover, Label: "First move", Type: SettingTypeString, Default: string(FirstMoverAlternate),
+		Options: []interface{}{string(FirstMoverHost), string(FirstMoverRandom), string(FirstMoverAlternate), string(FirstMoverLowerRated)}},
+}
+
+// FirstMoverFromSettings reads the first-mover policy from validated game settings
+func FirstMoverFromSettings(settings map[string]interface{}) FirstMoverPolicy {
+	policy, _ := settings[SettingFirstMover].(string)
+	switch FirstMoverPolicy(policy) {
+	case FirstMoverHost, FirstMoverRandom, FirstMoverLowerRated:
+		return FirstMoverPolicy(policy)
+	}
+	return FirstMoverAlternate
+}
+
+// FirstMoverContext is what a policy may need to know to pick the first mover
+type FirstMoverContext struct {
+	PreviousFirst *uuid.UUID        // Who moved first in the game this one follows (rematch or series), if any
+	Ratings       map[uuid.UUID]int // Player ratings, used by FirstMoverLowerRated
+	Rand          *rand.Rand        // Source of coin flips; nil uses the shared source
+}
+
+// ChooseFirstMover returns which of player1 and player2 moves first under the policy
+func ChooseFirstMover(policy FirstMoverPolicy, player1ID, player2ID uuid.UUID, ctx FirstMoverContext) uuid.UUID {
+	switch policy {
+	case FirstMoverHost:
+		// In a rematch player 1 is whoever moved first before, which by now is the host
+		if ctx.PreviousFirst != nil && (*ctx.PreviousFirst == player1ID || *ctx.PreviousFirst == player2ID) {
+			return *ctx.PreviousFirst
+		}
+		return player1ID
+	case FirstMoverRandom:
+		return ctx.coinFlip(player1ID, player2ID)
+	case FirstMoverLowerRated:
+		rating1, ok1 := ctx.Ratings[player1ID]
+		rating2, ok2 := ctx.Ratings[player2ID]
+		switch {
+		case !ok1 || !ok2 || rating1 == rating2:
+			return ctx.coinFlip(player1ID, player2ID)
+		case rating1 < rating2:
+			return player1ID
+		}
+		return player2ID
+	}
+
+	if ctx.PreviousFirst != nil {
+		switch *ctx.PreviousFirst {
+		case player1ID:
+			return player2ID
+		case player2ID:
+			return player1ID
+		}
+	}
+	return player1ID
+}
+
+// coinFlip picks one of the two players at random
+func (ctx FirstMoverContext) coinFlip(player1ID, player2ID uuid.UUID) uuid.UUID {
+	var heads bool
+	if ctx.Rand != nil {
+		heads = ctx.Rand.Intn(2) == 0
+	} else {
+		heads = rand.Intn(2) == 0
+	}
+	if heads {
+		return player1ID
+	}
+	return player2ID
+}
@@ -0,0 +1,105 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestChooseFirstMover tests each first-mover policy
+func TestChooseFirstMover(t *testing.T) {
+	host := uuid.New()
+	guest := uuid.New()
+
+	t.Run("Host", func(t *testing.T) {
+		assert.Equal(t, host, ChooseFirstMover(FirstMoverHost, host, guest, FirstMoverContext{}))
+		assert.Equal(t, host, ChooseFirstMover(FirstMoverHost, guest, host, FirstMoverContext{PreviousFirst: &host}), "the host keeps the first move in rematches")
+	})
+
+	t.Run("Alternate", func(t *testing.T) {
+		assert.Equal(t, host, ChooseFirstMover(FirstMoverAlternate, host, guest, FirstMoverContext{}))
+		assert.Equal(t, guest, ChooseFirstMover(FirstMoverAlternate, host, guest, FirstMoverContext{PreviousFirst: &host}))
+		assert.Equal(t, host, ChooseFirstMover(FirstMoverAlternate, host, guest, FirstMoverContext{PreviousFirst: &guest}))
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(1))
+		seen := map[uuid.UUID]int{}
+		for i := 0; i < 50; i++ {
+			seen[ChooseFirstMover(FirstMoverRandom, host, guest, FirstMoverContext{Rand: rng})]++
+		}
+		assert.Len(t, seen, 2, "both players get to move first")
+	})
+
+	t.Run("Lower Rated", func(t *testing.T) {
+		ratings := map[uuid.UUID]int{host: 1400, guest: 1200}
+		assert.Equal(t, guest, ChooseFirstMover(FirstMoverLowerRated, host, guest, FirstMoverContext{Ratings: ratings}))
+		ratings[guest] = 1600
+		assert.Equal(t, host, ChooseFirstMover(FirstMoverLowerRated, host, guest, FirstMoverContext{Ratings: ratings}))
+
+		ratings[guest] = 1400
+		first := ChooseFirstMover(FirstMoverLowerRated, host, guest, FirstMoverContext{Ratings: ratings, Rand: rand.New(rand.NewSource(1))})
+		assert.Contains(t, []uuid.UUID{host, guest}, first, "equal ratings are a coin flip")
+	})
+
+	t.Run("Policy From Settings", func(t *testing.T) {
+		settings, _ := DefaultRegistry.ValidateSettings(GameTypeConnect4, map[string]interface{}{SettingFirstMover: "lower_rated"})
+		assert.Equal(t, FirstMoverLowerRated, FirstMoverFromSettings(settings))
+		settings, _ = DefaultRegistry.ValidateSettings(GameTypeConnect4, map[string]interface{}{SettingFirstMover: "loser"})
+		assert.Equal(t, FirstMoverAlternate, FirstMoverFromSettings(settings))
+	})
+}
+
+// TestSeatFirstMover tests giving the first move to either player before a game begins
+func TestSeatFirstMover(t *testing.T) {
+	host := uuid.New()
+	guest := uuid.New()
+	newGame := func() *Game {
+		state, _ := DefaultRegistry.NewState(GameTypeConnect4, host, guest, nil)
+		return &Game{
+			Type: GameTypeConnect4, Status: GameStatusWaiting, State: state,
+			Player1ID: host, Player1Name: "host", Player2ID: guest, Player2Name: "guest",
+			Seats: []Seat{{Index: 0, PlayerID: host, Name: "host"}, {Index: 1, PlayerID: guest, Name: "guest"}},
+		}
+	}
+
+	t.Run("Player 1 Keeps The Seat", func(t *testing.T) {
+		g := newGame()
+		assert.NoError(t, g.SeatFirstMover(host))
+		assert.Equal(t, host, g.Player1ID)
+		assert.Equal(t, host, g.CurrentTurn)
+		assert.Equal(t, host, *g.FirstPlayerID)
+	})
+
+	t.Run("Player 2 Moves First", func(t *testing.T) {
+		g := newGame()
+		assert.NoError(t, g.SeatFirstMover(guest))
+		assert.Equal(t, guest, g.Player1ID)
+		assert.Equal(t, "guest", g.Player1Name)
+		assert.Equal(t, []uuid.UUID{guest, host}, g.PlayerIDs())
+		assert.Equal(t, guest, g.CurrentTurn)
+		assert.Equal(t, guest, g.State.GetCurrentPlayer())
+		assert.Equal(t, guest, g.FirstMover())
+		assert.NoError(t, g.State.ApplyMove(guest, map[string]interface{}{"column": float64(3)}))
+	})
+
+	t.Run("Only Players Can Move First", func(t *testing.T) {
+		g := newGame()
+		assert.Error(t, g.SeatFirstMover(uuid.New()))
+	})
+
+	t.Run("Rematch Follows The Policy", func(t *testing.T) {
+		g := newGame()
+		g.Settings = map[string]interface{}{SettingFirstMover: string(FirstMoverHost)}
+		assert.NoError(t, g.SeatFirstMover(host))
+		g.End(GameStatusCompleted, &host, EndReasonNormal, time.Now())
+
+		next, err := g.Rematch(time.Now(), FirstMoverContext{})
+		assert.NoError(t, err)
+		assert.Equal(t, host, next.FirstMover(), "the host moves first again")
+		assert.Equal(t, host, next.CurrentTurn)
+	})
+}
@@ -0,0 +1,92 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// fuzzMoveParser fuzzes a move parser with the JSON a client could send. Anything that decodes
+// must be rejected or parsed without panicking, and a parsed move must then be validated against
+// a fresh game by player 1: a move that validates must apply, and one that does not must leave
+// the state untouched.
+func fuzzMoveParser[T any](f *testing.F, parse func(move interface{}) (*T, error), newState func(player1, player2 uuid.UUID) GameState, seeds ...string) {
+	for _, seed := range append(seeds, `null`, `{}`, `[]`, `"move"`, `42`) {
+		f.Add([]byte(seed))
+	}
+	player1 := uuid.MustParse("00000000-0000-4000-8000-000000000001")
+	player2 := uuid.MustParse("00000000-0000-4000-8000-000000000002")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var move interface{}
+		if err := json.Unmarshal(data, &move); err != nil {
+			return
+		}
+		parsed, err := parse(move)
+		if err != nil {
+			return
+		}
+		require.NotNil(t, parsed)
+
+		state := newState(player1, player2)
+		before := canonicalJSON(t, state)
+		if err := state.ValidateMove(player1, move); err != nil {
+			require.Error(t, state.ApplyMove(player1, move))
+			require.Equal(t, before, canonicalJSON(t, state), "a rejected move changed the state")
+			return
+		}
+		require.NoError(t, state.ApplyMove(player1, move))
+	})
+}
+
+func FuzzParseTicTacToeMove(f *testing.F) {
+	fuzzMoveParser(f, parseTicTacToeMove, func(player1, player2 uuid.UUID) GameState {
+		return NewTicTacToeStateWithSize(player1, player2, 4, 3)
+	}, `{"row":1,"col":2}`, `{"row":-1,"col":0}`, `{"row":4,"col":4}`, `{"row":"1"}`)
+}
+
+func FuzzParseConnect4Move(f *testing.F) {
+	fuzzMoveParser(f, parseConnect4Move, func(player1, player2 uuid.UUID) GameState {
+		return NewConnect4State(player1, player2)
+	}, `{"column":3}`, `{"column":-1}`, `{"column":7}`, `{"column":1e9}`)
+}
+
+func FuzzParseRPSMove(f *testing.F) {
+	fuzzMoveParser(f, parseRPSMove, func(player1, player2 uuid.UUID) GameState {
+		return NewRPSState(player1, player2)
+	}, `{"choice":"rock"}`, `{"choice":"lizard"}`, `{"commitment":"abc"}`, `{"choice":"paper","salt":"x"}`)
+}
+
+func FuzzParseDotsAndBoxesMove(f *testing.F) {
+	fuzzMoveParser(f, parseDotsAndBoxesMove, func(player1, player2 uuid.UUID) GameState {
+		return NewDotsAndBoxesState(player1, player2)
+	}, `{"row":0,"col":0,"orientation":"horizontal"}`, `{"row":3,"col":4,"orientation":"vertical"}`, `{"row":0,"col":0,"orientation":"diagonal"}`)
+}
+
+func FuzzParseGomokuMove(f *testing.F) {
+	fuzzMoveParser(f, parseGomokuMove, func(player1, player2 uuid.UUID) GameState {
+		return NewGomokuStateWithOptions(player1, player2, 15, GomokuRuleFreestyle, GomokuOpeningSwap2)
+	}, `{"row":7,"col":7}`, `{"row":15,"col":0}`, `{"choice":"swap"}`, `{"choice":"black","row":1}`)
+}
+
+func FuzzParseCheckersMove(f *testing.F) {
+	fuzzMoveParser(f, parseCheckersMove, func(player1, player2 uuid.UUID) GameState {
+		return NewCheckersState(player1, player2)
+	}, `{"path":[{"row":5,"col":0},{"row":4,"col":1}]}`, `{"path":[{"row":5,"col":0}]}`,
+		`{"path":[{"row":5,"col":2},{"row":3,"col":4},{"row":9,"col":9}]}`, `{"path":null}`)
+}
+
+func FuzzParseOthelloMove(f *testing.F) {
+	fuzzMoveParser(f, parseOthelloMove, func(player1, player2 uuid.UUID) GameState {
+		return NewOthelloState(player1, player2)
+	}, `{"row":2,"col":3}`, `{"row":3,"col":3}`, `{"row":-1,"col":8}`)
+}
+
+func FuzzParseBattleshipMove(f *testing.F) {
+	fuzzMoveParser(f, parseBattleshipMove, func(player1, player2 uuid.UUID) GameState {
+		return NewBattleshipState(player1, player2)
+	}, `{"ships":[{"name":"carrier","row":0,"col":0},{"name":"battleship","row":1,"col":0},{"name":"cruiser","row":2,"col":0},{"name":"submarine","row":3,"col":0},{"name":"destroyer","row":4,"col":0}]}`,
+		`{"row":0,"col":0}`, `{"ships":[{"name":"carrier","row":-5,"col":0,"vertical":true}]}`)
+}
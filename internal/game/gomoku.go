@@ -0,0 +1,427 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultGomokuBoardSize = 15
+	gomokuWinLength        = 5
+)
+
+// GomokuRule decides which lines win
+type GomokuRule string
+
+const (
+	GomokuRuleFreestyle GomokuRule = "freestyle" // Five or more in a row wins
+	GomokuRuleExact     GomokuRule = "exact"     // Exactly five in a row wins; overlines do not count
+)
+
+// GomokuOpening decides how the game starts
+type GomokuOpening string
+
+const (
+	GomokuOpeningStandard GomokuOpening = "standard" // Player 1 is black and moves first
+	GomokuOpeningSwap2    GomokuOpening = "swap2"    // Colours are settled by the swap2 opening
+)
+
+// GomokuPhase is the stage of the game, which only differs from play during the swap2 opening
+type GomokuPhase string
+
+const (
+	GomokuPhasePlay         GomokuPhase = "play"
+	GomokuPhaseSwap2Place   GomokuPhase = "swap2_place"   // Player 1 places the first three stones (black, white, black)
+	GomokuPhaseSwap2Choice  GomokuPhase = "swap2_choice"  // Player 2 stays white, swaps to black or places two more stones
+	GomokuPhaseSwap2Extend  GomokuPhase = "swap2_extend"  // Player 2 places a white and a black stone
+	GomokuPhaseSwap2Colours GomokuPhase = "swap2_colours" // Player 1 picks a colour after the extra stones
+)
+
+// Swap2 choices, sent as GomokuMove.Choice
+const (
+	GomokuChoiceSwap   = "swap"   // swap2_choice: take black; the other player places the next (white) stone
+	GomokuChoiceExtend = "extend" // swap2_choice: place two more stones and let player 1 pick a colour
+	GomokuChoiceBlack  = "black"  // swap2_colours: player 1 plays black
+	GomokuChoiceWhite  = "white"  // swap2_colours: player 1 plays white
+)
+
+// GomokuState represents the state of a Gomoku (five in a row) game
+type GomokuState struct {
+	Board         [][]string    `json:"board"` // "B" (black), "W" (white) or ""
+	Player1ID     uuid.UUID     `json:"player1_id"`
+	Player2ID     uuid.UUID     `json:"player2_id"`
+	CurrentPlayer uuid.UUID     `json:"current_player"`
+	BlackPlayerID uuid.UUID     `json:"black_player_id"` // Player with the black stones (tentatively player 1 during swap2)
+	MoveCount     int           `json:"move_count"`      // Stones on the board
+	BoardSize     int           `json:"board_size"`      // 15 or 19
+	Rule          GomokuRule    `json:"rule"`
+	Opening       GomokuOpening `json:"opening"`
+	Phase         GomokuPhase   `json:"phase"`
+	LastMove      *GomokuMove   `json:"last_move,omitempty"`
+	Winner        *uuid.UUID    `json:"winner,omitempty"`     // Set by the move that completes a winning line
+	PositionHash  uint64        `json:"position_hash,string"` // Zobrist hash of the stones, phase and colour to move
+}
+
+// GomokuMove represents a move in Gomoku: a stone at Row/Col, or a swap2 Choice
+type GomokuMove struct {
+	Row    int    `json:"row"`
+	Col    int    `json:"col"`
+	Choice string `json:"choice,omitempty"`
+}
+
+func init() {
+	Register(Definition{
+		Type:        GameTypeGomoku,
+		Name:        "Gomoku",
+		Description: "Place stones on a large board and be the first to get five in a row",
+		MinPlayers:  2,
+		MaxPlayers:  2,
+		Settings: []SettingSchema{
+			{Key: "gomoku_board_size", Label: "Board size", Type: SettingTypeInt, Default: DefaultGomokuBoardSize, Options: []interface{}{15, 19}},
+			{Key: "gomoku_rule", Label: "Rule", Description: "Freestyle: five or more wins. Exact: only exactly five wins", Type: SettingTypeString, Default: string(GomokuRuleFreestyle), Options: []interface{}{string(GomokuRuleFreestyle), string(GomokuRuleExact)}},
+			{Key: "gomoku_opening", Label: "Opening", Description: "Swap2 balances the first player's advantage", Type: SettingTypeString, Default: string(GomokuOpeningStandard), Options: []interface{}{string(GomokuOpeningStandard), string(GomokuOpeningSwap2)}},
+		},
+		New: func(player1ID, player2ID uuid.UUID, settings map[string]interface{}) GameState {
+			return NewGomokuStateWithSettings(player1ID, player2ID, settings)
+		},
+		Decode: decodeState[GomokuState],
+		Bot:    newGomokuBot,
+	})
+}
+
+// NewGomokuState creates a new freestyle Gomoku game on a 15x15 board
+func NewGomokuState(player1ID, player2ID uuid.UUID) *GomokuState {
+	return NewGomokuStateWithOptions(player1ID, player2ID, DefaultGomokuBoardSize, GomokuRuleFreestyle, GomokuOpeningStandard)
+}
+
+// NewGomokuStateWithSettings creates a new Gomoku game state with custom settings
+func NewGomokuStateWithSettings(player1ID, player2ID uuid.UUID, settings interface{}) *GomokuState {
+	boardSize := DefaultGomokuBoardSize
+	rule := GomokuRuleFreestyle
+	opening := GomokuOpeningStandard
+
+	if settingsMap, ok := settings.(map[string]interface{}); ok {
+		if val, exists := IntSetting(settingsMap, "gomoku_board_size"); exists {
+			boardSize = val
+		}
+		if val, ok := settingsMap["gomoku_rule"].(string); ok {
+			rule = GomokuRule(val)
+		}
+		if val, ok := settingsMap["gomoku_opening"].(string); ok {
+			opening = GomokuOpening(val)
+		}
+	}
+
+	return NewGomokuStateWithOptions(player1ID, player2ID, boardSize, rule, opening)
+}
+
+// NewGomokuStateWithOptions creates a new Gomoku game state
+func NewGomokuStateWithOptions(player1ID, player2ID uuid.UUID, boardSize int, rule GomokuRule, opening GomokuOpening) *GomokuState {
+	if boardSize != 19 {
+		boardSize = DefaultGomokuBoardSize
+	}
+	if rule != GomokuRuleExact {
+		rule = GomokuRuleFreestyle
+	}
+	phase := GomokuPhasePlay
+	if opening == GomokuOpeningSwap2 {
+		phase = GomokuPhaseSwap2Place
+	} else {
+		opening = GomokuOpeningStandard
+	}
+
+	board := make([][]string, boardSize)
+	for i := range board {
+		board[i] = make([]string, boardSize)
+	}
+
+	state := &GomokuState{
+		Board:         board,
+		Player1ID:     player1ID,
+		Player2ID:     player2ID,
+		CurrentPlayer: player1ID,
+		BlackPlayerID: player1ID,
+		BoardSize:     boardSize,
+		Rule:          rule,
+		Opening:       opening,
+		Phase:         phase,
+	}
+	state.PositionHash = state.hashPosition()
+	return state
+}
+
+// ValidateMove checks if a move is valid
+func (s *GomokuState) ValidateMove(playerID uuid.UUID, move interface{}) error {
+	if playerID != s.CurrentPlayer {
+		return ErrNotYourTurn
+	}
+	if s.Winner != nil || s.MoveCount >= s.BoardSize*s.BoardSize {
+		return ErrGameAlreadyEnded
+	}
+
+	gomokuMove, err := parseGomokuMove(move)
+	if err != nil {
+		return err
+	}
+
+	if gomokuMove.Choice != "" {
+		switch {
+		case s.Phase == GomokuPhaseSwap2Choice && (gomokuMove.Choice == GomokuChoiceSwap || gomokuMove.Choice == GomokuChoiceExtend):
+			return nil
+		case s.Phase == GomokuPhaseSwap2Colours && (gomokuMove.Choice == GomokuChoiceBlack || gomokuMove.Choice == GomokuChoiceWhite):
+			return nil
+		}
+		return errors.New("choice not available in this phase")
+	}
+
+	if s.Phase == GomokuPhaseSwap2Colours {
+		return errors.New("choose a colour first")
+	}
+	if gomokuMove.Row < 0 || gomokuMove.Row >= s.BoardSize || gomokuMove.Col < 0 || gomokuMove.Col >= s.BoardSize {
+		return errors.New("position out of bounds")
+	}
+	if s.Board[gomokuMove.Row][gomokuMove.Col] != "" {
+		return errors.New("position already occupied")
+	}
+
+	return nil
+}
+
+// ApplyMove applies a move to the game state
+func (s *GomokuState) ApplyMove(playerID uuid.UUID, move interface{}) error {
+	if err := s.ValidateMove(playerID, move); err != nil {
+		return err
+	}
+
+	gomokuMove, err := parseGomokuMove(move)
+	if err != nil {
+		return err
+	}
+
+	if gomokuMove.Choice != "" {
+		s.applyChoice(gomokuMove.Choice)
+	} else {
+		s.placeStone(gomokuMove.Row, gomokuMove.Col)
+	}
+	s.PositionHash = s.hashPosition()
+	return nil
+}
+
+// placeStone places the next stone and moves the game on to the next player or opening phase
+func (s *GomokuState) placeStone(row, col int) {
+	// Colours alternate stone by stone from black, in the opening as well
+	stone := s.nextStone()
+	s.Board[row][col] = stone
+	s.MoveCount++
+	s.LastMove = &GomokuMove{Row: row, Col: col}
+
+	if s.completesLine(row, col) {
+		winnerID := s.playerForStone(stone)
+		s.Winner = &winnerID
+	}
+
+	switch s.Phase {
+	case GomokuPhaseSwap2Place:
+		if s.MoveCount == 3 {
+			s.Phase = GomokuPhaseSwap2Choice
+			s.CurrentPlayer = s.Player2ID
+		}
+		return
+	case GomokuPhaseSwap2Extend:
+		if s.MoveCount == 5 {
+			s.Phase = GomokuPhaseSwap2Colours
+			s.CurrentPlayer = s.Player1ID
+		}
+		return
+	case GomokuPhaseSwap2Choice:
+		// Placing the fourth stone keeps player 2 on white
+		s.Phase = GomokuPhasePlay
+	}
+
+	s.CurrentPlayer = s.playerForStone(s.nextStone())
+}
+
+// Fingerprint returns the Zobrist hash of the position, see Fingerprinter
+func (s *GomokuState) Fingerprint() uint64 {
+	if s.PositionHash == 0 {
+		return s.hashPosition() // Saved before position hashes were stored
+	}
+	return s.PositionHash
+}
+
+// hashPosition computes the Zobrist hash of the stones, the opening phase and the colour to
+// move. During swap2 the phase decides which seat moves; afterwards a position is the same
+// whichever player ended up with black.
+func (s *GomokuState) hashPosition() uint64 {
+	side := 0
+	if s.nextStone() == "W" {
+		side = 1
+	}
+	return zobristBoard(zobristSalt(GameTypeGomoku, s.BoardSize, s.Rule, s.Phase), s.Board, side)
+}
+
+// applyChoice applies a swap2 decision
+func (s *GomokuState) applyChoice(choice string) {
+	switch choice {
+	case GomokuChoiceSwap:
+		s.BlackPlayerID = s.Player2ID
+		s.Phase = GomokuPhasePlay
+	case GomokuChoiceExtend:
+		s.Phase = GomokuPhaseSwap2Extend
+		return
+	case GomokuChoiceBlack:
+		s.BlackPlayerID = s.Player1ID
+		s.Phase = GomokuPhasePlay
+	case GomokuChoiceWhite:
+		s.BlackPlayerID = s.Player2ID
+		s.Phase = GomokuPhasePlay
+	}
+	s.CurrentPlayer = s.playerForStone(s.nextStone())
+}
+
+// nextStone returns the colour of the next stone placed
+func (s *GomokuState) nextStone() string {
+	if s.MoveCount%2 == 0 {
+		return "B"
+	}
+	return "W"
+}
+
+// playerForStone returns the player who owns a colour
+func (s *GomokuState) playerForStone(stone string) uuid.UUID {
+	if stone == "B" {
+		return s.BlackPlayerID
+	}
+	if s.BlackPlayerID == s.Player1ID {
+		return s.Player2ID
+	}
+	return s.Player1ID
+}
+
+// completesLine reports whether the stone at (row, col) is part of a winning line. Only the
+// four lines through the new stone need checking, however large the board.
+func (s *GomokuState) completesLine(row, col int) bool {
+	for _, dir := range [][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}} {
+		length := 1 + s.runLength(row, col, dir[0], dir[1]) + s.runLength(row, col, -dir[0], -dir[1])
+		if length == gomokuWinLength || (length > gomokuWinLength && s.Rule == GomokuRuleFreestyle) {
+			return true
+		}
+	}
+	return false
+}
+
+// runLength counts the stones matching (row, col) beyond it in direction (dRow, dCol)
+func (s *GomokuState) runLength(row, col, dRow, dCol int) int {
+	stone := s.Board[row][col]
+	count := 0
+	for r, c := row+dRow, col+dCol; r >= 0 && r < s.BoardSize && c >= 0 && c < s.BoardSize && s.Board[r][c] == stone; r, c = r+dRow, c+dCol {
+		count++
+	}
+	return count
+}
+
+// CheckWinner checks if there's a winner or if the game is a draw
+func (s *GomokuState) CheckWinner() (winner *uuid.UUID, gameOver bool) {
+	if s.Winner != nil {
+		return s.Winner, true
+	}
+	if s.MoveCount >= s.BoardSize*s.BoardSize {
+		return nil, true // Draw
+	}
+	return nil, false
+}
+
+// LegalMoves returns every empty point, row by row, plus any swap2 choices open to the player to move
+func (s *GomokuState) LegalMoves() []interface{} {
+	moves := []interface{}{}
+	if _, gameOver := s.CheckWinner(); gameOver {
+		return moves
+	}
+
+	switch s.Phase {
+	case GomokuPhaseSwap2Colours:
+		return append(moves, GomokuMove{Choice: GomokuChoiceBlack}, GomokuMove{Choice: GomokuChoiceWhite})
+	case GomokuPhaseSwap2Choice:
+		moves = append(moves, GomokuMove{Choice: GomokuChoiceSwap}, GomokuMove{Choice: GomokuChoiceExtend})
+	}
+
+	for row := 0; row < s.BoardSize; row++ {
+		for col := 0; col < s.BoardSize; col++ {
+			if s.Board[row][col] == "" {
+				moves = append(moves, GomokuMove{Row: row, Col: col})
+			}
+		}
+	}
+	return moves
+}
+
+// GetCurrentPlayer returns the ID of the player whose turn it is
+func (s *GomokuState) GetCurrentPlayer() uuid.UUID {
+	return s.CurrentPlayer
+}
+
+// SetPlayer2 seats the second player when they join a waiting game
+func (s *GomokuState) SetPlayer2(playerID uuid.UUID) {
+	s.Player2ID = playerID
+}
+
+// GetState returns the current game state for serialization
+func (s *GomokuState) GetState() interface{} {
+	// Defensive: Ensure board is never nil
+	if s.Board == nil {
+		s.Board = make([][]string, s.BoardSize)
+		for i := range s.Board {
+			s.Board[i] = make([]string, s.BoardSize)
+		}
+	}
+	return s
+}
+
+// StateFor returns the full state: Gomoku has no hidden information
+func (s *GomokuState) StateFor(viewerID uuid.UUID) interface{} {
+	return s.GetState()
+}
+
+// Clone creates a deep copy of the game state
+func (s *GomokuState) Clone() GameState {
+	newState := *s
+	newState.Board = make([][]string, len(s.Board))
+	for i := range s.Board {
+		newState.Board[i] = make([]string, len(s.Board[i]))
+		copy(newState.Board[i], s.Board[i])
+	}
+	if s.LastMove != nil {
+		lastMove := *s.LastMove
+		newState.LastMove = &lastMove
+	}
+	if s.Winner != nil {
+		winner := *s.Winner
+		newState.Winner = &winner
+	}
+	return &newState
+}
+
+// parseGomokuMove parses a move from various formats
+func parseGomokuMove(move interface{}) (*GomokuMove, error) {
+	if m, ok := move.(*GomokuMove); ok {
+		return m, nil
+	}
+
+	if m, ok := move.(GomokuMove); ok {
+		return &m, nil
+	}
+
+	jsonData, err := json.Marshal(move)
+	if err != nil {
+		return nil, ErrInvalidMove
+	}
+
+	var gomokuMove GomokuMove
+	if err := json.Unmarshal(jsonData, &gomokuMove); err != nil {
+		return nil, ErrInvalidMove
+	}
+
+	return &gomokuMove, nil
+}
@@ -0,0 +1,212 @@
+package game
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// gomokuBot plays Gomoku with a shallow alpha-beta search. The board is far too large to search
+// every point, so only the most promising points next to existing stones are considered.
+type gomokuBot struct {
+	difficulty BotDifficulty
+	rng        *rand.Rand
+}
+
+func newGomokuBot(difficulty BotDifficulty, rng *rand.Rand) Bot {
+	return &gomokuBot{difficulty: difficulty, rng: rng}
+}
+
+// gomokuSearch returns the search used by a bot considering at most width points per position
+func gomokuSearch(width int) searchSpec {
+	return searchSpec{
+		moves: func(state GameState) []interface{} {
+			return gomokuCandidates(state.(*GomokuState), width)
+		},
+		evaluate: gomokuEvaluate,
+	}
+}
+
+// gomokuEvaluate scores a position for botID. Positions that are won by force within the next
+// couple of moves are scored close to a win, since the shallow search cannot see them through.
+func gomokuEvaluate(state GameState, botID uuid.UUID) int {
+	s := state.(*GomokuState)
+	mine, theirs := "B", "W"
+	if botID != s.BlackPlayerID {
+		mine, theirs = "W", "B"
+	}
+
+	mover := s.nextStone()
+	waiting := "W"
+	if mover == "W" {
+		waiting = "B"
+	}
+	winner := ""
+	switch {
+	case len(s.fourPoints(mover)) > 0:
+		winner = mover // The side to move completes five
+	case len(s.fourPoints(waiting)) > 1:
+		winner = waiting // The side to move cannot block two fours at once
+	}
+	switch winner {
+	case mine:
+		return winScore / 2
+	case theirs:
+		return -winScore / 2
+	}
+
+	return scoreLines(s.Board, gomokuWinLength, mine, theirs)
+}
+
+// ChooseMove picks a point (or a swap2 choice) for the bot
+func (b *gomokuBot) ChooseMove(state GameState, botID uuid.UUID) (interface{}, error) {
+	s, ok := state.(*GomokuState)
+	if !ok {
+		return nil, ErrInvalidMove
+	}
+	if s.CurrentPlayer != botID {
+		return nil, ErrNotYourTurn
+	}
+
+	switch s.Phase {
+	case GomokuPhaseSwap2Colours:
+		return GomokuMove{Choice: GomokuChoiceWhite}, nil
+	case GomokuPhaseSwap2Place, GomokuPhaseSwap2Extend:
+		return b.openingStone(s)
+	}
+
+	// In swap2_choice the bot stays white and plays its stone like any other move
+	switch b.difficulty {
+	case BotEasy:
+		return randomOrWinningMove(s, botID, gomokuCandidates(s, 0), b.rng)
+	case BotMedium:
+		return searchBestMove(s, botID, gomokuSearch(8), 2, b.rng)
+	default:
+		return searchBestMove(s, botID, gomokuSearch(10), 3, b.rng)
+	}
+}
+
+// openingStone places a swap2 opening stone near the centre
+func (b *gomokuBot) openingStone(s *GomokuState) (interface{}, error) {
+	candidates := gomokuCandidates(s, 0)
+	if len(candidates) == 0 {
+		return nil, ErrNoLegalMoves
+	}
+	return candidates[b.rng.Intn(len(candidates))], nil
+}
+
+// gomokuCandidates returns the empty points within two of a stone (the centre on an empty board),
+// best first by gomokuPointScore. A positive width keeps only that many points.
+func gomokuCandidates(s *GomokuState, width int) []interface{} {
+	if s.MoveCount == 0 {
+		center := s.BoardSize / 2
+		return []interface{}{GomokuMove{Row: center, Col: center}}
+	}
+
+	type candidate struct {
+		move  GomokuMove
+		score int
+	}
+	var candidates []candidate
+	for row := 0; row < s.BoardSize; row++ {
+		for col := 0; col < s.BoardSize; col++ {
+			if s.Board[row][col] == "" && s.nearStone(row, col, 2) {
+				candidates = append(candidates, candidate{GomokuMove{Row: row, Col: col}, gomokuPointScore(s, row, col)})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+	if width > 0 && len(candidates) > width {
+		candidates = candidates[:width]
+	}
+
+	moves := make([]interface{}, len(candidates))
+	for i, c := range candidates {
+		moves[i] = c.move
+	}
+	return moves
+}
+
+// fourPoints returns the empty points that would complete five for stone
+func (s *GomokuState) fourPoints(stone string) map[[2]int]bool {
+	points := make(map[[2]int]bool)
+	for row := 0; row < s.BoardSize; row++ {
+		for col := 0; col < s.BoardSize; col++ {
+			for _, dir := range [][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}} {
+				endRow, endCol := row+dir[0]*(gomokuWinLength-1), col+dir[1]*(gomokuWinLength-1)
+				if endRow >= s.BoardSize || endCol < 0 || endCol >= s.BoardSize {
+					continue
+				}
+				own, empty := 0, [2]int{-1, -1}
+				for i := 0; i < gomokuWinLength; i++ {
+					r, c := row+dir[0]*i, col+dir[1]*i
+					switch s.Board[r][c] {
+					case stone:
+						own++
+					case "":
+						empty = [2]int{r, c}
+					}
+				}
+				if own == gomokuWinLength-1 && empty[0] >= 0 {
+					points[empty] = true
+				}
+			}
+		}
+	}
+	return points
+}
+
+// nearStone reports whether any stone lies within distance of (row, col)
+func (s *GomokuState) nearStone(row, col, distance int) bool {
+	for r := row - distance; r <= row+distance; r++ {
+		for c := col - distance; c <= col+distance; c++ {
+			if r >= 0 && r < s.BoardSize && c >= 0 && c < s.BoardSize && s.Board[r][c] != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// gomokuPointScore rates an empty point by the windows of five through it: how far it would extend
+// the mover's own lines plus how far it would block the opponent's, so wins and forced blocks come first
+func gomokuPointScore(s *GomokuState, row, col int) int {
+	mine := s.nextStone()
+	theirs := "W"
+	if mine == "W" {
+		theirs = "B"
+	}
+
+	score := 0
+	for _, dir := range [][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}} {
+		for offset := -(gomokuWinLength - 1); offset <= 0; offset++ {
+			own, opp, fits := 0, 0, true
+			for i := 0; i < gomokuWinLength; i++ {
+				r, c := row+dir[0]*(offset+i), col+dir[1]*(offset+i)
+				if r < 0 || r >= s.BoardSize || c < 0 || c >= s.BoardSize {
+					fits = false
+					break
+				}
+				switch s.Board[r][c] {
+				case mine:
+					own++
+				case theirs:
+					opp++
+				}
+			}
+			if !fits {
+				continue
+			}
+			if opp == 0 {
+				score += 2 * lineWeight(own+1) // Attacking is worth a little more than defending
+			}
+			if own == 0 {
+				score += lineWeight(opp + 1)
+			}
+		}
+	}
+	return score
+}
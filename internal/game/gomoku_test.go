@@ -0,0 +1,173 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// playGomoku plays stones alternately for whoever is to move
+func playGomoku(t *testing.T, state *GomokuState, points ...[2]int) {
+	t.Helper()
+	for _, p := range points {
+		assert.NoError(t, state.ApplyMove(state.CurrentPlayer, GomokuMove{Row: p[0], Col: p[1]}))
+	}
+}
+
+// TestNewGomokuState tests creating a new Gomoku game
+func TestNewGomokuState(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	t.Run("Create Default Game", func(t *testing.T) {
+		state := NewGomokuState(player1, player2)
+
+		assert.Equal(t, 15, state.BoardSize)
+		assert.Len(t, state.Board, 15)
+		assert.Equal(t, GomokuRuleFreestyle, state.Rule)
+		assert.Equal(t, GomokuPhasePlay, state.Phase)
+		assert.Equal(t, player1, state.CurrentPlayer)
+		assert.Equal(t, player1, state.BlackPlayerID)
+	})
+
+	t.Run("Create Custom Game", func(t *testing.T) {
+		settings := map[string]interface{}{
+			"gomoku_board_size": float64(19),
+			"gomoku_rule":       "exact",
+			"gomoku_opening":    "swap2",
+		}
+		state := NewGomokuStateWithSettings(player1, player2, settings)
+
+		assert.Equal(t, 19, state.BoardSize)
+		assert.Equal(t, GomokuRuleExact, state.Rule)
+		assert.Equal(t, GomokuPhaseSwap2Place, state.Phase)
+	})
+
+	t.Run("Invalid Settings Fall Back To Defaults", func(t *testing.T) {
+		state := NewGomokuStateWithOptions(player1, player2, 17, "renju", "pro")
+		assert.Equal(t, 15, state.BoardSize)
+		assert.Equal(t, GomokuRuleFreestyle, state.Rule)
+		assert.Equal(t, GomokuOpeningStandard, state.Opening)
+	})
+}
+
+// TestGomokuValidateMove tests move validation
+func TestGomokuValidateMove(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+	state := NewGomokuState(player1, player2)
+
+	assert.NoError(t, state.ValidateMove(player1, GomokuMove{Row: 7, Col: 7}))
+	assert.ErrorIs(t, state.ValidateMove(player2, GomokuMove{Row: 7, Col: 7}), ErrNotYourTurn)
+	assert.Error(t, state.ValidateMove(player1, GomokuMove{Row: 15, Col: 0}))
+	assert.Error(t, state.ValidateMove(player1, GomokuMove{Choice: GomokuChoiceSwap}))
+
+	playGomoku(t, state, [2]int{7, 7})
+	assert.Error(t, state.ValidateMove(player2, GomokuMove{Row: 7, Col: 7}))
+}
+
+// TestGomokuCheckWinner tests win detection
+func TestGomokuCheckWinner(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	t.Run("Five In A Row", func(t *testing.T) {
+		state := NewGomokuState(player1, player2)
+		playGomoku(t, state, [2]int{7, 3}, [2]int{0, 0}, [2]int{7, 4}, [2]int{0, 1}, [2]int{7, 5}, [2]int{0, 2}, [2]int{7, 6})
+		_, over := state.CheckWinner()
+		assert.False(t, over)
+
+		playGomoku(t, state, [2]int{0, 4}, [2]int{7, 7})
+		winner, over := state.CheckWinner()
+		assert.True(t, over)
+		assert.Equal(t, player1, *winner)
+		assert.ErrorIs(t, state.ValidateMove(player2, GomokuMove{Row: 1, Col: 1}), ErrGameAlreadyEnded)
+		assert.Empty(t, state.LegalMoves())
+	})
+
+	t.Run("Diagonal", func(t *testing.T) {
+		state := NewGomokuState(player1, player2)
+		playGomoku(t, state, [2]int{14, 0}, [2]int{0, 0}, [2]int{13, 1}, [2]int{0, 1}, [2]int{12, 2}, [2]int{0, 2}, [2]int{11, 3}, [2]int{0, 3}, [2]int{10, 4})
+		winner, over := state.CheckWinner()
+		assert.True(t, over)
+		assert.Equal(t, player1, *winner)
+	})
+
+	overline := func(rule GomokuRule) *GomokuState {
+		// Black fills 7,2..7,4 and 7,6..7,7, then closes the gap to make six
+		state := NewGomokuStateWithOptions(player1, player2, 15, rule, GomokuOpeningStandard)
+		playGomoku(t, state, [2]int{7, 2}, [2]int{0, 0}, [2]int{7, 3}, [2]int{0, 2}, [2]int{7, 4}, [2]int{0, 4}, [2]int{7, 6}, [2]int{0, 6}, [2]int{7, 7}, [2]int{0, 8}, [2]int{7, 5})
+		return state
+	}
+
+	t.Run("Freestyle Overline Wins", func(t *testing.T) {
+		winner, over := overline(GomokuRuleFreestyle).CheckWinner()
+		assert.True(t, over)
+		assert.Equal(t, player1, *winner)
+	})
+
+	t.Run("Exact Five Ignores Overline", func(t *testing.T) {
+		_, over := overline(GomokuRuleExact).CheckWinner()
+		assert.False(t, over)
+	})
+}
+
+// TestGomokuSwap2 tests the swap2 opening
+func TestGomokuSwap2(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+	newState := func() *GomokuState {
+		state := NewGomokuStateWithOptions(player1, player2, 15, GomokuRuleFreestyle, GomokuOpeningSwap2)
+		playGomoku(t, state, [2]int{7, 7}, [2]int{7, 8}, [2]int{8, 7})
+		assert.Equal(t, GomokuPhaseSwap2Choice, state.Phase)
+		assert.Equal(t, player2, state.CurrentPlayer)
+		assert.Equal(t, "W", state.Board[7][8])
+		return state
+	}
+
+	t.Run("Stay White", func(t *testing.T) {
+		state := newState()
+		playGomoku(t, state, [2]int{6, 7})
+		assert.Equal(t, GomokuPhasePlay, state.Phase)
+		assert.Equal(t, player1, state.BlackPlayerID)
+		assert.Equal(t, player1, state.CurrentPlayer)
+	})
+
+	t.Run("Swap To Black", func(t *testing.T) {
+		state := newState()
+		assert.NoError(t, state.ApplyMove(player2, GomokuMove{Choice: GomokuChoiceSwap}))
+		assert.Equal(t, player2, state.BlackPlayerID)
+		assert.Equal(t, player1, state.CurrentPlayer, "the new white player places the fourth stone")
+		playGomoku(t, state, [2]int{6, 7})
+		assert.Equal(t, "W", state.Board[6][7])
+		assert.Equal(t, player2, state.CurrentPlayer)
+	})
+
+	t.Run("Extend And Let Player 1 Choose", func(t *testing.T) {
+		state := newState()
+		assert.NoError(t, state.ApplyMove(player2, GomokuMove{Choice: GomokuChoiceExtend}))
+		playGomoku(t, state, [2]int{6, 6}, [2]int{9, 9})
+		assert.Equal(t, GomokuPhaseSwap2Colours, state.Phase)
+		assert.Equal(t, player1, state.CurrentPlayer)
+		assert.Error(t, state.ValidateMove(player1, GomokuMove{Row: 1, Col: 1}))
+		assert.Len(t, state.LegalMoves(), 2)
+
+		assert.NoError(t, state.ApplyMove(player1, GomokuMove{Choice: GomokuChoiceWhite}))
+		assert.Equal(t, player2, state.BlackPlayerID)
+		assert.Equal(t, player1, state.CurrentPlayer, "white plays the sixth stone")
+	})
+}
+
+// TestGomokuClone tests that clones do not share the board
+func TestGomokuClone(t *testing.T) {
+	state := NewGomokuState(uuid.New(), uuid.New())
+	playGomoku(t, state, [2]int{7, 7})
+
+	clone := state.Clone().(*GomokuState)
+	clone.Board[0][0] = "W"
+	clone.LastMove.Row = 0
+
+	assert.Equal(t, "", state.Board[0][0])
+	assert.Equal(t, 7, state.LastMove.Row)
+}
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MoveRecord is a single accepted move in a game's move log. An agreed takeback is logged as a
+// record with Takeback set and no move, so replays undo the same moves the game did.
+type MoveRecord struct {
+	Ply         int             `json:"ply"`                   // 1-based position in the move sequence (for a takeback, the ply the game went back to)
+	PlayerID    uuid.UUID       `json:"player_id"`             // For a takeback, the player who asked for it
+	Move        json.RawMessage `json:"move,omitempty"`        // Raw move payload as submitted by the client
+	Takeback    int             `json:"takeback,omitempty"`    // Number of moves taken back
+	Timestamp   time.Time       `json:"timestamp"`             // Server time the move was accepted
+	StateHash   string          `json:"state_hash"`            // Hash of the state after the move was applied
+	Fingerprint string          `json:"fingerprint,omitempty"` // Position fingerprint after the move, see Fingerprint
+}
+
+// NewMoveRecord builds a move record for a move that has just been applied to state
+func NewMoveRecord(ply int, playerID uuid.UUID, move interface{}, state GameState) (*MoveRecord, error) {
+	moveData, err := json.Marshal(move)
+	if err != nil {
+		return nil, err
+	}
+
+	stateHash, err := HashState(state)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint, err := StateFingerprint(state)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MoveRecord{
+		Ply:         ply,
+		PlayerID:    playerID,
+		Move:        moveData,
+		Timestamp:   time.Now(),
+		StateHash:   stateHash,
+		Fingerprint: fingerprint,
+	}, nil
+}
+
+// NewTakebackRecord builds the move log record of a takeback that has just rewound state to ply
+func NewTakebackRecord(ply int, playerID uuid.UUID, plies int, state GameState) (*MoveRecord, error) {
+	stateHash, err := HashState(state)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint, err := StateFingerprint(state)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MoveRecord{
+		Ply:         ply,
+		PlayerID:    playerID,
+		Takeback:    plies,
+		Timestamp:   time.Now(),
+		StateHash:   stateHash,
+		Fingerprint: fingerprint,
+	}, nil
+}
+
+// ActiveMoves returns the moves of a move log that are still on the board once its takebacks
+// are applied, in order
+func ActiveMoves(log []MoveRecord) []MoveRecord {
+	active := make([]MoveRecord, 0, len(log))
+	for _, record := range log {
+		if record.Takeback == 0 {
+			active = append(active, record)
+			continue
+		}
+		remaining := len(active) - record.Takeback
+		if remaining < 0 {
+			remaining = 0
+		}
+		active = active[:remaining]
+	}
+	return active
+}
+
+// HashState returns a hex-encoded SHA-256 of the canonical serialization of the game state
+func HashState(state GameState) (string, error) {
+	data, err := CanonicalState(state)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,61 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMoveRecord tests building move log entries
+func TestMoveRecord(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	t.Run("Record Captures Move And State Hash", func(t *testing.T) {
+		state := NewConnect4State(player1, player2)
+		move := map[string]interface{}{"column": float64(3)}
+		assert.NoError(t, state.ApplyMove(player1, move))
+
+		record, err := NewMoveRecord(1, player1, move, state)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, record.Ply)
+		assert.Equal(t, player1, record.PlayerID)
+		assert.JSONEq(t, `{"column":3}`, string(record.Move))
+		assert.Len(t, record.StateHash, 64)
+		assert.False(t, record.Timestamp.IsZero())
+	})
+
+	t.Run("Hash Changes With State", func(t *testing.T) {
+		state := NewTicTacToeState(player1, player2)
+		before, err := HashState(state)
+		assert.NoError(t, err)
+
+		assert.NoError(t, state.ApplyMove(player1, TicTacToeMove{Row: 0, Col: 0}))
+		after, err := HashState(state)
+		assert.NoError(t, err)
+		assert.NotEqual(t, before, after)
+
+		clone := state.Clone()
+		cloneHash, _ := HashState(clone)
+		assert.Equal(t, after, cloneHash)
+	})
+
+	t.Run("Round Trip Through JSON", func(t *testing.T) {
+		state := NewRPSState(player1, player2)
+		move := RPSMove{Choice: RPSChoiceRock}
+		assert.NoError(t, state.ApplyMove(player1, move))
+
+		record, err := NewMoveRecord(1, player1, move, state)
+		assert.NoError(t, err)
+
+		data, err := json.Marshal(record)
+		assert.NoError(t, err)
+
+		var decoded MoveRecord
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, record.StateHash, decoded.StateHash)
+		assert.JSONEq(t, string(record.Move), string(decoded.Move))
+	})
+}
@@ -0,0 +1,360 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+const DefaultOthelloBoardSize = 8
+
+var othelloDirections = [][2]int{{-1, -1}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, -1}, {1, 0}, {1, 1}}
+
+// OthelloState represents the state of an Othello (Reversi) game. Player 1 plays black and moves
+// first. A player without a move that flips a disc passes automatically, so the player to move
+// always has a legal move while the game is in progress.
+type OthelloState struct {
+	Board         [][]string   `json:"board"` // "B" (black), "W" (white) or ""
+	Player1ID     uuid.UUID    `json:"player1_id"`
+	Player2ID     uuid.UUID    `json:"player2_id"`
+	CurrentPlayer uuid.UUID    `json:"current_player"`
+	BoardSize     int          `json:"board_size"` // 6, 8 or 10
+	BlackCount    int          `json:"black_count"`
+	WhiteCount    int          `json:"white_count"`
+	MoveCount     int          `json:"move_count"`
+	LastMove      *OthelloMove `json:"last_move,omitempty"`
+	PassedPlayer  *uuid.UUID   `json:"passed_player,omitempty"` // Player whose turn was skipped after the last move
+	Finished      bool         `json:"finished"`                // Neither player can move
+	Winner        *uuid.UUID   `json:"winner,omitempty"`        // Player with more discs once finished
+	PositionHash  uint64       `json:"position_hash,string"`    // Zobrist hash of the discs and side to move
+}
+
+// OthelloMove represents a move in Othello
+type OthelloMove struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+func init() {
+	Register(Definition{
+		Type:        GameTypeOthello,
+		Name:        "Othello",
+		Description: "Outflank your opponent's discs to flip them; the most discs when neither player can move wins",
+		MinPlayers:  2,
+		MaxPlayers:  2,
+		Settings: []SettingSchema{
+			{Key: "othello_board_size", Label: "Board size", Type: SettingTypeInt, Default: DefaultOthelloBoardSize, Options: []interface{}{6, 8, 10}},
+		},
+		New: func(player1ID, player2ID uuid.UUID, settings map[string]interface{}) GameState {
+			return NewOthelloStateWithSettings(player1ID, player2ID, settings)
+		},
+		Decode: decodeState[OthelloState],
+		Bot:    newOthelloBot,
+	})
+}
+
+// NewOthelloState creates a new Othello game on an 8x8 board
+func NewOthelloState(player1ID, player2ID uuid.UUID) *OthelloState {
+	return NewOthelloStateWithSize(player1ID, player2ID, DefaultOthelloBoardSize)
+}
+
+// NewOthelloStateWithSettings creates a new Othello game state with custom settings
+func NewOthelloStateWithSettings(player1ID, player2ID uuid.UUID, settings interface{}) *OthelloState {
+	boardSize := DefaultOthelloBoardSize
+
+	if settingsMap, ok := settings.(map[string]interface{}); ok {
+		if val, exists := IntSetting(settingsMap, "othello_board_size"); exists {
+			boardSize = val
+		}
+	}
+
+	return NewOthelloStateWithSize(player1ID, player2ID, boardSize)
+}
+
+// NewOthelloStateWithSize creates a new Othello game state with the four starting discs in the centre
+func NewOthelloStateWithSize(player1ID, player2ID uuid.UUID, boardSize int) *OthelloState {
+	if boardSize != 6 && boardSize != 10 {
+		boardSize = DefaultOthelloBoardSize
+	}
+
+	board := make([][]string, boardSize)
+	for i := range board {
+		board[i] = make([]string, boardSize)
+	}
+	mid := boardSize / 2
+	board[mid-1][mid-1], board[mid][mid] = "W", "W"
+	board[mid-1][mid], board[mid][mid-1] = "B", "B"
+
+	state := &OthelloState{
+		Board:         board,
+		Player1ID:     player1ID,
+		Player2ID:     player2ID,
+		CurrentPlayer: player1ID,
+		BoardSize:     boardSize,
+		BlackCount:    2,
+		WhiteCount:    2,
+	}
+	state.PositionHash = state.hashPosition()
+	return state
+}
+
+// ValidateMove checks if a move is valid
+func (s *OthelloState) ValidateMove(playerID uuid.UUID, move interface{}) error {
+	if playerID != s.CurrentPlayer {
+		return ErrNotYourTurn
+	}
+	if s.Finished {
+		return ErrGameAlreadyEnded
+	}
+
+	othelloMove, err := parseOthelloMove(move)
+	if err != nil {
+		return err
+	}
+
+	if othelloMove.Row < 0 || othelloMove.Row >= s.BoardSize || othelloMove.Col < 0 || othelloMove.Col >= s.BoardSize {
+		return errors.New("move out of bounds")
+	}
+	if s.Board[othelloMove.Row][othelloMove.Col] != "" {
+		return errors.New("square already occupied")
+	}
+	if !s.outflanks(othelloMove.Row, othelloMove.Col, s.discOf(playerID)) {
+		return errors.New("move must flip at least one disc")
+	}
+
+	return nil
+}
+
+// ApplyMove places a disc, flips the outflanked discs and hands the turn over. If the opponent
+// then has no move they pass; if neither player can move the game is over.
+func (s *OthelloState) ApplyMove(playerID uuid.UUID, move interface{}) error {
+	if err := s.ValidateMove(playerID, move); err != nil {
+		return err
+	}
+
+	othelloMove, err := parseOthelloMove(move)
+	if err != nil {
+		return err
+	}
+
+	disc := s.discOf(playerID)
+	flipped := s.flips(othelloMove.Row, othelloMove.Col, disc)
+	s.Board[othelloMove.Row][othelloMove.Col] = disc
+	for _, square := range flipped {
+		s.Board[square[0]][square[1]] = disc
+	}
+	if disc == "B" {
+		s.BlackCount += len(flipped) + 1
+		s.WhiteCount -= len(flipped)
+	} else {
+		s.WhiteCount += len(flipped) + 1
+		s.BlackCount -= len(flipped)
+	}
+
+	s.MoveCount++
+	s.LastMove = &OthelloMove{Row: othelloMove.Row, Col: othelloMove.Col}
+	s.PassedPlayer = nil
+
+	opponent := s.opponentOf(playerID)
+	switch {
+	case s.hasMove(s.discOf(opponent)):
+		s.CurrentPlayer = opponent
+	case s.hasMove(disc):
+		s.PassedPlayer = &opponent
+	default:
+		s.Finished = true
+		switch {
+		case s.BlackCount > s.WhiteCount:
+			winner := s.Player1ID
+			s.Winner = &winner
+		case s.WhiteCount > s.BlackCount:
+			winner := s.Player2ID
+			s.Winner = &winner
+		}
+	}
+	s.PositionHash = s.hashPosition()
+
+	return nil
+}
+
+// Fingerprint returns the Zobrist hash of the position, see Fingerprinter
+func (s *OthelloState) Fingerprint() uint64 {
+	if s.PositionHash == 0 {
+		return s.hashPosition() // Saved before position hashes were stored
+	}
+	return s.PositionHash
+}
+
+// hashPosition computes the Zobrist hash of the discs and the side to move
+func (s *OthelloState) hashPosition() uint64 {
+	return zobristBoard(zobristSalt(GameTypeOthello, s.BoardSize), s.Board, sideToMove(s.CurrentPlayer, s.Player1ID))
+}
+
+// CheckWinner checks if there's a winner or if the game is a draw
+func (s *OthelloState) CheckWinner() (winner *uuid.UUID, gameOver bool) {
+	if !s.Finished {
+		return nil, false
+	}
+	return s.Winner, true
+}
+
+// LegalMoves returns every square where the player to move would flip a disc, row by row
+func (s *OthelloState) LegalMoves() []interface{} {
+	moves := []interface{}{}
+	if s.Finished {
+		return moves
+	}
+	disc := s.discOf(s.CurrentPlayer)
+	for row := 0; row < s.BoardSize; row++ {
+		for col := 0; col < s.BoardSize; col++ {
+			if s.Board[row][col] == "" && s.outflanks(row, col, disc) {
+				moves = append(moves, OthelloMove{Row: row, Col: col})
+			}
+		}
+	}
+	return moves
+}
+
+// flips returns the discs that placing disc at (row, col) would flip
+func (s *OthelloState) flips(row, col int, disc string) [][2]int {
+	var flipped [][2]int
+	for _, dir := range othelloDirections {
+		var line [][2]int
+		r, c := row+dir[0], col+dir[1]
+		for r >= 0 && r < s.BoardSize && c >= 0 && c < s.BoardSize {
+			cell := s.Board[r][c]
+			if cell == "" {
+				break
+			}
+			if cell == disc {
+				flipped = append(flipped, line...)
+				break
+			}
+			line = append(line, [2]int{r, c})
+			r, c = r+dir[0], c+dir[1]
+		}
+	}
+	return flipped
+}
+
+// outflanks reports whether placing disc at (row, col) would flip anything, without listing the discs
+func (s *OthelloState) outflanks(row, col int, disc string) bool {
+	for _, dir := range othelloDirections {
+		r, c := row+dir[0], col+dir[1]
+		for seen := 0; r >= 0 && r < s.BoardSize && c >= 0 && c < s.BoardSize; seen++ {
+			cell := s.Board[r][c]
+			if cell == "" {
+				break
+			}
+			if cell == disc {
+				if seen > 0 {
+					return true
+				}
+				break
+			}
+			r, c = r+dir[0], c+dir[1]
+		}
+	}
+	return false
+}
+
+// hasMove reports whether disc can be placed anywhere
+func (s *OthelloState) hasMove(disc string) bool {
+	for row := 0; row < s.BoardSize; row++ {
+		for col := 0; col < s.BoardSize; col++ {
+			if s.Board[row][col] == "" && s.outflanks(row, col, disc) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// discOf returns the disc colour of a player
+func (s *OthelloState) discOf(playerID uuid.UUID) string {
+	if playerID == s.Player1ID {
+		return "B"
+	}
+	return "W"
+}
+
+// opponentOf returns the other player
+func (s *OthelloState) opponentOf(playerID uuid.UUID) uuid.UUID {
+	if playerID == s.Player1ID {
+		return s.Player2ID
+	}
+	return s.Player1ID
+}
+
+// GetCurrentPlayer returns the ID of the player whose turn it is
+func (s *OthelloState) GetCurrentPlayer() uuid.UUID {
+	return s.CurrentPlayer
+}
+
+// SetPlayer2 seats the second player when they join a waiting game
+func (s *OthelloState) SetPlayer2(playerID uuid.UUID) {
+	s.Player2ID = playerID
+}
+
+// GetState returns the current game state for serialization
+func (s *OthelloState) GetState() interface{} {
+	if s.Board == nil {
+		s.Board = make([][]string, s.BoardSize)
+		for i := range s.Board {
+			s.Board[i] = make([]string, s.BoardSize)
+		}
+	}
+	return s
+}
+
+// StateFor returns the full state: Othello has no hidden information
+func (s *OthelloState) StateFor(viewerID uuid.UUID) interface{} {
+	return s.GetState()
+}
+
+// Clone creates a deep copy of the game state
+func (s *OthelloState) Clone() GameState {
+	newState := *s
+	newState.Board = make([][]string, len(s.Board))
+	for i := range s.Board {
+		newState.Board[i] = make([]string, len(s.Board[i]))
+		copy(newState.Board[i], s.Board[i])
+	}
+	if s.LastMove != nil {
+		lastMove := *s.LastMove
+		newState.LastMove = &lastMove
+	}
+	if s.PassedPlayer != nil {
+		passed := *s.PassedPlayer
+		newState.PassedPlayer = &passed
+	}
+	if s.Winner != nil {
+		winner := *s.Winner
+		newState.Winner = &winner
+	}
+	return &newState
+}
+
+// parseOthelloMove parses a move from various formats
+func parseOthelloMove(move interface{}) (*OthelloMove, error) {
+	if m, ok := move.(*OthelloMove); ok {
+		return m, nil
+	}
+
+	if m, ok := move.(OthelloMove); ok {
+		return &m, nil
+	}
+
+	jsonData, err := json.Marshal(move)
+	if err != nil {
+		return nil, ErrInvalidMove
+	}
+
+	var othelloMove OthelloMove
+	if err := json.Unmarshal(jsonData, &othelloMove); err != nil {
+		return nil, ErrInvalidMove
+	}
+
+	return &othelloMove, nil
+}
@@ -0,0 +1,119 @@
+package game
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// othelloBot plays Othello with an alpha-beta search over positional weights and mobility
+type othelloBot struct {
+	difficulty BotDifficulty
+	rng        *rand.Rand
+}
+
+func newOthelloBot(difficulty BotDifficulty, rng *rand.Rand) Bot {
+	return &othelloBot{difficulty: difficulty, rng: rng}
+}
+
+var othelloSearch = searchSpec{
+	moves: func(state GameState) []interface{} {
+		return othelloMoves(state.(*OthelloState))
+	},
+	evaluate: othelloEvaluate,
+}
+
+// othelloEvaluate scores a position for botID: discs on valuable squares plus the difference in
+// available moves, since running the opponent out of moves is how Othello is won
+func othelloEvaluate(state GameState, botID uuid.UUID) int {
+	s := state.(*OthelloState)
+	mine := s.discOf(botID)
+
+	score := 0
+	myMoves, theirMoves := 0, 0
+	for row := 0; row < s.BoardSize; row++ {
+		for col := 0; col < s.BoardSize; col++ {
+			switch s.Board[row][col] {
+			case "":
+				if s.outflanks(row, col, mine) {
+					myMoves++
+				}
+				if s.outflanks(row, col, othelloOpposite(mine)) {
+					theirMoves++
+				}
+			case mine:
+				score += othelloSquareWeight(s.BoardSize, row, col)
+			default:
+				score -= othelloSquareWeight(s.BoardSize, row, col)
+			}
+		}
+	}
+	return score + 5*(myMoves-theirMoves)
+}
+
+// othelloSquareWeight rates a square: corners can never be flipped, while the squares next to an
+// empty corner tend to give it away
+func othelloSquareWeight(size, row, col int) int {
+	last := size - 1
+	edgeRow := row == 0 || row == last
+	edgeCol := col == 0 || col == last
+	nearRow := row <= 1 || row >= last-1
+	nearCol := col <= 1 || col >= last-1
+
+	switch {
+	case edgeRow && edgeCol:
+		return 100
+	case nearRow && nearCol && !edgeRow && !edgeCol:
+		return -50 // X-square, diagonally next to a corner
+	case nearRow && nearCol:
+		return -20 // C-square, next to a corner along an edge
+	case edgeRow || edgeCol:
+		return 10
+	}
+	return 1
+}
+
+// othelloOpposite returns the other disc colour
+func othelloOpposite(disc string) string {
+	if disc == "B" {
+		return "W"
+	}
+	return "B"
+}
+
+// ChooseMove picks a square for the bot
+func (b *othelloBot) ChooseMove(state GameState, botID uuid.UUID) (interface{}, error) {
+	s, ok := state.(*OthelloState)
+	if !ok {
+		return nil, ErrInvalidMove
+	}
+	if s.CurrentPlayer != botID {
+		return nil, ErrNotYourTurn
+	}
+
+	// The 10x10 board has about twice as many moves per position, so it is searched a ply shallower
+	depth := 4
+	if s.BoardSize > DefaultOthelloBoardSize {
+		depth = 3
+	}
+
+	switch b.difficulty {
+	case BotEasy:
+		return randomOrWinningMove(s, botID, s.LegalMoves(), b.rng)
+	case BotMedium:
+		return searchBestMove(s, botID, othelloSearch, depth-1, b.rng)
+	default:
+		return searchBestMove(s, botID, othelloSearch, depth, b.rng)
+	}
+}
+
+// othelloMoves lists the legal moves, most valuable squares first so the search prunes earlier
+func othelloMoves(s *OthelloState) []interface{} {
+	moves := s.LegalMoves()
+	sort.SliceStable(moves, func(i, j int) bool {
+		a, b := moves[i].(OthelloMove), moves[j].(OthelloMove)
+		return othelloSquareWeight(s.BoardSize, a.Row, a.Col) > othelloSquareWeight(s.BoardSize, b.Row, b.Col)
+	})
+	return moves
+}
@@ -0,0 +1,224 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// othelloBoard builds a board from rows of "B", "W" and "." characters
+func othelloBoard(rows ...string) [][]string {
+	board := make([][]string, len(rows))
+	for i, row := range rows {
+		board[i] = make([]string, len(row))
+		for j, cell := range row {
+			if cell != '.' {
+				board[i][j] = string(cell)
+			}
+		}
+	}
+	return board
+}
+
+// TestNewOthelloState tests creating a new Othello game
+func TestNewOthelloState(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	t.Run("Create Default Game", func(t *testing.T) {
+		state := NewOthelloState(player1, player2)
+
+		assert.Equal(t, 8, state.BoardSize)
+		assert.Len(t, state.Board, 8)
+		assert.Equal(t, "W", state.Board[3][3])
+		assert.Equal(t, "B", state.Board[3][4])
+		assert.Equal(t, "B", state.Board[4][3])
+		assert.Equal(t, "W", state.Board[4][4])
+		assert.Equal(t, 2, state.BlackCount)
+		assert.Equal(t, 2, state.WhiteCount)
+		assert.Equal(t, player1, state.CurrentPlayer)
+	})
+
+	t.Run("Create Custom Size Game", func(t *testing.T) {
+		for _, size := range []int{6, 10} {
+			state := NewOthelloStateWithSettings(player1, player2, map[string]interface{}{"othello_board_size": float64(size)})
+			assert.Equal(t, size, state.BoardSize)
+			assert.Equal(t, "B", state.Board[size/2-1][size/2])
+			assert.Len(t, state.LegalMoves(), 4)
+		}
+	})
+
+	t.Run("Unsupported Size Falls Back To Default", func(t *testing.T) {
+		state := NewOthelloStateWithSize(player1, player2, 7)
+		assert.Equal(t, DefaultOthelloBoardSize, state.BoardSize)
+	})
+}
+
+// TestOthelloValidateMove tests move validation
+func TestOthelloValidateMove(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+	state := NewOthelloState(player1, player2)
+
+	t.Run("Valid Move", func(t *testing.T) {
+		assert.NoError(t, state.ValidateMove(player1, OthelloMove{Row: 2, Col: 3}))
+	})
+
+	t.Run("Wrong Player Turn", func(t *testing.T) {
+		assert.Equal(t, ErrNotYourTurn, state.ValidateMove(player2, OthelloMove{Row: 2, Col: 3}))
+	})
+
+	t.Run("Out Of Bounds", func(t *testing.T) {
+		err := state.ValidateMove(player1, OthelloMove{Row: 8, Col: 0})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "bounds")
+	})
+
+	t.Run("Occupied Square", func(t *testing.T) {
+		err := state.ValidateMove(player1, OthelloMove{Row: 3, Col: 3})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "occupied")
+	})
+
+	t.Run("Must Flip A Disc", func(t *testing.T) {
+		err := state.ValidateMove(player1, OthelloMove{Row: 0, Col: 0})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "flip")
+	})
+}
+
+// TestOthelloApplyMove tests placing discs and flipping
+func TestOthelloApplyMove(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	t.Run("Flips Outflanked Disc", func(t *testing.T) {
+		state := NewOthelloState(player1, player2)
+		assert.NoError(t, state.ApplyMove(player1, OthelloMove{Row: 2, Col: 3}))
+
+		assert.Equal(t, "B", state.Board[2][3])
+		assert.Equal(t, "B", state.Board[3][3])
+		assert.Equal(t, 4, state.BlackCount)
+		assert.Equal(t, 1, state.WhiteCount)
+		assert.Equal(t, player2, state.CurrentPlayer)
+		assert.Nil(t, state.PassedPlayer)
+	})
+
+	t.Run("Flips In Several Directions", func(t *testing.T) {
+		state := NewOthelloStateWithSize(player1, player2, 6)
+		state.Board = othelloBoard(
+			"......",
+			".BB...",
+			".BWW..",
+			".BW...",
+			"......",
+			"......",
+		)
+		state.BlackCount, state.WhiteCount = 4, 3
+
+		assert.NoError(t, state.ApplyMove(player1, OthelloMove{Row: 3, Col: 3}))
+		assert.Equal(t, "B", state.Board[2][2]) // Diagonal
+		assert.Equal(t, "B", state.Board[3][2]) // Horizontal
+		assert.Equal(t, "W", state.Board[2][3]) // Not outflanked
+		assert.Equal(t, 7, state.BlackCount)
+		assert.Equal(t, 1, state.WhiteCount)
+	})
+
+	t.Run("Move From JSON", func(t *testing.T) {
+		state := NewOthelloState(player1, player2)
+		var move map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(`{"row":4,"col":5}`), &move))
+		assert.NoError(t, state.ApplyMove(player1, move))
+		assert.Equal(t, "B", state.Board[4][4])
+	})
+}
+
+// TestOthelloPass tests the automatic pass and the end of the game
+func TestOthelloPass(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	t.Run("Opponent Without A Move Passes", func(t *testing.T) {
+		state := NewOthelloStateWithSize(player1, player2, 6)
+		state.Board = othelloBoard(
+			".WB...",
+			"......",
+			"......",
+			"......",
+			"......",
+			"....WB",
+		)
+		state.BlackCount, state.WhiteCount = 2, 2
+
+		// White's only disc left, at 5,4, cannot outflank anything, so black moves again
+		assert.NoError(t, state.ApplyMove(player1, OthelloMove{Row: 0, Col: 0}))
+		assert.Equal(t, player1, state.CurrentPlayer)
+		assert.Equal(t, player2, *state.PassedPlayer)
+		assert.Equal(t, []interface{}{OthelloMove{Row: 5, Col: 3}}, state.LegalMoves())
+		_, gameOver := state.CheckWinner()
+		assert.False(t, gameOver)
+
+		// Taking the last white disc leaves neither player a move
+		assert.NoError(t, state.ApplyMove(player1, OthelloMove{Row: 5, Col: 3}))
+		winner, gameOver := state.CheckWinner()
+		assert.True(t, gameOver)
+		assert.Equal(t, player1, *winner)
+		assert.Equal(t, 6, state.BlackCount)
+		assert.Equal(t, 0, state.WhiteCount)
+		assert.Nil(t, state.PassedPlayer)
+		assert.Empty(t, state.LegalMoves())
+		assert.Equal(t, ErrGameAlreadyEnded, state.ValidateMove(player1, OthelloMove{Row: 1, Col: 1}))
+	})
+
+	t.Run("Equal Disc Count Is A Draw", func(t *testing.T) {
+		state := NewOthelloStateWithSize(player1, player2, 6)
+		state.Board = othelloBoard(
+			".WB...",
+			"......",
+			"......",
+			"......",
+			"......",
+			"...WWW",
+		)
+		state.BlackCount, state.WhiteCount = 1, 4
+
+		assert.NoError(t, state.ApplyMove(player1, OthelloMove{Row: 0, Col: 0}))
+		winner, gameOver := state.CheckWinner()
+		assert.True(t, gameOver)
+		assert.Nil(t, winner)
+		assert.Equal(t, 3, state.BlackCount)
+		assert.Equal(t, 3, state.WhiteCount)
+	})
+}
+
+// TestOthelloLegalMoves tests legal move enumeration
+func TestOthelloLegalMoves(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+	state := NewOthelloState(player1, player2)
+
+	assert.Equal(t, []interface{}{
+		OthelloMove{Row: 2, Col: 3},
+		OthelloMove{Row: 3, Col: 2},
+		OthelloMove{Row: 4, Col: 5},
+		OthelloMove{Row: 5, Col: 4},
+	}, state.LegalMoves())
+	for _, move := range state.LegalMoves() {
+		assert.NoError(t, state.ValidateMove(player1, move))
+	}
+}
+
+// TestOthelloClone tests that clones do not share state
+func TestOthelloClone(t *testing.T) {
+	player1 := uuid.New()
+	state := NewOthelloState(player1, uuid.New())
+	clone := state.Clone().(*OthelloState)
+
+	assert.NoError(t, clone.ApplyMove(player1, OthelloMove{Row: 2, Col: 3}))
+	assert.Equal(t, "", state.Board[2][3])
+	assert.Equal(t, "W", state.Board[3][3])
+	assert.Equal(t, 2, state.BlackCount)
+	assert.Nil(t, state.LastMove)
+}
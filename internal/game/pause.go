@@ -0,0 +1,78 @@
+package game
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrGamePaused is returned when a paused game is played on
+	ErrGamePaused = errors.New("game is paused")
+	// ErrGameNotPaused is returned when a game that is not paused is to be resumed
+	ErrGameNotPaused = errors.New("game is not paused")
+	// ErrPausesDisabled is returned when players ask to pause a game whose result counts
+	ErrPausesDisabled = errors.New("only casual games can be paused by their players")
+)
+
+// Pause records how a paused game was paused and the status it resumes in
+type Pause struct {
+	By     uuid.UUID  `json:"by"`              // Player who asked for the pause, or the admin who paused the game
+	Admin  bool       `json:"admin,omitempty"` // Paused by a tournament admin; only an admin resumes the game
+	At     time.Time  `json:"at"`
+	Status GameStatus `json:"status"` // Status the game had when it was paused
+}
+
+// PausesAllowed reports whether the players of the game may agree to pause it. Like takebacks,
+// only casual two-player games allow it; tournament games are paused by a tournament admin.
+func (g *Game) PausesAllowed() bool {
+	return !g.Rated && g.TournamentID == nil && !g.IsMultiplayer()
+}
+
+// Pause suspends an in-progress game until it is resumed, stopping the clock with the time the
+// player to move had left and clearing any pending draw offer, takeback or pause request.
+// admin pauses are made by a tournament admin and only apply to tournament games.
+func (g *Game) Pause(by uuid.UUID, admin bool, now time.Time) error {
+	if !g.Status.InProgress() {
+		return ErrGameNotActive
+	}
+	if admin && g.TournamentID == nil {
+		return errors.New("only tournament games are paused by an admin")
+	}
+	if !admin && !g.PausesAllowed() {
+		return ErrPausesDisabled
+	}
+
+	g.Paused = &Pause{By: by, Admin: admin, At: now, Status: g.Status}
+	g.Status = GameStatusPaused
+	g.PauseRequestedBy = nil
+	g.DrawOfferedBy = nil
+	g.TakebackRequestedBy = nil
+	g.UpdatedAt = now
+	if g.Clock != nil {
+		g.Clock.Stop(now)
+	}
+	return nil
+}
+
+// Resume continues a paused game in the status it was paused in. The clock of the player to
+// move runs again with the time they had left, and players who were disconnected get a full
+// reconnection grace period from now.
+func (g *Game) Resume(now time.Time) error {
+	if g.Status != GameStatusPaused || g.Paused == nil {
+		return ErrGameNotPaused
+	}
+
+	g.Status = g.Paused.Status
+	g.Paused = nil
+	g.ResumeRequestedBy = nil
+	g.UpdatedAt = now
+	if g.Clock != nil && g.Status == GameStatusActive {
+		g.Clock.Resume(g.CurrentTurn, now)
+	}
+	for playerID := range g.Disconnected {
+		g.Disconnected[playerID] = now
+	}
+	return nil
+}
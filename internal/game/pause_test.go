@@ -0,0 +1,144 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newConfiguredGame creates a tic-tac-toe game changed by configure before it is stored
+func newConfiguredGame(t *testing.T, player1 uuid.UUID, configure func(g *Game)) *eventSourcedGame {
+	t.Helper()
+	sg := newEventSourcedGame(t, GameTypeTicTacToe, player1, nil)
+	configure(sg.Game)
+	created, err := NewCreatedEvent(sg.Game)
+	require.NoError(t, err)
+	sg.events = nil
+	require.NoError(t, sg.store(created))
+	return sg
+}
+
+// TestPause tests pausing and resuming games
+func TestPause(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+	totalClock := map[string]interface{}{
+		"time_control":       "total",
+		"time_total_seconds": float64(60),
+	}
+
+	t.Run("Clock Is Frozen While Paused", func(t *testing.T) {
+		sg := newEventSourcedGame(t, GameTypeConnect4, player1, totalClock)
+		sg.emit(t, EventJoined, player2, JoinedData{Name: "Bob"})
+		sg.emit(t, EventStarted, uuid.Nil, StartedData{FirstPlayerID: &player1})
+		sg.emit(t, EventMove, player1, Connect4Move{Column: 3})
+		sg.emit(t, EventDrawOffered, player2, nil)
+		sg.emit(t, EventPauseRequested, player2, nil)
+		sg.emit(t, EventPaused, player2, PausedData{})
+
+		assert.Equal(t, GameStatusPaused, sg.Status)
+		require.NotNil(t, sg.Paused)
+		assert.Equal(t, player2, sg.Paused.By)
+		assert.Nil(t, sg.PauseRequestedBy)
+		assert.Nil(t, sg.DrawOfferedBy)
+		assert.False(t, sg.Clock.Running())
+		assert.Equal(t, int64(57000), sg.Clock.Remaining(player2, sg.now.Add(time.Hour)))
+		sg.assertRebuilt(t)
+
+		sg.now = sg.now.Add(24 * time.Hour)
+		sg.emit(t, EventResumeRequested, player1, nil)
+		sg.emit(t, EventResumed, player2, nil)
+		assert.Equal(t, GameStatusActive, sg.Status)
+		assert.Nil(t, sg.Paused)
+		assert.Nil(t, sg.ResumeRequestedBy)
+		assert.Equal(t, player2, sg.Clock.Turn)
+		assert.Equal(t, int64(56000), sg.Clock.Remaining(player2, sg.now.Add(time.Second)))
+
+		sg.emit(t, EventMove, player2, Connect4Move{Column: 3})
+		sg.assertRebuilt(t)
+	})
+
+	t.Run("Per Move Clock Keeps The Time Left For The Move", func(t *testing.T) {
+		sg := newEventSourcedGame(t, GameTypeTicTacToe, player1, map[string]interface{}{
+			"time_control":          "per_move",
+			"time_per_move_seconds": float64(30),
+		})
+		sg.emit(t, EventJoined, player2, JoinedData{Name: "Bob"})
+		sg.emit(t, EventStarted, uuid.Nil, StartedData{FirstPlayerID: &player1})
+		sg.emit(t, EventPaused, player1, PausedData{})
+		sg.emit(t, EventResumed, player2, nil)
+
+		assert.Equal(t, int64(29000), sg.Clock.Remaining(player1, sg.now))
+	})
+
+	t.Run("Setup Phase Resumes In Setup", func(t *testing.T) {
+		sg := newEventSourcedGame(t, GameTypeBattleship, player1, nil)
+		sg.emit(t, EventJoined, player2, JoinedData{Name: "Bob"})
+		sg.emit(t, EventStarted, uuid.Nil, StartedData{FirstPlayerID: &player1})
+		require.Equal(t, GameStatusSetup, sg.Status)
+
+		sg.emit(t, EventPaused, player1, PausedData{})
+		sg.emit(t, EventResumed, player2, nil)
+		assert.Equal(t, GameStatusSetup, sg.Status)
+		sg.assertRebuilt(t)
+	})
+
+	t.Run("Disconnected Players Get A New Grace Period", func(t *testing.T) {
+		sg := newEventSourcedGame(t, GameTypeTicTacToe, player1, nil)
+		sg.emit(t, EventJoined, player2, JoinedData{Name: "Bob"})
+		sg.emit(t, EventStarted, uuid.Nil, StartedData{FirstPlayerID: &player1})
+		sg.emit(t, EventDisconnected, player2, nil)
+		sg.emit(t, EventPaused, player1, PausedData{})
+		sg.now = sg.now.Add(time.Hour)
+		sg.emit(t, EventResumed, player1, nil)
+
+		assert.Equal(t, sg.now, sg.Disconnected[player2])
+	})
+
+	t.Run("Only Casual Games Are Paused By Players", func(t *testing.T) {
+		sg := newConfiguredGame(t, player1, func(g *Game) { g.Rated = true })
+		sg.emit(t, EventJoined, player2, JoinedData{Name: "Bob"})
+		sg.emit(t, EventStarted, uuid.Nil, StartedData{FirstPlayerID: &player1})
+
+		assert.ErrorIs(t, sg.tryEmit(EventPaused, player1, PausedData{}), ErrPausesDisabled)
+		assert.Error(t, sg.tryEmit(EventPaused, player1, PausedData{Admin: true}), "not a tournament game")
+	})
+
+	t.Run("Tournament Games Are Paused By An Admin", func(t *testing.T) {
+		admin := uuid.New()
+		tournamentID := uuid.New()
+		sg := newConfiguredGame(t, player1, func(g *Game) { g.TournamentID = &tournamentID })
+		sg.emit(t, EventJoined, player2, JoinedData{Name: "Bob"})
+		sg.emit(t, EventStarted, uuid.Nil, StartedData{FirstPlayerID: &player1})
+
+		assert.ErrorIs(t, sg.tryEmit(EventPaused, player1, PausedData{}), ErrPausesDisabled)
+		sg.emit(t, EventPaused, admin, PausedData{Admin: true})
+		require.NotNil(t, sg.Paused)
+		assert.True(t, sg.Paused.Admin)
+		assert.Equal(t, admin, sg.Paused.By)
+		sg.assertRebuilt(t)
+
+		sg.emit(t, EventResumed, admin, nil)
+		assert.Equal(t, GameStatusActive, sg.Status)
+	})
+
+	t.Run("Ending A Paused Game Clears The Resume Request", func(t *testing.T) {
+		sg := newEventSourcedGame(t, GameTypeTicTacToe, player1, nil)
+		sg.emit(t, EventJoined, player2, JoinedData{Name: "Bob"})
+		sg.emit(t, EventStarted, uuid.Nil, StartedData{FirstPlayerID: &player1})
+		sg.emit(t, EventPaused, player1, PausedData{})
+		sg.emit(t, EventResumeRequested, player2, nil)
+
+		sg.End(GameStatusAbandoned, nil, EndReasonDisconnect, sg.now)
+		assert.Nil(t, sg.ResumeRequestedBy)
+	})
+
+	t.Run("Only Games In Progress Are Paused Or Resumed", func(t *testing.T) {
+		sg := newEventSourcedGame(t, GameTypeTicTacToe, player1, nil)
+		assert.ErrorIs(t, sg.tryEmit(EventPaused, player1, PausedData{}), ErrGameNotActive)
+		assert.ErrorIs(t, sg.tryEmit(EventResumed, player1, nil), ErrGameNotPaused)
+	})
+}
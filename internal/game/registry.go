@@ -0,0 +1,389 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// SettingType describes the kind of value a game setting holds
+type SettingType string
+
+const (
+	SettingTypeInt    SettingType = "int"
+	SettingTypeString SettingType = "string"
+	SettingTypeBool   SettingType = "bool"
+)
+
+// SettingSchema describes a single configurable setting for a game type
+type SettingSchema struct {
+	Key         string        `json:"key"` // Matches the JSON key in domain.GameSettings
+	Label       string        `json:"label"`
+	Description string        `json:"description,omitempty"`
+	Type        SettingType   `json:"type"`
+	Default     interface{}   `json:"default"`
+	Min         *int          `json:"min,omitempty"`
+	Max         *int          `json:"max,omitempty"`
+	Options     []interface{} `json:"options,omitempty"` // Allowed values (if restricted)
+}
+
+// Definition describes a game engine and everything the services need to run it
+type Definition struct {
+	Type        GameType        `json:"type"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	MinPlayers  int             `json:"min_players"`
+	MaxPlayers  int             `json:"max_players"`
+	Settings    []SettingSchema `json:"settings"`
+
+	// New creates a fresh game state. player2ID may be uuid.Nil for games still waiting for an opponent.
+	// Games for more than two players may leave it nil and provide NewForPlayers instead.
+	New func(player1ID, player2ID uuid.UUID, settings map[string]interface{}) GameState `json:"-"`
+
+	// NewForPlayers creates a fresh game state for the players in seat order. Games with
+	// MaxPlayers above two must provide it; the services call it once every seat is taken.
+	NewForPlayers func(playerIDs []uuid.UUID, settings map[string]interface{}) GameState `json:"-"`
+
+	// Validate applies game-specific rules across settings (e.g. win length <= grid size).
+	// It runs after the generic schema checks and may be nil.
+	Validate func(settings map[string]interface{}) `json:"-"`
+
+	// Decode restores a game state from its serialized JSON
+	Decode func(data []byte) (GameState, error) `json:"-"`
+
+	// Bot creates a computer opponent for the game (nil if the game has no bot)
+	Bot func(difficulty BotDifficulty, rng *rand.Rand) Bot `json:"-"`
+}
+
+// SettingPlayers is the setting that picks how many players a multiplayer game seats. It is added
+// to every game type whose MaxPlayers is above two.
+const SettingPlayers = "players"
+
+// OpponentSetter is implemented by game states that can seat a second player after creation
+type OpponentSetter interface {
+	SetPlayer2(playerID uuid.UUID)
+}
+
+// Registry holds the set of available game engines
+type Registry struct {
+	mu    sync.RWMutex
+	defs  map[GameType]*Definition
+	order []GameType // Registration order (file order for built-in engines), used for stable listings
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		defs: make(map[GameType]*Definition),
+	}
+}
+
+// Register adds a game definition to the registry
+func (r *Registry) Register(def Definition) error {
+	if def.Type == "" {
+		return fmt.Errorf("game definition is missing a type")
+	}
+	if (def.New == nil && def.NewForPlayers == nil) || def.Decode == nil {
+		return fmt.Errorf("game definition %s must provide New and Decode", def.Type)
+	}
+	if def.MinPlayers < 2 {
+		def.MinPlayers = 2
+	}
+	if def.MaxPlayers < def.MinPlayers {
+		def.MaxPlayers = def.MinPlayers
+	}
+	if def.MaxPlayers > 2 {
+		if def.NewForPlayers == nil {
+			return fmt.Errorf("game definition %s seats up to %d players and must provide NewForPlayers", def.Type, def.MaxPlayers)
+		}
+		def.Settings = append(def.Settings[:len(def.Settings):len(def.Settings)], SettingSchema{
+			Key: SettingPlayers, Label: "Players", Type: SettingTypeInt,
+			Default: def.MinPlayers, Min: intPtr(def.MinPlayers), Max: intPtr(def.MaxPlayers),
+		})
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.defs[def.Type]; exists {
+		return fmt.Errorf("game type %s is already registered", def.Type)
+	}
+
+	r.defs[def.Type] = &def
+	r.order = append(r.order, def.Type)
+	return nil
+}
+
+// MustRegister is like Register but panics on error (intended for init functions)
+func (r *Registry) MustRegister(def Definition) {
+	if err := r.Register(def); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns the definition for a game type
+func (r *Registry) Lookup(gameType GameType) (*Definition, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	def, ok := r.defs[gameType]
+	return def, ok
+}
+
+// IsRegistered reports whether a game type is available
+func (r *Registry) IsRegistered(gameType GameType) bool {
+	_, ok := r.Lookup(gameType)
+	return ok
+}
+
+// Types returns all registered game types in registration order
+func (r *Registry) Types() []GameType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	types := make([]GameType, len(r.order))
+	copy(types, r.order)
+	return types
+}
+
+// Definitions returns all registered definitions in registration order
+func (r *Registry) Definitions() []*Definition {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	defs := make([]*Definition, 0, len(r.order))
+	for _, gameType := range r.order {
+		defs = append(defs, r.defs[gameType])
+	}
+	return defs
+}
+
+// NewState creates a new game state for the given type, validating settings first
+func (r *Registry) NewState(gameType GameType, player1ID, player2ID uuid.UUID, settings map[string]interface{}) (GameState, error) {
+	def, ok := r.Lookup(gameType)
+	if !ok {
+		return nil, fmt.Errorf("unsupported game type: %s", gameType)
+	}
+	if def.New == nil {
+		playerIDs := []uuid.UUID{player1ID}
+		if player2ID != uuid.Nil {
+			playerIDs = append(playerIDs, player2ID)
+		}
+		return def.NewForPlayers(playerIDs, def.validate(settings)), nil
+	}
+	return def.New(player1ID, player2ID, def.validate(settings)), nil
+}
+
+// NewStateForPlayers creates a new game state for the given players in seat order
+func (r *Registry) NewStateForPlayers(gameType GameType, playerIDs []uuid.UUID, settings map[string]interface{}) (GameState, error) {
+	def, ok := r.Lookup(gameType)
+	if !ok {
+		return nil, fmt.Errorf("unsupported game type: %s", gameType)
+	}
+	if len(playerIDs) > def.MaxPlayers {
+		return nil, fmt.Errorf("%s seats at most %d players", def.Name, def.MaxPlayers)
+	}
+	if def.NewForPlayers != nil {
+		return def.NewForPlayers(playerIDs, def.validate(settings)), nil
+	}
+
+	player1ID, player2ID := uuid.Nil, uuid.Nil
+	if len(playerIDs) > 0 {
+		player1ID = playerIDs[0]
+	}
+	if len(playerIDs) > 1 {
+		player2ID = playerIDs[1]
+	}
+	return def.New(player1ID, player2ID, def.validate(settings)), nil
+}
+
+// DecodeState restores a serialized game state for the given type
+func (r *Registry) DecodeState(gameType GameType, data []byte) (GameState, error) {
+	def, ok := r.Lookup(gameType)
+	if !ok {
+		return nil, fmt.Errorf("unsupported game type: %s", gameType)
+	}
+	state, err := def.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal game state: %w", err)
+	}
+	return state, nil
+}
+
+// DefaultSettings returns the default settings for a game type
+func (r *Registry) DefaultSettings(gameType GameType) (map[string]interface{}, error) {
+	def, ok := r.Lookup(gameType)
+	if !ok {
+		return nil, fmt.Errorf("unsupported game type: %s", gameType)
+	}
+	return def.validate(nil), nil
+}
+
+// ValidateSettings returns a copy of settings with invalid or missing values replaced by defaults.
+// Keys that do not belong to the game type are dropped.
+func (r *Registry) ValidateSettings(gameType GameType, settings map[string]interface{}) (map[string]interface{}, error) {
+	def, ok := r.Lookup(gameType)
+	if !ok {
+		return nil, fmt.Errorf("unsupported game type: %s", gameType)
+	}
+	return def.validate(settings), nil
+}
+
+// validate checks each setting (including the settings shared by every game type) against its
+// schema, then runs the game-specific hook
+func (d *Definition) validate(settings map[string]interface{}) map[string]interface{} {
+	common := CommonSettings()
+	result := make(map[string]interface{}, len(d.Settings)+len(common))
+
+	for _, schema := range append(d.Settings[:len(d.Settings):len(d.Settings)], common...) {
+		value, exists := settings[schema.Key]
+		if !exists || !schema.accepts(value) {
+			result[schema.Key] = schema.Default
+			continue
+		}
+		if schema.Type == SettingTypeInt {
+			intValue, _ := IntSetting(settings, schema.Key)
+			result[schema.Key] = intValue
+			continue
+		}
+		result[schema.Key] = value
+	}
+
+	if d.Validate != nil {
+		d.Validate(result)
+	}
+
+	return result
+}
+
+// accepts reports whether a value is valid for this setting
+func (s SettingSchema) accepts(value interface{}) bool {
+	switch s.Type {
+	case SettingTypeInt:
+		intValue, ok := toInt(value)
+		if !ok {
+			return false
+		}
+		if s.Min != nil && intValue < *s.Min {
+			return false
+		}
+		if s.Max != nil && intValue > *s.Max {
+			return false
+		}
+		if len(s.Options) > 0 {
+			for _, option := range s.Options {
+				if optionInt, ok := toInt(option); ok && optionInt == intValue {
+					return true
+				}
+			}
+			return false
+		}
+		return true
+	case SettingTypeString:
+		strValue, ok := value.(string)
+		if !ok {
+			return false
+		}
+		if len(s.Options) > 0 {
+			for _, option := range s.Options {
+				if option == strValue {
+					return true
+				}
+			}
+			return false
+		}
+		return true
+	case SettingTypeBool:
+		_, ok := value.(bool)
+		return ok
+	}
+	return false
+}
+
+// PlayersFromSettings returns how many players validated settings seat (two unless the game
+// type has a players setting)
+func PlayersFromSettings(settings map[string]interface{}) int {
+	if players, ok := IntSetting(settings, SettingPlayers); ok && players > 2 {
+		return players
+	}
+	return 2
+}
+
+// IntSetting reads an integer setting regardless of whether it came from JSON (float64) or Go code (int)
+func IntSetting(settings map[string]interface{}, key string) (int, bool) {
+	value, exists := settings[key]
+	if !exists {
+		return 0, false
+	}
+	return toInt(value)
+}
+
+// toInt converts the numeric types that can appear in a settings map to an int
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		if v != float64(int(v)) {
+			return 0, false
+		}
+		return int(v), true
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return 0, false
+		}
+		return int(n), true
+	}
+	return 0, false
+}
+
+// intPtr returns a pointer to an int (used for schema bounds)
+func intPtr(v int) *int {
+	return &v
+}
+
+// decodeState is a helper for Definition.Decode implementations
+func decodeState[T any, PT interface {
+	*T
+	GameState
+}](data []byte) (GameState, error) {
+	var state T
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return PT(&state), nil
+}
+
+// DefaultRegistry is the registry used by the services; engines register themselves in init
+var DefaultRegistry = NewRegistry()
+
+// Register adds a game definition to the default registry
+func Register(def Definition) {
+	DefaultRegistry.MustRegister(def)
+}
+
+// Lookup returns a definition from the default registry
+func Lookup(gameType GameType) (*Definition, bool) {
+	return DefaultRegistry.Lookup(gameType)
+}
+
+// IsRegistered reports whether a game type is available in the default registry
+func IsRegistered(gameType GameType) bool {
+	return DefaultRegistry.IsRegistered(gameType)
+}
+
+// RegisteredTypes returns all game types in the default registry
+func RegisteredTypes() []GameType {
+	return DefaultRegistry.Types()
+}
+
+// Definitions returns all definitions in the default registry
+func Definitions() []*Definition {
+	return DefaultRegistry.Definitions()
+}
@@ -0,0 +1,134 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDefaultRegistry tests that the built-in engines register themselves
+func TestDefaultRegistry(t *testing.T) {
+	t.Run("Built-in Games Registered", func(t *testing.T) {
+		assert.ElementsMatch(t, []GameType{GameTypeTicTacToe, GameTypeConnect4, GameTypeRockPaperScissors, GameTypeDotsAndBoxes, GameTypeGomoku, GameTypeCheckers, GameTypeOthello, GameTypeBattleship, GameTypeRPSElimination}, RegisteredTypes())
+		assert.True(t, IsRegistered(GameTypeConnect4))
+		assert.False(t, IsRegistered(GameType("chess")))
+	})
+
+	t.Run("Duplicate Registration Rejected", func(t *testing.T) {
+		def, _ := Lookup(GameTypeTicTacToe)
+		err := DefaultRegistry.Register(*def)
+		assert.Error(t, err)
+	})
+
+	t.Run("Definition Without Constructor Rejected", func(t *testing.T) {
+		registry := NewRegistry()
+		err := registry.Register(Definition{Type: "empty"})
+		assert.Error(t, err)
+	})
+
+	t.Run("Multiplayer Definition Needs NewForPlayers", func(t *testing.T) {
+		registry := NewRegistry()
+		def, _ := Lookup(GameTypeTicTacToe)
+		multiplayer := *def
+		multiplayer.MaxPlayers = 4
+		assert.Error(t, registry.Register(multiplayer))
+
+		multiplayer.NewForPlayers = func(playerIDs []uuid.UUID, settings map[string]interface{}) GameState {
+			return NewTicTacToeState(playerIDs[0], playerIDs[1])
+		}
+		assert.NoError(t, registry.Register(multiplayer))
+		settings, err := registry.DefaultSettings(GameTypeTicTacToe)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, settings[SettingPlayers])
+		original, _ := DefaultRegistry.DefaultSettings(GameTypeTicTacToe)
+		assert.NotContains(t, original, SettingPlayers, "the original definition is untouched")
+	})
+}
+
+// TestRegistrySettings tests default and validated settings
+func TestRegistrySettings(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		settings, err := DefaultRegistry.DefaultSettings(GameTypeConnect4)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"connect4_rows":          6,
+			"connect4_cols":          7,
+			"connect4_win_length":    4,
+			"time_control":           "none",
+			"time_total_seconds":     300,
+			"time_increment_seconds": 0,
+			"time_per_move_seconds":  30,
+			"first_mover":            "alternate",
+		}, settings)
+	})
+
+	t.Run("Out Of Range Values Replaced", func(t *testing.T) {
+		settings, err := DefaultRegistry.ValidateSettings(GameTypeConnect4, map[string]interface{}{
+			"connect4_rows": float64(12),
+			"connect4_cols": float64(9),
+			"rps_best_of":   float64(7), // Belongs to another game, dropped
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 6, settings["connect4_rows"])
+		assert.Equal(t, 9, settings["connect4_cols"])
+		assert.NotContains(t, settings, "rps_best_of")
+	})
+
+	t.Run("Options Enforced", func(t *testing.T) {
+		settings, _ := DefaultRegistry.ValidateSettings(GameTypeRockPaperScissors, map[string]interface{}{"rps_best_of": float64(4)})
+		assert.Equal(t, 5, settings["rps_best_of"])
+
+		settings, _ = DefaultRegistry.ValidateSettings(GameTypeRockPaperScissors, map[string]interface{}{"rps_best_of": float64(7)})
+		assert.Equal(t, 7, settings["rps_best_of"])
+	})
+
+	t.Run("Game Specific Validation", func(t *testing.T) {
+		settings, _ := DefaultRegistry.ValidateSettings(GameTypeTicTacToe, map[string]interface{}{
+			"tictactoe_grid_size":  float64(4),
+			"tictactoe_win_length": float64(5),
+		})
+		assert.Equal(t, 4, settings["tictactoe_win_length"])
+	})
+
+	t.Run("Unknown Game Type", func(t *testing.T) {
+		_, err := DefaultRegistry.ValidateSettings(GameType("chess"), nil)
+		assert.Error(t, err)
+	})
+}
+
+// TestRegistryStateRoundTrip tests creating and decoding states through the registry
+func TestRegistryStateRoundTrip(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	for _, gameType := range RegisteredTypes() {
+		t.Run(string(gameType), func(t *testing.T) {
+			state, err := DefaultRegistry.NewState(gameType, player1, player2, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, player1, state.GetCurrentPlayer())
+
+			data, err := json.Marshal(state.GetState())
+			assert.NoError(t, err)
+
+			decoded, err := DefaultRegistry.DecodeState(gameType, data)
+			assert.NoError(t, err)
+			assert.Equal(t, state, decoded)
+		})
+	}
+
+	t.Run("Custom Settings Applied", func(t *testing.T) {
+		state, err := DefaultRegistry.NewState(GameTypeDotsAndBoxes, player1, player2, map[string]interface{}{"dots_grid_size": float64(6)})
+		assert.NoError(t, err)
+		assert.Equal(t, 6, state.(*DotsAndBoxesState).GridRows)
+	})
+
+	t.Run("Seat Second Player", func(t *testing.T) {
+		state, _ := DefaultRegistry.NewState(GameTypeTicTacToe, player1, uuid.Nil, nil)
+		setter, ok := state.(OpponentSetter)
+		assert.True(t, ok)
+		setter.SetPlayer2(player2)
+		assert.Equal(t, player2, state.(*TicTacToeState).Player2ID)
+	})
+}
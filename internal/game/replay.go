@@ -0,0 +1,148 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ReplayFrame is the position of a game after a given ply
+type ReplayFrame struct {
+	Ply           int         `json:"ply"`            // 0 is the starting position
+	Move          *MoveRecord `json:"move,omitempty"` // Move (or takeback) that produced this position (nil for ply 0)
+	State         interface{} `json:"state"`
+	CurrentPlayer uuid.UUID   `json:"current_player"`
+	WinnerID      *uuid.UUID  `json:"winner_id,omitempty"`
+	GameOver      bool        `json:"game_over"`
+	Verified      bool        `json:"verified"` // Rebuilt state hash matches the one recorded when the move was played
+}
+
+// Replay rebuilds historical positions of a game by re-applying its move log. Each record of
+// the log is one frame, takebacks included.
+type Replay struct {
+	initial GameState
+	moves   []MoveRecord
+}
+
+// NewReplay creates a replay of a two-player game starting from a fresh state built with the
+// game's original settings
+func NewReplay(registry *Registry, gameType GameType, player1ID, player2ID uuid.UUID, settings map[string]interface{}, moves []MoveRecord) (*Replay, error) {
+	return NewReplayForPlayers(registry, gameType, []uuid.UUID{player1ID, player2ID}, settings, moves)
+}
+
+// NewReplayForPlayers creates a replay for the players in seat order, starting from a fresh
+// state built with the game's original settings
+func NewReplayForPlayers(registry *Registry, gameType GameType, playerIDs []uuid.UUID, settings map[string]interface{}, moves []MoveRecord) (*Replay, error) {
+	initial, err := registry.NewStateForPlayers(gameType, playerIDs, settings)
+	if err != nil {
+		return nil, err
+	}
+	return &Replay{initial: initial, moves: moves}, nil
+}
+
+// TotalPlies returns the number of moves in the replay
+func (r *Replay) TotalPlies() int {
+	return len(r.moves)
+}
+
+// Moves returns the move log the replay was built from
+func (r *Replay) Moves() []MoveRecord {
+	return r.moves
+}
+
+// StateAt returns the game state after the given ply (0 = starting position)
+func (r *Replay) StateAt(ply int) (GameState, error) {
+	if ply < 0 || ply > len(r.moves) {
+		return nil, fmt.Errorf("ply %d out of range (0-%d)", ply, len(r.moves))
+	}
+
+	return r.rebuild(ActiveMoves(r.moves[:ply]))
+}
+
+// rebuild applies moves to a copy of the starting position
+func (r *Replay) rebuild(moves []MoveRecord) (GameState, error) {
+	state := r.initial.Clone()
+	for i := range moves {
+		if err := applyRecord(state, &moves[i]); err != nil {
+			return nil, err
+		}
+	}
+	return state, nil
+}
+
+// FrameAt returns the position after the given ply (0 = starting position)
+func (r *Replay) FrameAt(ply int) (*ReplayFrame, error) {
+	state, err := r.StateAt(ply)
+	if err != nil {
+		return nil, err
+	}
+
+	var move *MoveRecord
+	if ply > 0 {
+		move = &r.moves[ply-1]
+	}
+	return newReplayFrame(ply, move, state)
+}
+
+// Frames returns every position of the game, from the starting position to the last move
+func (r *Replay) Frames() ([]ReplayFrame, error) {
+	frames := make([]ReplayFrame, 0, len(r.moves)+1)
+
+	state := r.initial.Clone()
+	frame, err := newReplayFrame(0, nil, state)
+	if err != nil {
+		return nil, err
+	}
+	frames = append(frames, *frame)
+
+	for i := range r.moves {
+		if r.moves[i].Takeback > 0 {
+			// Go back to the position before the moves that were taken back
+			if state, err = r.rebuild(ActiveMoves(r.moves[:i+1])); err != nil {
+				return nil, err
+			}
+		} else if err := applyRecord(state, &r.moves[i]); err != nil {
+			return nil, err
+		}
+		frame, err := newReplayFrame(i+1, &r.moves[i], state)
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, *frame)
+	}
+	return frames, nil
+}
+
+// applyRecord re-applies a logged move to state
+func applyRecord(state GameState, record *MoveRecord) error {
+	var move interface{}
+	if err := json.Unmarshal(record.Move, &move); err != nil {
+		return fmt.Errorf("failed to unmarshal move at ply %d: %w", record.Ply, err)
+	}
+	if err := state.ApplyMove(record.PlayerID, move); err != nil {
+		return fmt.Errorf("failed to replay move at ply %d: %w", record.Ply, err)
+	}
+	return nil
+}
+
+// newReplayFrame snapshots state into a frame
+func newReplayFrame(ply int, move *MoveRecord, state GameState) (*ReplayFrame, error) {
+	hash, err := HashState(state)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := state.Clone()
+	winner, gameOver := snapshot.CheckWinner()
+
+	return &ReplayFrame{
+		Ply:           ply,
+		Move:          move,
+		State:         snapshot.GetState(),
+		CurrentPlayer: snapshot.GetCurrentPlayer(),
+		WinnerID:      winner,
+		GameOver:      gameOver,
+		Verified:      move == nil || move.StateHash == hash,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// playTicTacToe plays moves on a fresh board and returns the resulting move log
+func playTicTacToe(t *testing.T, player1, player2 uuid.UUID, settings map[string]interface{}, moves []TicTacToeMove) []MoveRecord {
+	state, err := DefaultRegistry.NewState(GameTypeTicTacToe, player1, player2, settings)
+	assert.NoError(t, err)
+
+	records := make([]MoveRecord, 0, len(moves))
+	for i, move := range moves {
+		playerID := state.GetCurrentPlayer()
+		assert.NoError(t, state.ApplyMove(playerID, move))
+		record, err := NewMoveRecord(i+1, playerID, move, state)
+		assert.NoError(t, err)
+		records = append(records, *record)
+	}
+	return records
+}
+
+// TestReplay tests rebuilding historical positions from a move log
+func TestReplay(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+	settings := map[string]interface{}{"tictactoe_grid_size": 4, "tictactoe_win_length": 3}
+	moves := []TicTacToeMove{{Row: 0, Col: 0}, {Row: 1, Col: 0}, {Row: 0, Col: 1}, {Row: 1, Col: 1}, {Row: 0, Col: 2}}
+	records := playTicTacToe(t, player1, player2, settings, moves)
+
+	t.Run("Starting Position", func(t *testing.T) {
+		replay, err := NewReplay(DefaultRegistry, GameTypeTicTacToe, player1, player2, settings, records)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, replay.TotalPlies())
+
+		frame, err := replay.FrameAt(0)
+		assert.NoError(t, err)
+		assert.Nil(t, frame.Move)
+		assert.Equal(t, player1, frame.CurrentPlayer)
+		assert.False(t, frame.GameOver)
+		assert.True(t, frame.Verified)
+	})
+
+	t.Run("Intermediate Position", func(t *testing.T) {
+		replay, _ := NewReplay(DefaultRegistry, GameTypeTicTacToe, player1, player2, settings, records)
+
+		state, err := replay.StateAt(2)
+		assert.NoError(t, err)
+		board := state.(*TicTacToeState).Board
+		assert.Len(t, board, 4)
+		assert.Equal(t, "X", board[0][0])
+		assert.Equal(t, "O", board[1][0])
+		assert.Equal(t, "", board[0][1])
+	})
+
+	t.Run("Final Position Matches Recorded Hashes", func(t *testing.T) {
+		replay, _ := NewReplay(DefaultRegistry, GameTypeTicTacToe, player1, player2, settings, records)
+
+		frames, err := replay.Frames()
+		assert.NoError(t, err)
+		assert.Len(t, frames, 6)
+		for _, frame := range frames {
+			assert.True(t, frame.Verified, "ply %d should verify", frame.Ply)
+		}
+
+		last := frames[5]
+		assert.True(t, last.GameOver)
+		assert.Equal(t, player1, *last.WinnerID)
+	})
+
+	t.Run("Wrong Settings Fail Verification", func(t *testing.T) {
+		// Default 3x3 board still accepts these moves but produces different states
+		replay, _ := NewReplay(DefaultRegistry, GameTypeTicTacToe, player1, player2, nil, records)
+
+		frame, err := replay.FrameAt(1)
+		assert.NoError(t, err)
+		assert.False(t, frame.Verified)
+	})
+
+	t.Run("Ply Out Of Range", func(t *testing.T) {
+		replay, _ := NewReplay(DefaultRegistry, GameTypeTicTacToe, player1, player2, settings, records)
+
+		_, err := replay.FrameAt(6)
+		assert.Error(t, err)
+		_, err = replay.StateAt(-1)
+		assert.Error(t, err)
+	})
+}
@@ -1,8 +1,11 @@
 package game
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"strings"
 
 	"github.com/google/uuid"
 )
@@ -29,22 +32,62 @@ type RPSRound struct {
 
 // RPSState represents the state of a Rock-Paper-Scissors game
 type RPSState struct {
-	Player1ID       uuid.UUID  `json:"player1_id"`
-	Player2ID       uuid.UUID  `json:"player2_id"`
-	CurrentRound    int        `json:"current_round"`    // 1-5
-	Player1Score    int        `json:"player1_score"`    // Rounds won
-	Player2Score    int        `json:"player2_score"`    // Rounds won
-	Rounds          []RPSRound `json:"rounds"`           // History of all rounds
-	Player1Choice   RPSChoice  `json:"player1_choice"`   // Current round choice
-	Player2Choice   RPSChoice  `json:"player2_choice"`   // Current round choice
-	BothRevealed    bool       `json:"both_revealed"`    // Both players made choice
-	MaxRounds       int        `json:"max_rounds"`       // Best of 5 = 5 rounds max
-	WinsNeeded      int        `json:"wins_needed"`      // 3 wins needed
+	Player1ID         uuid.UUID  `json:"player1_id"`
+	Player2ID         uuid.UUID  `json:"player2_id"`
+	CurrentRound      int        `json:"current_round"`                // 1-5
+	Player1Score      int        `json:"player1_score"`                // Rounds won
+	Player2Score      int        `json:"player2_score"`                // Rounds won
+	Rounds            []RPSRound `json:"rounds"`                       // History of all rounds
+	Player1Choice     RPSChoice  `json:"player1_choice"`               // Current round choice
+	Player2Choice     RPSChoice  `json:"player2_choice"`               // Current round choice
+	BothRevealed      bool       `json:"both_revealed"`                // Both players made choice
+	MaxRounds         int        `json:"max_rounds"`                   // Best of 5 = 5 rounds max
+	WinsNeeded        int        `json:"wins_needed"`                  // 3 wins needed
+	CommitReveal      bool       `json:"commit_reveal"`                // Players commit to a hash of their choice before revealing it
+	Player1Commitment string     `json:"player1_commitment,omitempty"` // Current round commitment
+	Player2Commitment string     `json:"player2_commitment,omitempty"` // Current round commitment
 }
 
-// RPSMove represents a move in Rock-Paper-Scissors
+// RPSMove represents a move in Rock-Paper-Scissors. Outside commit-reveal mode only Choice is used.
+// In commit-reveal mode a player first sends Commitment, then Choice and Salt to reveal it.
 type RPSMove struct {
-	Choice RPSChoice `json:"choice"`
+	Choice     RPSChoice `json:"choice,omitempty"`
+	Commitment string    `json:"commitment,omitempty"` // Hex SHA-256 of "choice:salt", see RPSCommitment
+	Salt       string    `json:"salt,omitempty"`       // Secret the commitment was made with
+}
+
+// rpsView is the state as seen by one viewer: pending choices of other players are hidden,
+// but everyone can see whether a player is ready
+type rpsView struct {
+	RPSState
+	Player1Ready bool `json:"player1_ready"` // Player 1 has chosen or committed this round
+	Player2Ready bool `json:"player2_ready"` // Player 2 has chosen or committed this round
+}
+
+// RPSCommitment returns the commitment for a choice in commit-reveal mode. Clients should
+// use a fresh random salt every round so the choice cannot be guessed from the hash.
+func RPSCommitment(choice RPSChoice, salt string) string {
+	sum := sha256.Sum256([]byte(string(choice) + ":" + salt))
+	return hex.EncodeToString(sum[:])
+}
+
+func init() {
+	Register(Definition{
+		Type:        GameTypeRockPaperScissors,
+		Name:        "Rock Paper Scissors",
+		Description: "Both players choose simultaneously; first to win the majority of rounds takes the match",
+		MinPlayers:  2,
+		MaxPlayers:  2,
+		Settings: []SettingSchema{
+			{Key: "rps_best_of", Label: "Best of", Type: SettingTypeInt, Default: 5, Options: []interface{}{3, 5, 7, 9}},
+			{Key: "rps_commit_reveal", Label: "Commit-reveal", Description: "Submit a hash of your choice first and reveal it once both players have committed", Type: SettingTypeBool, Default: false},
+		},
+		New: func(player1ID, player2ID uuid.UUID, settings map[string]interface{}) GameState {
+			return NewRPSStateWithSettings(player1ID, player2ID, settings)
+		},
+		Decode: decodeState[RPSState],
+		Bot:    newRPSBot,
+	})
 }
 
 // NewRPSState creates a new Rock-Paper-Scissors game state with default settings (best of 5)
@@ -55,16 +98,18 @@ func NewRPSState(player1ID, player2ID uuid.UUID) *RPSState {
 // NewRPSStateWithSettings creates a new RPS game state with custom settings
 func NewRPSStateWithSettings(player1ID, player2ID uuid.UUID, settings interface{}) *RPSState {
 	bestOf := 5 // default
+	commitReveal := false
 	
 	if settingsMap, ok := settings.(map[string]interface{}); ok {
-		if val, exists := settingsMap["rps_best_of"]; exists {
-			if bestOfFloat, ok := val.(float64); ok {
-				bestOf = int(bestOfFloat)
-			}
+		if val, exists := IntSetting(settingsMap, "rps_best_of"); exists {
+			bestOf = val
 		}
+		commitReveal, _ = settingsMap["rps_commit_reveal"].(bool)
 	}
 	
-	return NewRPSStateWithBestOf(player1ID, player2ID, bestOf)
+	state := NewRPSStateWithBestOf(player1ID, player2ID, bestOf)
+	state.CommitReveal = commitReveal
+	return state
 }
 
 // NewRPSStateWithBestOf creates a new RPS game state with specified best-of rounds
@@ -104,8 +149,8 @@ func (s *RPSState) ValidateMove(playerID uuid.UUID, move interface{}) error {
 		return ErrInvalidPlayer
 	}
 
-	// SAFETY CHECK: Game should already be over if someone reached WinsNeeded
-	if s.Player1Score >= s.WinsNeeded || s.Player2Score >= s.WinsNeeded {
+	// No choices once someone reached WinsNeeded or the last round has been played
+	if _, gameOver := s.CheckWinner(); gameOver {
 		return ErrGameAlreadyEnded
 	}
 
@@ -115,22 +160,81 @@ func (s *RPSState) ValidateMove(playerID uuid.UUID, move interface{}) error {
 		return err
 	}
 
-	// Validate choice
-	if rpsMove.Choice != RPSChoiceRock && rpsMove.Choice != RPSChoicePaper && rpsMove.Choice != RPSChoiceScissors {
-		return errors.New("invalid choice: must be rock, paper, or scissors")
-	}
-
 	// Check if player already made a choice this round
-	if playerID == s.Player1ID && s.Player1Choice != RPSChoiceNone {
+	if s.choiceOf(playerID) != RPSChoiceNone {
 		return errors.New("you have already made a choice this round")
 	}
-	if playerID == s.Player2ID && s.Player2Choice != RPSChoiceNone {
-		return errors.New("you have already made a choice this round")
+
+	if s.CommitReveal {
+		return s.validateCommitReveal(playerID, rpsMove)
+	}
+	return validateRPSChoice(rpsMove.Choice)
+}
+
+// validateCommitReveal checks a move in commit-reveal mode. A player commits first and may only
+// reveal once the opponent is locked in; a player who has not committed yet may also play their
+// choice openly once the opponent is locked in, since it can no longer influence them.
+func (s *RPSState) validateCommitReveal(playerID uuid.UUID, move *RPSMove) error {
+	opponentID := s.Player1ID
+	if playerID == s.Player1ID {
+		opponentID = s.Player2ID
 	}
+	commitment := s.commitmentOf(playerID)
 
+	if move.Commitment != "" {
+		if commitment != "" {
+			return errors.New("you have already committed this round")
+		}
+		if decoded, err := hex.DecodeString(move.Commitment); err != nil || len(decoded) != sha256.Size {
+			return errors.New("commitment must be a hex-encoded SHA-256 hash")
+		}
+		return nil
+	}
+
+	if !s.lockedIn(opponentID) {
+		if commitment == "" {
+			return errors.New("commit to your choice before revealing it")
+		}
+		return errors.New("wait for your opponent to commit before revealing")
+	}
+	if err := validateRPSChoice(move.Choice); err != nil {
+		return err
+	}
+	if commitment != "" && RPSCommitment(move.Choice, move.Salt) != commitment {
+		return errors.New("choice and salt do not match your commitment")
+	}
+	return nil
+}
+
+// validateRPSChoice checks that a choice is rock, paper or scissors
+func validateRPSChoice(choice RPSChoice) error {
+	if choice != RPSChoiceRock && choice != RPSChoicePaper && choice != RPSChoiceScissors {
+		return errors.New("invalid choice: must be rock, paper, or scissors")
+	}
 	return nil
 }
 
+// choiceOf returns a player's choice for the current round
+func (s *RPSState) choiceOf(playerID uuid.UUID) RPSChoice {
+	if playerID == s.Player1ID {
+		return s.Player1Choice
+	}
+	return s.Player2Choice
+}
+
+// commitmentOf returns a player's commitment for the current round
+func (s *RPSState) commitmentOf(playerID uuid.UUID) string {
+	if playerID == s.Player1ID {
+		return s.Player1Commitment
+	}
+	return s.Player2Commitment
+}
+
+// lockedIn reports whether a player has chosen or committed this round
+func (s *RPSState) lockedIn(playerID uuid.UUID) bool {
+	return s.choiceOf(playerID) != RPSChoiceNone || s.commitmentOf(playerID) != ""
+}
+
 // ApplyMove applies a move to the game state
 func (s *RPSState) ApplyMove(playerID uuid.UUID, move interface{}) error {
 	// Validate first
@@ -144,6 +248,16 @@ func (s *RPSState) ApplyMove(playerID uuid.UUID, move interface{}) error {
 		return err
 	}
 
+	// Record a commitment; the round resolves once both players have revealed
+	if s.CommitReveal && rpsMove.Commitment != "" {
+		if playerID == s.Player1ID {
+			s.Player1Commitment = strings.ToLower(rpsMove.Commitment)
+		} else {
+			s.Player2Commitment = strings.ToLower(rpsMove.Commitment)
+		}
+		return nil
+	}
+
 	// Record the choice
 	if playerID == s.Player1ID {
 		s.Player1Choice = rpsMove.Choice
@@ -198,6 +312,8 @@ func (s *RPSState) resolveRound() {
 	// Reset for next round
 	s.Player1Choice = RPSChoiceNone
 	s.Player2Choice = RPSChoiceNone
+	s.Player1Commitment = ""
+	s.Player2Commitment = ""
 	s.BothRevealed = false
 	s.CurrentRound++
 }
@@ -229,14 +345,51 @@ func (s *RPSState) CheckWinner() (winner *uuid.UUID, gameOver bool) {
 	return nil, false
 }
 
+// LegalMoves returns the three choices while the match is undecided. Both players choose
+// simultaneously, so the same choices are open to whichever player has not chosen yet.
+// Commitments and reveals cannot be enumerated, so in commit-reveal mode the choices are only
+// listed when the player to move may play one openly.
+func (s *RPSState) LegalMoves() []interface{} {
+	moves := []interface{}{}
+	if _, gameOver := s.CheckWinner(); gameOver {
+		return moves
+	}
+	if s.CommitReveal {
+		mover := s.GetCurrentPlayer()
+		opponentID := s.Player1ID
+		if mover == s.Player1ID {
+			opponentID = s.Player2ID
+		}
+		if s.lockedIn(mover) || !s.lockedIn(opponentID) {
+			return moves
+		}
+	}
+	for _, choice := range []RPSChoice{RPSChoiceRock, RPSChoicePaper, RPSChoiceScissors} {
+		moves = append(moves, RPSMove{Choice: choice})
+	}
+	return moves
+}
+
 // GetCurrentPlayer returns the ID of the player whose turn it is
 // For RPS, both players play simultaneously, so we return a placeholder
 func (s *RPSState) GetCurrentPlayer() uuid.UUID {
 	// In RPS, both players move simultaneously
-	// We'll return Player1ID by default, but the game logic should allow both to move
+	// Return player 2 once player 1 has chosen (so their clock runs), otherwise player 1
+	if s.lockedIn(s.Player1ID) && !s.lockedIn(s.Player2ID) {
+		return s.Player2ID
+	}
+	// Commit-reveal: both have committed, so the same applies to revealing
+	if s.Player1Choice != RPSChoiceNone && s.Player2Choice == RPSChoiceNone {
+		return s.Player2ID
+	}
 	return s.Player1ID
 }
 
+// SetPlayer2 seats the second player when they join a waiting game
+func (s *RPSState) SetPlayer2(playerID uuid.UUID) {
+	s.Player2ID = playerID
+}
+
 // GetState returns the current game state for serialization
 func (s *RPSState) GetState() interface{} {
 	// Defensive: Ensure Rounds is never nil
@@ -246,6 +399,23 @@ func (s *RPSState) GetState() interface{} {
 	return s
 }
 
+// StateFor hides the current round's choices from everyone but the player who made them.
+// Commitments stay visible: they reveal nothing about the choice without the salt.
+func (s *RPSState) StateFor(viewerID uuid.UUID) interface{} {
+	view := rpsView{
+		RPSState:     *s.GetState().(*RPSState),
+		Player1Ready: s.lockedIn(s.Player1ID),
+		Player2Ready: s.lockedIn(s.Player2ID),
+	}
+	if viewerID == uuid.Nil || viewerID != s.Player1ID {
+		view.Player1Choice = RPSChoiceNone
+	}
+	if viewerID == uuid.Nil || viewerID != s.Player2ID {
+		view.Player2Choice = RPSChoiceNone
+	}
+	return view
+}
+
 // Clone creates a deep copy of the game state
 func (s *RPSState) Clone() GameState {
 	newState := *s
@@ -0,0 +1,116 @@
+package game
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/google/uuid"
+)
+
+var rpsChoices = []RPSChoice{RPSChoiceRock, RPSChoicePaper, RPSChoiceScissors}
+
+// rpsCounter maps each choice to the choice that beats it
+var rpsCounter = map[RPSChoice]RPSChoice{
+	RPSChoiceRock:     RPSChoicePaper,
+	RPSChoicePaper:    RPSChoiceScissors,
+	RPSChoiceScissors: RPSChoiceRock,
+}
+
+// rpsBot plays Rock-Paper-Scissors. It only looks at completed rounds, never at the
+// opponent's pending choice for the current round.
+type rpsBot struct {
+	difficulty BotDifficulty
+	rng        *rand.Rand
+}
+
+func newRPSBot(difficulty BotDifficulty, rng *rand.Rand) Bot {
+	return &rpsBot{difficulty: difficulty, rng: rng}
+}
+
+// ChooseMove picks a choice for the current round.
+// Easy plays uniformly at random; medium counters the opponent's most frequent choice;
+// hard counters what the opponent has most often played after their previous choice,
+// falling back to frequency and mixing in random play so it cannot be exploited easily.
+func (b *rpsBot) ChooseMove(state GameState, botID uuid.UUID) (interface{}, error) {
+	s, ok := state.(*RPSState)
+	if !ok {
+		return nil, ErrInvalidMove
+	}
+	if botID != s.Player1ID && botID != s.Player2ID {
+		return nil, ErrInvalidPlayer
+	}
+	if (botID == s.Player1ID && s.Player1Choice != RPSChoiceNone) || (botID == s.Player2ID && s.Player2Choice != RPSChoiceNone) {
+		return nil, errors.New("bot has already chosen this round")
+	}
+
+	history := rpsOpponentHistory(s, botID)
+
+	var prediction RPSChoice
+	switch b.difficulty {
+	case BotMedium:
+		prediction = b.mostFrequent(history, nil)
+	case BotHard:
+		if b.rng.Intn(5) > 0 {
+			prediction = b.mostFrequent(history, rpsFollowers(history))
+		}
+	}
+
+	if prediction == RPSChoiceNone {
+		return RPSMove{Choice: rpsChoices[b.rng.Intn(len(rpsChoices))]}, nil
+	}
+	return RPSMove{Choice: rpsCounter[prediction]}, nil
+}
+
+// mostFrequent returns the most common choice, preferring the candidates in preferred when there are any.
+// Ties are broken at random; RPSChoiceNone is returned when there is nothing to go on.
+func (b *rpsBot) mostFrequent(history, preferred []RPSChoice) RPSChoice {
+	if len(preferred) > 0 {
+		history = preferred
+	}
+	if len(history) == 0 {
+		return RPSChoiceNone
+	}
+
+	counts := make(map[RPSChoice]int)
+	for _, choice := range history {
+		counts[choice]++
+	}
+	var best []RPSChoice
+	for _, choice := range rpsChoices {
+		switch {
+		case len(best) == 0 || counts[choice] > counts[best[0]]:
+			best = []RPSChoice{choice}
+		case counts[choice] == counts[best[0]]:
+			best = append(best, choice)
+		}
+	}
+	return best[b.rng.Intn(len(best))]
+}
+
+// rpsOpponentHistory returns the opponent's choices in completed rounds, oldest first
+func rpsOpponentHistory(s *RPSState, botID uuid.UUID) []RPSChoice {
+	history := make([]RPSChoice, 0, len(s.Rounds))
+	for _, round := range s.Rounds {
+		if botID == s.Player1ID {
+			history = append(history, round.Player2Choice)
+		} else {
+			history = append(history, round.Player1Choice)
+		}
+	}
+	return history
+}
+
+// rpsFollowers returns every choice the opponent made right after playing their latest choice
+func rpsFollowers(history []RPSChoice) []RPSChoice {
+	if len(history) == 0 {
+		return nil
+	}
+	last := history[len(history)-1]
+	var followers []RPSChoice
+	for i := 0; i < len(history)-1; i++ {
+		if history[i] == last {
+			followers = append(followers, history[i+1])
+		}
+	}
+	return followers
+}
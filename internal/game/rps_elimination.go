@@ -0,0 +1,274 @@
+package game
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RPSEliminationMinPlayers = 3
+	RPSEliminationMaxPlayers = 8
+)
+
+// RPSEliminationState is Rock-Paper-Scissors for three or more players. Every player still in
+// chooses at the same time each round. When exactly two different choices are shown, everyone
+// who picked the losing one is eliminated; when all players agree or all three choices appear,
+// the round is replayed. The last player standing wins.
+type RPSEliminationState struct {
+	PlayerIDs    []uuid.UUID             `json:"players"` // Every player in seat order
+	CurrentRound int                     `json:"current_round"`
+	Choices      map[uuid.UUID]RPSChoice `json:"choices"`          // Choices made so far this round
+	Eliminated   map[uuid.UUID]int       `json:"eliminated"`       // Player -> round they were knocked out in
+	Rounds       []RPSEliminationRound   `json:"rounds"`           // History of all rounds
+	Winner       *uuid.UUID              `json:"winner,omitempty"` // Last player standing
+}
+
+// RPSEliminationRound records the choices of one round and who they knocked out
+type RPSEliminationRound struct {
+	RoundNumber int                     `json:"round_number"`
+	Choices     map[uuid.UUID]RPSChoice `json:"choices"`
+	Eliminated  []uuid.UUID             `json:"eliminated,omitempty"` // Empty when the round is replayed
+}
+
+// rpsEliminationView is the state as seen by one viewer: pending choices of other players are
+// hidden, but everyone can see who is ready
+type rpsEliminationView struct {
+	RPSEliminationState
+	Ready []uuid.UUID `json:"ready"` // Players who have chosen this round
+}
+
+func init() {
+	Register(Definition{
+		Type:        GameTypeRPSElimination,
+		Name:        "RPS Elimination",
+		Description: "Everyone chooses at once; a losing choice knocks you out and the last player standing wins",
+		MinPlayers:  RPSEliminationMinPlayers,
+		MaxPlayers:  RPSEliminationMaxPlayers,
+		NewForPlayers: func(playerIDs []uuid.UUID, settings map[string]interface{}) GameState {
+			return NewRPSEliminationState(playerIDs)
+		},
+		Decode: decodeState[RPSEliminationState],
+		Bot:    newRPSEliminationBot,
+	})
+}
+
+// NewRPSEliminationState creates a new elimination game for the players in seat order
+func NewRPSEliminationState(playerIDs []uuid.UUID) *RPSEliminationState {
+	return &RPSEliminationState{
+		PlayerIDs:    append([]uuid.UUID{}, playerIDs...),
+		CurrentRound: 1,
+		Choices:      make(map[uuid.UUID]RPSChoice),
+		Eliminated:   make(map[uuid.UUID]int),
+		Rounds:       []RPSEliminationRound{},
+	}
+}
+
+// ValidateMove checks if a move is valid
+func (s *RPSEliminationState) ValidateMove(playerID uuid.UUID, move interface{}) error {
+	if !s.isPlayer(playerID) {
+		return ErrInvalidPlayer
+	}
+	if s.Winner != nil {
+		return ErrGameAlreadyEnded
+	}
+	if _, out := s.Eliminated[playerID]; out {
+		return errors.New("you have been eliminated")
+	}
+	if _, chosen := s.Choices[playerID]; chosen {
+		return errors.New("you have already made a choice this round")
+	}
+
+	rpsMove, err := parseRPSMove(move)
+	if err != nil {
+		return err
+	}
+	return validateRPSChoice(rpsMove.Choice)
+}
+
+// ApplyMove records a choice and resolves the round once every player still in has chosen
+func (s *RPSEliminationState) ApplyMove(playerID uuid.UUID, move interface{}) error {
+	if err := s.ValidateMove(playerID, move); err != nil {
+		return err
+	}
+
+	rpsMove, err := parseRPSMove(move)
+	if err != nil {
+		return err
+	}
+
+	s.Choices[playerID] = rpsMove.Choice
+	if len(s.Choices) == len(s.remaining()) {
+		s.resolveRound()
+	}
+	return nil
+}
+
+// resolveRound knocks out the players who chose the losing option, if there is exactly one
+func (s *RPSEliminationState) resolveRound() {
+	shown := make(map[RPSChoice]bool)
+	for _, choice := range s.Choices {
+		shown[choice] = true
+	}
+
+	round := RPSEliminationRound{RoundNumber: s.CurrentRound, Choices: s.Choices}
+	if len(shown) == 2 {
+		var losing RPSChoice
+		switch {
+		case shown[RPSChoiceRock] && shown[RPSChoicePaper]:
+			losing = RPSChoiceRock
+		case shown[RPSChoicePaper] && shown[RPSChoiceScissors]:
+			losing = RPSChoicePaper
+		default:
+			losing = RPSChoiceScissors
+		}
+		for _, playerID := range s.remaining() {
+			if s.Choices[playerID] == losing {
+				s.Eliminated[playerID] = s.CurrentRound
+				round.Eliminated = append(round.Eliminated, playerID)
+			}
+		}
+	}
+	s.Rounds = append(s.Rounds, round)
+
+	if remaining := s.remaining(); len(remaining) == 1 {
+		winner := remaining[0]
+		s.Winner = &winner
+	}
+	s.Choices = make(map[uuid.UUID]RPSChoice)
+	s.CurrentRound++
+}
+
+// remaining returns the players not yet eliminated, in seat order
+func (s *RPSEliminationState) remaining() []uuid.UUID {
+	var remaining []uuid.UUID
+	for _, playerID := range s.PlayerIDs {
+		if _, out := s.Eliminated[playerID]; !out {
+			remaining = append(remaining, playerID)
+		}
+	}
+	return remaining
+}
+
+// isPlayer reports whether a player is seated in the game
+func (s *RPSEliminationState) isPlayer(playerID uuid.UUID) bool {
+	for _, id := range s.PlayerIDs {
+		if id == playerID && id != uuid.Nil {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckWinner checks if there's a winner; the game only ends once one player is left
+func (s *RPSEliminationState) CheckWinner() (winner *uuid.UUID, gameOver bool) {
+	if s.Winner == nil {
+		return nil, false
+	}
+	return s.Winner, true
+}
+
+// LegalMoves returns the three choices while the game is undecided. Players choose
+// simultaneously, so the same choices are open to whichever player has not chosen yet.
+func (s *RPSEliminationState) LegalMoves() []interface{} {
+	moves := []interface{}{}
+	if s.Winner != nil {
+		return moves
+	}
+	for _, choice := range []RPSChoice{RPSChoiceRock, RPSChoicePaper, RPSChoiceScissors} {
+		moves = append(moves, RPSMove{Choice: choice})
+	}
+	return moves
+}
+
+// GetCurrentPlayer returns the first player in seat order who still has to choose this round
+// (so their clock runs), or the winner once the game is over
+func (s *RPSEliminationState) GetCurrentPlayer() uuid.UUID {
+	if s.Winner != nil {
+		return *s.Winner
+	}
+	for _, playerID := range s.remaining() {
+		if _, chosen := s.Choices[playerID]; !chosen {
+			return playerID
+		}
+	}
+	return uuid.Nil
+}
+
+// Players returns the seated players in turn order
+func (s *RPSEliminationState) Players() []uuid.UUID {
+	return append([]uuid.UUID{}, s.PlayerIDs...)
+}
+
+// Standings places the players still in first (tied until one is left), then the eliminated
+// players, latest knocked out first; players knocked out in the same round are tied
+func (s *RPSEliminationState) Standings() [][]uuid.UUID {
+	standings := [][]uuid.UUID{s.remaining()}
+	for round := s.CurrentRound; round >= 1; round-- {
+		var group []uuid.UUID
+		for _, playerID := range s.PlayerIDs {
+			if out, ok := s.Eliminated[playerID]; ok && out == round {
+				group = append(group, playerID)
+			}
+		}
+		if len(group) > 0 {
+			standings = append(standings, group)
+		}
+	}
+	return standings
+}
+
+// GetState returns the current game state for serialization
+func (s *RPSEliminationState) GetState() interface{} {
+	if s.Choices == nil {
+		s.Choices = make(map[uuid.UUID]RPSChoice)
+	}
+	if s.Eliminated == nil {
+		s.Eliminated = make(map[uuid.UUID]int)
+	}
+	if s.Rounds == nil {
+		s.Rounds = []RPSEliminationRound{}
+	}
+	return s
+}
+
+// StateFor hides the current round's choices from everyone but the player who made them
+func (s *RPSEliminationState) StateFor(viewerID uuid.UUID) interface{} {
+	view := rpsEliminationView{
+		RPSEliminationState: *s.GetState().(*RPSEliminationState),
+		Ready:               []uuid.UUID{},
+	}
+	view.Choices = make(map[uuid.UUID]RPSChoice)
+	for _, playerID := range s.PlayerIDs {
+		choice, chosen := s.Choices[playerID]
+		if !chosen {
+			continue
+		}
+		view.Ready = append(view.Ready, playerID)
+		if viewerID != uuid.Nil && viewerID == playerID {
+			view.Choices[playerID] = choice
+		}
+	}
+	return view
+}
+
+// Clone creates a deep copy of the game state
+func (s *RPSEliminationState) Clone() GameState {
+	newState := *s
+	newState.PlayerIDs = append([]uuid.UUID{}, s.PlayerIDs...)
+	newState.Choices = make(map[uuid.UUID]RPSChoice, len(s.Choices))
+	for playerID, choice := range s.Choices {
+		newState.Choices[playerID] = choice
+	}
+	newState.Eliminated = make(map[uuid.UUID]int, len(s.Eliminated))
+	for playerID, round := range s.Eliminated {
+		newState.Eliminated[playerID] = round
+	}
+	// Rounds are never modified once recorded, so they can be shared
+	newState.Rounds = append([]RPSEliminationRound{}, s.Rounds...)
+	if s.Winner != nil {
+		winner := *s.Winner
+		newState.Winner = &winner
+	}
+	return &newState
+}
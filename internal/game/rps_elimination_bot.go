@@ -0,0 +1,109 @@
+package game
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/google/uuid"
+)
+
+// rpsEliminationBot plays RPS Elimination. Like rpsBot it only looks at completed rounds, never
+// at the pending choices of the current round.
+type rpsEliminationBot struct {
+	rpsBot
+}
+
+func newRPSEliminationBot(difficulty BotDifficulty, rng *rand.Rand) Bot {
+	return &rpsEliminationBot{rpsBot{difficulty: difficulty, rng: rng}}
+}
+
+// ChooseMove picks a choice for the current round.
+// Easy plays uniformly at random; medium counters the most frequent choice of the players still
+// in; hard predicts each of them separately and picks the choice that beats the most of them
+// while being beaten by the fewest, mixing in random play like the two-player bot.
+func (b *rpsEliminationBot) ChooseMove(state GameState, botID uuid.UUID) (interface{}, error) {
+	s, ok := state.(*RPSEliminationState)
+	if !ok {
+		return nil, ErrInvalidMove
+	}
+	if !s.isPlayer(botID) {
+		return nil, ErrInvalidPlayer
+	}
+	if _, out := s.Eliminated[botID]; out {
+		return nil, errors.New("bot has been eliminated")
+	}
+	if _, chosen := s.Choices[botID]; chosen {
+		return nil, errors.New("bot has already chosen this round")
+	}
+
+	var opponents []uuid.UUID
+	for _, playerID := range s.remaining() {
+		if playerID != botID {
+			opponents = append(opponents, playerID)
+		}
+	}
+
+	switch {
+	case b.difficulty == BotMedium:
+		var pooled []RPSChoice
+		for _, playerID := range opponents {
+			pooled = append(pooled, rpsEliminationHistory(s, playerID)...)
+		}
+		if prediction := b.mostFrequent(pooled, nil); prediction != RPSChoiceNone {
+			return RPSMove{Choice: rpsCounter[prediction]}, nil
+		}
+	case b.difficulty == BotHard && b.rng.Intn(5) > 0:
+		if choice, ok := b.bestAgainst(s, opponents); ok {
+			return RPSMove{Choice: choice}, nil
+		}
+	}
+	return RPSMove{Choice: rpsChoices[b.rng.Intn(len(rpsChoices))]}, nil
+}
+
+// bestAgainst scores each choice against the predicted choices of the opponents: being knocked
+// out costs more than knocking someone out gains
+func (b *rpsEliminationBot) bestAgainst(s *RPSEliminationState, opponents []uuid.UUID) (RPSChoice, bool) {
+	predicted := make(map[RPSChoice]int)
+	for _, playerID := range opponents {
+		history := rpsEliminationHistory(s, playerID)
+		if prediction := b.mostFrequent(history, rpsFollowers(history)); prediction != RPSChoiceNone {
+			predicted[prediction]++
+		}
+	}
+	if len(predicted) == 0 {
+		return RPSChoiceNone, false
+	}
+
+	var best []RPSChoice
+	bestScore := 0
+	for _, choice := range rpsChoices {
+		// rpsCounter[x] beats x, so choice beats whatever it counters
+		score := 0
+		for predictedChoice, count := range predicted {
+			switch {
+			case rpsCounter[predictedChoice] == choice:
+				score += count
+			case rpsCounter[choice] == predictedChoice:
+				score -= 2 * count
+			}
+		}
+		switch {
+		case len(best) == 0 || score > bestScore:
+			best, bestScore = []RPSChoice{choice}, score
+		case score == bestScore:
+			best = append(best, choice)
+		}
+	}
+	return best[b.rng.Intn(len(best))], true
+}
+
+// rpsEliminationHistory returns a player's choices in completed rounds, oldest first
+func rpsEliminationHistory(s *RPSEliminationState, playerID uuid.UUID) []RPSChoice {
+	var history []RPSChoice
+	for _, round := range s.Rounds {
+		if choice, ok := round.Choices[playerID]; ok {
+			history = append(history, choice)
+		}
+	}
+	return history
+}
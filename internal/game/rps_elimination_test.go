@@ -0,0 +1,123 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// playRPSRound makes every listed player choose in turn
+func playRPSRound(t *testing.T, state *RPSEliminationState, choices map[uuid.UUID]RPSChoice) {
+	t.Helper()
+	for _, playerID := range state.PlayerIDs {
+		if choice, ok := choices[playerID]; ok {
+			assert.NoError(t, state.ApplyMove(playerID, RPSMove{Choice: choice}))
+		}
+	}
+}
+
+// TestRPSElimination tests the multi-player elimination variant
+func TestRPSElimination(t *testing.T) {
+	p1, p2, p3, p4 := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("Losing Choice Is Knocked Out", func(t *testing.T) {
+		state := NewRPSEliminationState([]uuid.UUID{p1, p2, p3})
+		playRPSRound(t, state, map[uuid.UUID]RPSChoice{p1: RPSChoiceRock, p2: RPSChoiceRock, p3: RPSChoiceScissors})
+
+		assert.Equal(t, 1, state.Eliminated[p3])
+		assert.Equal(t, []uuid.UUID{p3}, state.Rounds[0].Eliminated)
+		assert.Equal(t, 2, state.CurrentRound)
+		assert.Empty(t, state.Choices)
+		_, gameOver := state.CheckWinner()
+		assert.False(t, gameOver)
+
+		err := state.ValidateMove(p3, RPSMove{Choice: RPSChoiceRock})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "eliminated")
+
+		playRPSRound(t, state, map[uuid.UUID]RPSChoice{p1: RPSChoicePaper, p2: RPSChoiceRock})
+		winner, gameOver := state.CheckWinner()
+		assert.True(t, gameOver)
+		assert.Equal(t, p1, *winner)
+		assert.Equal(t, [][]uuid.UUID{{p1}, {p2}, {p3}}, state.Standings())
+		assert.Empty(t, state.LegalMoves())
+		assert.Equal(t, ErrGameAlreadyEnded, state.ValidateMove(p1, RPSMove{Choice: RPSChoiceRock}))
+	})
+
+	t.Run("Undecided Rounds Are Replayed", func(t *testing.T) {
+		state := NewRPSEliminationState([]uuid.UUID{p1, p2, p3})
+		playRPSRound(t, state, map[uuid.UUID]RPSChoice{p1: RPSChoiceRock, p2: RPSChoiceRock, p3: RPSChoiceRock})
+		playRPSRound(t, state, map[uuid.UUID]RPSChoice{p1: RPSChoiceRock, p2: RPSChoicePaper, p3: RPSChoiceScissors})
+
+		assert.Len(t, state.Rounds, 2)
+		assert.Empty(t, state.Eliminated)
+		assert.Equal(t, 3, state.CurrentRound)
+	})
+
+	t.Run("Players Knocked Out Together Tie", func(t *testing.T) {
+		state := NewRPSEliminationState([]uuid.UUID{p1, p2, p3, p4})
+		playRPSRound(t, state, map[uuid.UUID]RPSChoice{p1: RPSChoiceScissors, p2: RPSChoicePaper, p3: RPSChoicePaper, p4: RPSChoiceScissors})
+		assert.Equal(t, [][]uuid.UUID{{p1, p4}, {p2, p3}}, state.Standings(), "the players still in share first place")
+
+		playRPSRound(t, state, map[uuid.UUID]RPSChoice{p1: RPSChoiceScissors, p4: RPSChoiceRock})
+		assert.Equal(t, [][]uuid.UUID{{p4}, {p1}, {p2, p3}}, state.Standings())
+	})
+
+	t.Run("Choices In Seat Order", func(t *testing.T) {
+		state := NewRPSEliminationState([]uuid.UUID{p1, p2, p3})
+		assert.Equal(t, p1, state.GetCurrentPlayer())
+
+		assert.NoError(t, state.ApplyMove(p2, RPSMove{Choice: RPSChoiceRock}))
+		assert.Equal(t, p1, state.GetCurrentPlayer(), "players may choose before their seat comes up")
+		assert.NoError(t, state.ApplyMove(p1, RPSMove{Choice: RPSChoiceRock}))
+		assert.Equal(t, p3, state.GetCurrentPlayer())
+
+		err := state.ValidateMove(p1, RPSMove{Choice: RPSChoicePaper})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already made a choice")
+		assert.Equal(t, ErrInvalidPlayer, state.ValidateMove(uuid.New(), RPSMove{Choice: RPSChoiceRock}))
+		assert.Error(t, state.ValidateMove(p3, RPSMove{Choice: "lizard"}))
+	})
+
+	t.Run("Pending Choices Are Hidden", func(t *testing.T) {
+		state := NewRPSEliminationState([]uuid.UUID{p1, p2, p3})
+		assert.NoError(t, state.ApplyMove(p2, RPSMove{Choice: RPSChoiceScissors}))
+
+		view := state.StateFor(p2).(rpsEliminationView)
+		assert.Equal(t, RPSChoiceScissors, view.Choices[p2])
+		assert.Equal(t, []uuid.UUID{p2}, view.Ready)
+
+		for _, viewer := range []uuid.UUID{p1, uuid.Nil} {
+			data, err := json.Marshal(state.StateFor(viewer))
+			assert.NoError(t, err)
+			assert.NotContains(t, string(data), "scissors")
+		}
+		assert.Equal(t, RPSChoiceScissors, state.Choices[p2], "the state itself is not modified")
+	})
+
+	t.Run("Clone Is Independent", func(t *testing.T) {
+		state := NewRPSEliminationState([]uuid.UUID{p1, p2, p3})
+		clone := state.Clone().(*RPSEliminationState)
+		playRPSRound(t, clone, map[uuid.UUID]RPSChoice{p1: RPSChoiceRock, p2: RPSChoiceRock, p3: RPSChoiceScissors})
+
+		assert.Empty(t, state.Eliminated)
+		assert.Empty(t, state.Rounds)
+		assert.Equal(t, 1, state.CurrentRound)
+	})
+
+	t.Run("Round Trip Through The Registry", func(t *testing.T) {
+		state, err := DefaultRegistry.NewStateForPlayers(GameTypeRPSElimination, []uuid.UUID{p1, p2, p3}, nil)
+		assert.NoError(t, err)
+		playRPSRound(t, state.(*RPSEliminationState), map[uuid.UUID]RPSChoice{p1: RPSChoicePaper, p2: RPSChoiceRock, p3: RPSChoicePaper})
+
+		data, _ := json.Marshal(state.GetState())
+		decoded, err := DefaultRegistry.DecodeState(GameTypeRPSElimination, data)
+		assert.NoError(t, err)
+		assert.Equal(t, state, decoded)
+
+		_, err = DefaultRegistry.NewStateForPlayers(GameTypeTicTacToe, []uuid.UUID{p1, p2, p3}, nil)
+		assert.Error(t, err, "two-player games cannot seat three")
+	})
+}
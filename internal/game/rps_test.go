@@ -224,3 +224,109 @@ func TestRPSFullGame(t *testing.T) {
 	}
 }
 
+
+// TestRPSLegalMoves tests legal move enumeration
+func TestRPSLegalMoves(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+	state := NewRPSState(player1, player2)
+
+	assert.ElementsMatch(t, []interface{}{
+		RPSMove{Choice: RPSChoiceRock},
+		RPSMove{Choice: RPSChoicePaper},
+		RPSMove{Choice: RPSChoiceScissors},
+	}, state.LegalMoves())
+
+	for i := 0; i < state.WinsNeeded; i++ {
+		state.ApplyMove(player1, RPSMove{Choice: RPSChoiceRock})
+		state.ApplyMove(player2, RPSMove{Choice: RPSChoiceScissors})
+	}
+	assert.Empty(t, state.LegalMoves())
+}
+
+// TestRPSStateFor tests that pending choices are hidden from everyone but their owner
+func TestRPSStateFor(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+	state := NewRPSState(player1, player2)
+	assert.NoError(t, state.ApplyMove(player1, RPSMove{Choice: RPSChoicePaper}))
+
+	own := state.StateFor(player1).(rpsView)
+	assert.Equal(t, RPSChoicePaper, own.Player1Choice)
+	assert.True(t, own.Player1Ready)
+
+	for _, viewer := range []uuid.UUID{player2, uuid.Nil} {
+		view := state.StateFor(viewer).(rpsView)
+		assert.Equal(t, RPSChoiceNone, view.Player1Choice)
+		assert.True(t, view.Player1Ready)
+		assert.False(t, view.Player2Ready)
+	}
+
+	// The stored state is untouched and resolved rounds stay visible
+	assert.Equal(t, RPSChoicePaper, state.Player1Choice)
+	assert.NoError(t, state.ApplyMove(player2, RPSMove{Choice: RPSChoiceRock}))
+	view := state.StateFor(uuid.Nil).(rpsView)
+	assert.Equal(t, RPSChoicePaper, view.Rounds[0].Player1Choice)
+}
+
+// TestRPSCommitReveal tests the commit-reveal mode
+func TestRPSCommitReveal(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+	newState := func() *RPSState {
+		return NewRPSStateWithSettings(player1, player2, map[string]interface{}{"rps_commit_reveal": true})
+	}
+
+	t.Run("Commit Then Reveal", func(t *testing.T) {
+		state := newState()
+		assert.True(t, state.CommitReveal)
+
+		assert.Error(t, state.ApplyMove(player1, RPSMove{Choice: RPSChoiceRock}), "must commit first")
+		assert.Error(t, state.ApplyMove(player1, RPSMove{Commitment: "not-a-hash"}))
+
+		assert.NoError(t, state.ApplyMove(player1, RPSMove{Commitment: RPSCommitment(RPSChoiceRock, "salt-1")}))
+		assert.Equal(t, player2, state.GetCurrentPlayer())
+		assert.Error(t, state.ApplyMove(player1, RPSMove{Choice: RPSChoiceRock, Salt: "salt-1"}), "opponent not committed")
+
+		assert.NoError(t, state.ApplyMove(player2, RPSMove{Commitment: RPSCommitment(RPSChoiceScissors, "salt-2")}))
+		assert.Error(t, state.ApplyMove(player1, RPSMove{Choice: RPSChoicePaper, Salt: "salt-1"}), "does not match")
+		assert.NoError(t, state.ApplyMove(player1, RPSMove{Choice: RPSChoiceRock, Salt: "salt-1"}))
+		assert.Equal(t, player2, state.GetCurrentPlayer())
+		assert.NoError(t, state.ApplyMove(player2, RPSMove{Choice: RPSChoiceScissors, Salt: "salt-2"}))
+
+		assert.Len(t, state.Rounds, 1)
+		assert.Equal(t, player1, *state.Rounds[0].WinnerID)
+		assert.Empty(t, state.Player1Commitment)
+		assert.Empty(t, state.Player2Commitment)
+	})
+
+	t.Run("Open Play Once The Opponent Is Committed", func(t *testing.T) {
+		state := newState()
+		assert.Empty(t, state.LegalMoves())
+
+		assert.NoError(t, state.ApplyMove(player1, RPSMove{Commitment: RPSCommitment(RPSChoicePaper, "s")}))
+		assert.Len(t, state.LegalMoves(), 3)
+		assert.NoError(t, state.ApplyMove(player2, RPSMove{Choice: RPSChoiceRock}))
+		assert.Equal(t, player1, state.GetCurrentPlayer())
+
+		// Player 1 cannot see player 2's choice while their own is still hidden behind the commitment
+		view := state.StateFor(player1).(rpsView)
+		assert.Equal(t, RPSChoiceNone, view.Player2Choice)
+		assert.NotEmpty(t, view.Player1Commitment)
+
+		assert.NoError(t, state.ApplyMove(player1, RPSMove{Choice: RPSChoicePaper, Salt: "s"}))
+		assert.Equal(t, 1, state.Player1Score)
+	})
+
+	t.Run("Bot Plays Against A Commitment", func(t *testing.T) {
+		state := newState()
+		botID := BotPlayerID(BotHard)
+		state.Player2ID = botID
+		assert.NoError(t, state.ApplyMove(player1, RPSMove{Commitment: RPSCommitment(RPSChoiceRock, "s")}))
+
+		bot, _ := NewBot(GameTypeRockPaperScissors, BotHard, nil)
+		move, err := bot.ChooseMove(state.Clone(), botID)
+		assert.NoError(t, err)
+		assert.NoError(t, state.ApplyMove(botID, move))
+	})
+}
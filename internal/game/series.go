@@ -0,0 +1,124 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// SeriesStatus represents the state of a best-of-N series
+type SeriesStatus string
+
+const (
+	SeriesStatusInProgress SeriesStatus = "in_progress"
+	SeriesStatusCompleted  SeriesStatus = "completed" // A player reached the required wins, or the game limit was hit
+	SeriesStatusAbandoned  SeriesStatus = "abandoned" // A game of the series was abandoned
+)
+
+// SeriesBestOfOptions are the series lengths rooms may ask for
+var SeriesBestOfOptions = []int{3, 5, 7}
+
+// Series is a best-of-N match between two players, played as consecutive games with the seats
+// swapped each game. Every game of the series carries its own copy: the score before the game
+// while it is played, including its result once it has ended. Draws do not count towards the
+// required wins, so a series that keeps being drawn ends without a winner after MaxGames.
+type Series struct {
+	ID        uuid.UUID    `json:"id"`
+	BestOf    int          `json:"best_of"`
+	Game      int          `json:"game"`       // Number of this game in the series, from 1
+	PlayerIDs []uuid.UUID  `json:"player_ids"` // Players in their seats of the first game
+	Wins      []int        `json:"wins"`       // Games won by each of PlayerIDs
+	Draws     int          `json:"draws"`
+	WinnerID  *uuid.UUID   `json:"winner_id,omitempty"`
+	Status    SeriesStatus `json:"status"`
+}
+
+// NewSeries starts a best-of-N series between two players at its first game
+func NewSeries(bestOf int, player1ID, player2ID uuid.UUID) (*Series, error) {
+	if bestOf < 1 || bestOf%2 == 0 {
+		return nil, fmt.Errorf("a series must be played over an odd number of games")
+	}
+	return &Series{
+		ID:        uuid.New(),
+		BestOf:    bestOf,
+		Game:      1,
+		PlayerIDs: []uuid.UUID{player1ID, player2ID},
+		Wins:      []int{0, 0},
+		Status:    SeriesStatusInProgress,
+	}, nil
+}
+
+// RequiredWins returns the number of games needed to win the series
+func (s *Series) RequiredWins() int {
+	return s.BestOf/2 + 1
+}
+
+// MaxGames returns how many games are played at most before a series ends without a winner
+func (s *Series) MaxGames() int {
+	return 2 * s.BestOf
+}
+
+// Over reports whether the series has finished
+func (s *Series) Over() bool {
+	return s.Status != SeriesStatusInProgress
+}
+
+// WinsOf returns the number of games a player has won in the series
+func (s *Series) WinsOf(playerID uuid.UUID) int {
+	for i, id := range s.PlayerIDs {
+		if id == playerID && i < len(s.Wins) {
+			return s.Wins[i]
+		}
+	}
+	return 0
+}
+
+// Record adds the result of the current game (nil winnerID for a draw) and ends the series once
+// a player has the required wins or the game limit is reached
+func (s *Series) Record(winnerID *uuid.UUID) {
+	if s.Over() {
+		return
+	}
+	if winnerID == nil {
+		s.Draws++
+	}
+	for i, id := range s.PlayerIDs {
+		if winnerID != nil && id == *winnerID {
+			s.Wins[i]++
+			if s.Wins[i] >= s.RequiredWins() {
+				winner := id
+				s.WinnerID = &winner
+				s.Status = SeriesStatusCompleted
+			}
+		}
+	}
+	if s.Game >= s.MaxGames() {
+		s.Status = SeriesStatusCompleted
+	}
+}
+
+// Abandon ends the series without a winner
+func (s *Series) Abandon() {
+	if !s.Over() {
+		s.Status = SeriesStatusAbandoned
+	}
+}
+
+// Next returns the series as carried by the following game
+func (s *Series) Next() *Series {
+	next := s.Clone()
+	next.Game++
+	return next
+}
+
+// Clone creates a deep copy of the series
+func (s *Series) Clone() *Series {
+	clone := *s
+	clone.PlayerIDs = append([]uuid.UUID{}, s.PlayerIDs...)
+	clone.Wins = append([]int{}, s.Wins...)
+	if s.WinnerID != nil {
+		winner := *s.WinnerID
+		clone.WinnerID = &winner
+	}
+	return &clone
+}
@@ -0,0 +1,161 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSeries tests scoring a best-of-N series
+func TestSeries(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	t.Run("Odd Lengths Only", func(t *testing.T) {
+		_, err := NewSeries(4, player1, player2)
+		assert.Error(t, err)
+		_, err = NewSeries(0, player1, player2)
+		assert.Error(t, err)
+
+		series, err := NewSeries(5, player1, player2)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, series.RequiredWins())
+		assert.Equal(t, 1, series.Game)
+		assert.False(t, series.Over())
+	})
+
+	t.Run("First To The Required Wins", func(t *testing.T) {
+		series, _ := NewSeries(3, player1, player2)
+		series.Record(&player2)
+		series = series.Next()
+		series.Record(nil)
+		series = series.Next()
+		series.Record(&player1)
+		assert.False(t, series.Over())
+		assert.Equal(t, 1, series.WinsOf(player1))
+		assert.Equal(t, 1, series.Draws)
+
+		series = series.Next()
+		series.Record(&player2)
+		assert.True(t, series.Over())
+		assert.Equal(t, SeriesStatusCompleted, series.Status)
+		assert.Equal(t, player2, *series.WinnerID)
+		assert.Equal(t, 4, series.Game)
+
+		series.Record(&player1)
+		assert.Equal(t, 1, series.WinsOf(player1), "results after the series is over are ignored")
+	})
+
+	t.Run("Endless Draws End Without A Winner", func(t *testing.T) {
+		series, _ := NewSeries(3, player1, player2)
+		for series.Game < series.MaxGames() {
+			series.Record(nil)
+			series = series.Next()
+		}
+		assert.False(t, series.Over())
+		series.Record(nil)
+		assert.True(t, series.Over())
+		assert.Nil(t, series.WinnerID)
+		assert.Equal(t, 6, series.Draws)
+	})
+
+	t.Run("Abandoned", func(t *testing.T) {
+		series, _ := NewSeries(3, player1, player2)
+		series.Abandon()
+		assert.Equal(t, SeriesStatusAbandoned, series.Status)
+		series.Record(&player1)
+		assert.Equal(t, 0, series.WinsOf(player1))
+	})
+
+	t.Run("Each Game Keeps Its Own Score", func(t *testing.T) {
+		series, _ := NewSeries(3, player1, player2)
+		next := series.Next()
+		next.Record(&player1)
+		assert.Equal(t, 0, series.WinsOf(player1))
+		assert.Equal(t, 1, series.Game)
+		assert.Equal(t, 2, next.Game)
+	})
+}
+
+// TestGameRematch tests following a finished game with a rematch or the next game of a series
+func TestGameRematch(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+	now := time.Now()
+
+	finished := func(t *testing.T) *Game {
+		state, _ := DefaultRegistry.NewState(GameTypeConnect4, player1, player2, nil)
+		g := &Game{
+			ID: uuid.New(), Type: GameTypeConnect4, Status: GameStatusActive,
+			Player1ID: player1, Player1Name: "alice", Player2ID: player2, Player2Name: "bob",
+			State: state, Settings: map[string]interface{}{"connect4_rows": float64(8), "time_control": "per_move"},
+		}
+		g.End(GameStatusCompleted, &player1, EndReasonNormal, now)
+		return g
+	}
+
+	t.Run("Seats Are Swapped", func(t *testing.T) {
+		g := finished(t)
+		next, err := g.Rematch(now, FirstMoverContext{})
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, g.ID, next.ID)
+		assert.Equal(t, g.ID, *next.PreviousGameID)
+		assert.Equal(t, player2, next.Player1ID)
+		assert.Equal(t, "bob", next.Player1Name)
+		assert.Equal(t, []uuid.UUID{player2, player1}, next.PlayerIDs())
+		assert.Equal(t, player2, next.CurrentTurn)
+		assert.Equal(t, GameStatusActive, next.Status)
+		assert.Equal(t, 8, next.State.(*Connect4State).Rows, "settings are kept")
+		assert.NotNil(t, next.Clock)
+		assert.Nil(t, next.Series)
+	})
+
+	t.Run("Series Carries On Until Decided", func(t *testing.T) {
+		g := finished(t)
+		g.Series, _ = NewSeries(3, player1, player2)
+		g.Series.Record(&player1)
+
+		next, err := g.Rematch(now, FirstMoverContext{})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, next.Series.Game)
+
+		next.End(GameStatusCompleted, &player1, EndReasonNormal, now)
+		assert.True(t, next.Series.Over())
+		assert.Equal(t, player1, *next.Series.WinnerID)
+		assert.Equal(t, 1, g.Series.WinsOf(player1), "the earlier game keeps its score")
+
+		after, err := next.Rematch(now, FirstMoverContext{})
+		assert.NoError(t, err)
+		assert.Nil(t, after.Series, "a rematch after the series is a single game")
+	})
+
+	t.Run("Abandoned Game Abandons The Series", func(t *testing.T) {
+		state, _ := DefaultRegistry.NewState(GameTypeConnect4, player1, player2, nil)
+		g := &Game{Type: GameTypeConnect4, Status: GameStatusActive, Player1ID: player1, Player2ID: player2, State: state}
+		g.Series, _ = NewSeries(3, player1, player2)
+		g.End(GameStatusAbandoned, nil, EndReasonAborted, now)
+		assert.Equal(t, SeriesStatusAbandoned, g.Series.Status)
+	})
+
+	t.Run("Bot Keeps Its Seat", func(t *testing.T) {
+		g := finished(t)
+		botID := BotPlayerID(BotEasy)
+		g.Player2ID = botID
+		g.Bot = &BotSeat{PlayerID: botID, Difficulty: BotEasy}
+
+		next, err := g.Rematch(now, FirstMoverContext{})
+		assert.NoError(t, err)
+		assert.Equal(t, botID, next.Player1ID)
+		assert.Equal(t, botID, next.Bot.PlayerID)
+		assert.True(t, next.AwaitingMove(botID))
+	})
+
+	t.Run("Two Players Only", func(t *testing.T) {
+		g := &Game{Type: GameTypeRPSElimination, SeatCount: 3, Status: GameStatusCompleted}
+		_, err := g.Rematch(now, FirstMoverContext{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,77 @@
+package game
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrTakebacksDisabled is returned when a move is to be taken back in a game whose result counts
+var ErrTakebacksDisabled = errors.New("takebacks are only allowed in casual games")
+
+// TakebacksAllowed reports whether moves of the game may be taken back. Only casual two-player
+// games allow it; rated games (ranked and quick play rooms, rated bot games) and tournament
+// games never do.
+func (g *Game) TakebacksAllowed() bool {
+	return !g.Rated && g.TournamentID == nil && !g.IsMultiplayer()
+}
+
+// TakebackPlies returns how many of the moves still on the board must be taken back so that
+// playerID can replay their last one: that move and every move made since. It returns 0 when
+// the player has no move to take back.
+func TakebackPlies(active []MoveRecord, playerID uuid.UUID) int {
+	for i := len(active) - 1; i >= 0; i-- {
+		if active[i].PlayerID == playerID {
+			return len(active) - i
+		}
+	}
+	return 0
+}
+
+// TakeBack rewinds an active game to just before the last move of playerID still on the board,
+// rebuilding the state from the game's move log. It returns the takeback record to append to
+// the move log. The clock keeps the time already used and runs for whoever is to move.
+func (g *Game) TakeBack(playerID uuid.UUID, log []MoveRecord, now time.Time) (*MoveRecord, error) {
+	if !g.TakebacksAllowed() {
+		return nil, ErrTakebacksDisabled
+	}
+	if g.Status != GameStatusActive {
+		return nil, ErrGameNotActive
+	}
+
+	active := ActiveMoves(log)
+	plies := TakebackPlies(active, playerID)
+	if plies == 0 {
+		return nil, errors.New("you have no move to take back")
+	}
+
+	replay, err := NewReplayForPlayers(DefaultRegistry, g.Type, g.PlayerIDs(), g.Settings, active[:len(active)-plies])
+	if err != nil {
+		return nil, err
+	}
+	state, err := replay.StateAt(replay.TotalPlies())
+	if err != nil {
+		return nil, err
+	}
+	if setup, ok := state.(SetupPhase); ok && setup.InSetup() {
+		return nil, errors.New("moves made during setup cannot be taken back")
+	}
+
+	record, err := NewTakebackRecord(len(active)-plies, playerID, plies, state)
+	if err != nil {
+		return nil, err
+	}
+
+	g.State = state
+	g.MoveCount = record.Ply
+	g.CurrentTurn = state.GetCurrentPlayer()
+	g.TakebackRequestedBy = nil
+	g.DrawOfferedBy = nil
+	g.UpdatedAt = now
+	if g.Clock != nil {
+		g.Clock.Stop(now)
+		g.Clock.Start(g.CurrentTurn, now)
+	}
+	return record, nil
+}
@@ -0,0 +1,162 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// takebackGame is a casual tic-tac-toe game together with its move log
+type takebackGame struct {
+	*Game
+	log []MoveRecord
+}
+
+// newTakebackGame starts a casual tic-tac-toe game between two players
+func newTakebackGame(player1, player2 uuid.UUID) *takebackGame {
+	state, _ := DefaultRegistry.NewState(GameTypeTicTacToe, player1, player2, nil)
+	g := &Game{
+		ID: uuid.New(), Type: GameTypeTicTacToe, Status: GameStatusActive,
+		Player1ID: player1, Player2ID: player2, State: state, CurrentTurn: player1,
+	}
+	return &takebackGame{Game: g}
+}
+
+// play applies a move the way the game service does, logging it
+func (tg *takebackGame) play(t *testing.T, playerID uuid.UUID, row, col int) {
+	t.Helper()
+	move := TicTacToeMove{Row: row, Col: col}
+	require.NoError(t, tg.State.ApplyMove(playerID, move))
+	tg.MoveCount++
+	record, err := NewMoveRecord(tg.MoveCount, playerID, move, tg.State)
+	require.NoError(t, err)
+	tg.log = append(tg.log, *record)
+	tg.CurrentTurn = tg.State.GetCurrentPlayer()
+}
+
+// takeBack takes back playerID's last move, logging the takeback
+func (tg *takebackGame) takeBack(t *testing.T, playerID uuid.UUID) *MoveRecord {
+	t.Helper()
+	record, err := tg.TakeBack(playerID, tg.log, time.Now())
+	require.NoError(t, err)
+	tg.log = append(tg.log, *record)
+	return record
+}
+
+// TestTakeBack tests rewinding casual games and replaying their move logs
+func TestTakeBack(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	t.Run("Own Move Before The Reply", func(t *testing.T) {
+		tg := newTakebackGame(player1, player2)
+		tg.play(t, player1, 1, 1)
+
+		record := tg.takeBack(t, player1)
+		assert.Equal(t, 1, record.Takeback)
+		assert.Equal(t, 0, record.Ply)
+		assert.Equal(t, 0, tg.MoveCount)
+		assert.Equal(t, player1, tg.CurrentTurn)
+		assert.NoError(t, tg.State.ValidateMove(player1, TicTacToeMove{Row: 1, Col: 1}), "the square is free again")
+	})
+
+	t.Run("Reply Is Taken Back Too", func(t *testing.T) {
+		tg := newTakebackGame(player1, player2)
+		tg.play(t, player1, 0, 0)
+		tg.play(t, player2, 1, 1)
+		tg.play(t, player1, 2, 2)
+		tg.play(t, player2, 0, 2)
+
+		record := tg.takeBack(t, player1)
+		assert.Equal(t, 2, record.Takeback)
+		assert.Equal(t, 2, tg.MoveCount)
+		assert.Equal(t, player1, tg.CurrentTurn)
+		assert.Len(t, ActiveMoves(tg.log), 2)
+
+		// The game carries on from the rewound position
+		tg.play(t, player1, 2, 0)
+		assert.Equal(t, 3, tg.log[len(tg.log)-1].Ply)
+	})
+
+	t.Run("Replay Follows The Takeback", func(t *testing.T) {
+		tg := newTakebackGame(player1, player2)
+		tg.play(t, player1, 0, 0)
+		tg.play(t, player2, 1, 1)
+		tg.takeBack(t, player2)
+		tg.play(t, player2, 0, 1)
+
+		replay, err := NewReplay(DefaultRegistry, GameTypeTicTacToe, player1, player2, nil, tg.log)
+		require.NoError(t, err)
+		frames, err := replay.Frames()
+		require.NoError(t, err)
+		require.Len(t, frames, 5)
+		for _, frame := range frames {
+			assert.True(t, frame.Verified, "frame %d", frame.Ply)
+		}
+		assert.Equal(t, player2, frames[3].CurrentPlayer, "the takeback frame is the rewound position")
+
+		final, err := replay.StateAt(replay.TotalPlies())
+		require.NoError(t, err)
+		assert.Equal(t, tg.State, final)
+		rewound, err := replay.StateAt(3)
+		require.NoError(t, err)
+		afterFirst, _ := replay.StateAt(1)
+		assert.Equal(t, afterFirst, rewound)
+	})
+
+	t.Run("Nothing To Take Back", func(t *testing.T) {
+		tg := newTakebackGame(player1, player2)
+		tg.play(t, player1, 0, 0)
+		_, err := tg.TakeBack(player2, tg.log, time.Now())
+		assert.Error(t, err)
+	})
+
+	t.Run("Only In Casual Games", func(t *testing.T) {
+		tg := newTakebackGame(player1, player2)
+		tg.play(t, player1, 0, 0)
+		tg.Rated = true
+		_, err := tg.TakeBack(player1, tg.log, time.Now())
+		assert.ErrorIs(t, err, ErrTakebacksDisabled)
+
+		tournamentID := uuid.New()
+		tg.Rated = false
+		tg.TournamentID = &tournamentID
+		assert.False(t, tg.TakebacksAllowed())
+		assert.False(t, (&Game{SeatCount: 3}).TakebacksAllowed())
+	})
+
+	t.Run("Clock Runs For The Player To Move", func(t *testing.T) {
+		tg := newTakebackGame(player1, player2)
+		now := time.Now()
+		tg.Clock = NewGameClock(TimeControl{Type: TimeControlTotal, TotalSeconds: 60}, player1, player2)
+		tg.Clock.Start(player1, now)
+		tg.play(t, player1, 0, 0)
+		tg.Clock.Switch(player2, now.Add(5*time.Second))
+
+		_, err := tg.TakeBack(player1, tg.log, now.Add(7*time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, player1, tg.Clock.Turn)
+		assert.Equal(t, int64(55000), tg.Clock.RemainingMs[player1], "time already used is not refunded")
+		assert.Equal(t, int64(58000), tg.Clock.RemainingMs[player2])
+	})
+}
+
+// TestActiveMoves tests resolving takebacks in a move log
+func TestActiveMoves(t *testing.T) {
+	player := uuid.New()
+	log := []MoveRecord{
+		{Ply: 1, PlayerID: player},
+		{Ply: 2, PlayerID: player},
+		{Ply: 1, PlayerID: player, Takeback: 1},
+		{Ply: 2, PlayerID: player},
+		{Ply: 3, PlayerID: player},
+		{Ply: 0, PlayerID: player, Takeback: 5},
+	}
+	assert.Len(t, ActiveMoves(log[:2]), 2)
+	active := ActiveMoves(log[:5])
+	assert.Equal(t, []int{1, 2, 3}, []int{active[0].Ply, active[1].Ply, active[2].Ply})
+	assert.Empty(t, ActiveMoves(log), "taking back more than was played clears the board")
+}
@@ -3,7 +3,6 @@ package game
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 
 	"github.com/google/uuid"
 )
@@ -15,8 +14,35 @@ type TicTacToeState struct {
 	Player2ID     uuid.UUID  `json:"player2_id"`
 	CurrentPlayer uuid.UUID  `json:"current_player"`
 	MoveCount     int        `json:"move_count"`
-	GridSize      int        `json:"grid_size"`  // Size of the grid (3, 4, or 5)
-	WinLength     int        `json:"win_length"` // Number in a row to win
+	GridSize      int        `json:"grid_size"`            // Size of the grid (3, 4, or 5)
+	WinLength     int        `json:"win_length"`           // Number in a row to win
+	PositionHash  uint64     `json:"position_hash,string"` // Zobrist hash of the board and side to move
+}
+
+func init() {
+	Register(Definition{
+		Type:        GameTypeTicTacToe,
+		Name:        "Tic-Tac-Toe",
+		Description: "Get a line of marks on a square grid before your opponent",
+		MinPlayers:  2,
+		MaxPlayers:  2,
+		Settings: []SettingSchema{
+			{Key: "tictactoe_grid_size", Label: "Grid size", Type: SettingTypeInt, Default: 3, Min: intPtr(3), Max: intPtr(5)},
+			{Key: "tictactoe_win_length", Label: "Win length", Description: "How many in a row to win", Type: SettingTypeInt, Default: 3, Min: intPtr(3), Max: intPtr(5)},
+		},
+		New: func(player1ID, player2ID uuid.UUID, settings map[string]interface{}) GameState {
+			return NewTicTacToeStateWithSettings(player1ID, player2ID, settings)
+		},
+		Validate: func(settings map[string]interface{}) {
+			gridSize, _ := IntSetting(settings, "tictactoe_grid_size")
+			winLength, _ := IntSetting(settings, "tictactoe_win_length")
+			if winLength > gridSize {
+				settings["tictactoe_win_length"] = gridSize
+			}
+		},
+		Decode: decodeState[TicTacToeState],
+		Bot:    newTicTacToeBot,
+	})
 }
 
 // TicTacToeMove represents a move in Tic-Tac-Toe
@@ -32,29 +58,18 @@ func NewTicTacToeState(player1ID, player2ID uuid.UUID) *TicTacToeState {
 
 // NewTicTacToeStateWithSettings creates a new Tic-Tac-Toe game state with custom settings
 func NewTicTacToeStateWithSettings(player1ID, player2ID uuid.UUID, settings interface{}) *TicTacToeState {
-	gridSize := 3     // default
-	winLength := 3    // default
-	
-	fmt.Printf("NewTicTacToeStateWithSettings called with settings: %+v\n", settings)
-	
+	gridSize := 3  // default
+	winLength := 3 // default
+
 	if settingsMap, ok := settings.(map[string]interface{}); ok {
-		fmt.Printf("Settings is a map: %+v\n", settingsMap)
-		if val, exists := settingsMap["tictactoe_grid_size"]; exists {
-			fmt.Printf("Found tictactoe_grid_size: %+v (type: %T)\n", val, val)
-			if sizeFloat, ok := val.(float64); ok {
-				gridSize = int(sizeFloat)
-				fmt.Printf("Set gridSize to: %d\n", gridSize)
-			}
+		if val, exists := IntSetting(settingsMap, "tictactoe_grid_size"); exists {
+			gridSize = val
 		}
-		if val, exists := settingsMap["tictactoe_win_length"]; exists {
-			if lengthFloat, ok := val.(float64); ok {
-				winLength = int(lengthFloat)
-			}
+		if val, exists := IntSetting(settingsMap, "tictactoe_win_length"); exists {
+			winLength = val
 		}
-	} else {
-		fmt.Printf("Settings is NOT a map, type is: %T\n", settings)
 	}
-	
+
 	return NewTicTacToeStateWithSize(player1ID, player2ID, gridSize, winLength)
 }
 
@@ -79,17 +94,17 @@ func NewTicTacToeStateWithSize(player1ID, player2ID uuid.UUID, gridSize, winLeng
 		board[i] = make([]string, gridSize)
 	}
 	
-	fmt.Printf("Creating TicTacToe game with gridSize=%d, winLength=%d\n", gridSize, winLength)
-	
-	return &TicTacToeState{
+	state := &TicTacToeState{
 		Board:         board,
 		Player1ID:     player1ID,
 		Player2ID:     player2ID,
-		CurrentPlayer: player1ID, // Player 1 always starts
+		CurrentPlayer: player1ID, // The first mover is seated as player 1, see FirstMoverPolicy
 		MoveCount:     0,
 		GridSize:      gridSize,
 		WinLength:     winLength,
 	}
+	state.PositionHash = state.hashPosition()
+	return state
 }
 
 // ValidateMove checks if a move is valid
@@ -98,6 +113,9 @@ func (s *TicTacToeState) ValidateMove(playerID uuid.UUID, move interface{}) erro
 	if playerID != s.CurrentPlayer {
 		return ErrNotYourTurn
 	}
+	if _, gameOver := s.CheckWinner(); gameOver {
+		return ErrGameAlreadyEnded
+	}
 
 	// Parse move
 	ticTacToeMove, err := parseTicTacToeMove(move)
@@ -147,10 +165,24 @@ func (s *TicTacToeState) ApplyMove(playerID uuid.UUID, move interface{}) error {
 	} else {
 		s.CurrentPlayer = s.Player1ID
 	}
+	s.PositionHash = s.hashPosition()
 
 	return nil
 }
 
+// Fingerprint returns the Zobrist hash of the position, see Fingerprinter
+func (s *TicTacToeState) Fingerprint() uint64 {
+	if s.PositionHash == 0 {
+		return s.hashPosition() // Saved before position hashes were stored
+	}
+	return s.PositionHash
+}
+
+// hashPosition computes the Zobrist hash of the board and the side to move
+func (s *TicTacToeState) hashPosition() uint64 {
+	return zobristBoard(zobristSalt(GameTypeTicTacToe, s.GridSize, s.WinLength), s.Board, sideToMove(s.CurrentPlayer, s.Player1ID))
+}
+
 // CheckWinner checks if there's a winner or if the game is a draw
 func (s *TicTacToeState) CheckWinner() (winner *uuid.UUID, gameOver bool) {
 	// Check rows
@@ -256,11 +288,32 @@ func (s *TicTacToeState) checkLineFromPoint(startRow, startCol, dRow, dCol int)
 	return true
 }
 
+// LegalMoves returns every empty square, row by row
+func (s *TicTacToeState) LegalMoves() []interface{} {
+	moves := []interface{}{}
+	if _, gameOver := s.CheckWinner(); gameOver {
+		return moves
+	}
+	for row := 0; row < s.GridSize; row++ {
+		for col := 0; col < s.GridSize; col++ {
+			if s.Board[row][col] == "" {
+				moves = append(moves, TicTacToeMove{Row: row, Col: col})
+			}
+		}
+	}
+	return moves
+}
+
 // GetCurrentPlayer returns the ID of the player whose turn it is
 func (s *TicTacToeState) GetCurrentPlayer() uuid.UUID {
 	return s.CurrentPlayer
 }
 
+// SetPlayer2 seats the second player when they join a waiting game
+func (s *TicTacToeState) SetPlayer2(playerID uuid.UUID) {
+	s.Player2ID = playerID
+}
+
 // GetState returns the current game state for serialization
 func (s *TicTacToeState) GetState() interface{} {
 	// Defensive: Ensure board is never nil
@@ -273,6 +326,11 @@ func (s *TicTacToeState) GetState() interface{} {
 	return s
 }
 
+// StateFor returns the full state: Tic-Tac-Toe has no hidden information
+func (s *TicTacToeState) StateFor(viewerID uuid.UUID) interface{} {
+	return s.GetState()
+}
+
 // Clone creates a deep copy of the game state
 func (s *TicTacToeState) Clone() GameState {
 	newState := *s
@@ -0,0 +1,88 @@
+package game
+
+import (
+	"math/rand"
+
+	"github.com/google/uuid"
+)
+
+// ticTacToeBot plays Tic-Tac-Toe with an alpha-beta search on any grid size and win length
+type ticTacToeBot struct {
+	difficulty BotDifficulty
+	rng        *rand.Rand
+}
+
+func newTicTacToeBot(difficulty BotDifficulty, rng *rand.Rand) Bot {
+	return &ticTacToeBot{difficulty: difficulty, rng: rng}
+}
+
+var ticTacToeSearch = searchSpec{
+	moves: func(state GameState) []interface{} {
+		return ticTacToeMoves(state.(*TicTacToeState))
+	},
+	evaluate: func(state GameState, botID uuid.UUID) int {
+		s := state.(*TicTacToeState)
+		mine, theirs := "X", "O"
+		if botID == s.Player2ID {
+			mine, theirs = "O", "X"
+		}
+		return scoreLines(s.Board, s.WinLength, mine, theirs)
+	},
+}
+
+// ChooseMove picks a square for the bot
+func (b *ticTacToeBot) ChooseMove(state GameState, botID uuid.UUID) (interface{}, error) {
+	s, ok := state.(*TicTacToeState)
+	if !ok {
+		return nil, ErrInvalidMove
+	}
+	if s.CurrentPlayer != botID {
+		return nil, ErrNotYourTurn
+	}
+
+	switch b.difficulty {
+	case BotEasy:
+		return randomOrWinningMove(s, botID, ticTacToeMoves(s), b.rng)
+	case BotMedium:
+		return searchBestMove(s, botID, ticTacToeSearch, 2, b.rng)
+	default:
+		// The classic 3x3 board is small enough to solve outright
+		depth := 4
+		if s.GridSize == 3 {
+			depth = s.GridSize * s.GridSize
+		}
+		return searchBestMove(s, botID, ticTacToeSearch, depth, b.rng)
+	}
+}
+
+// ticTacToeMoves lists the empty squares, centre first so the search prunes earlier
+func ticTacToeMoves(s *TicTacToeState) []interface{} {
+	moves := make([]interface{}, 0, s.GridSize*s.GridSize-s.MoveCount)
+	center := float64(s.GridSize-1) / 2
+	for _, cell := range cellsByDistance(s.GridSize, s.GridSize, center, center) {
+		if s.Board[cell[0]][cell[1]] == "" {
+			moves = append(moves, TicTacToeMove{Row: cell[0], Col: cell[1]})
+		}
+	}
+	return moves
+}
+
+// cellsByDistance returns every cell of a rows x cols grid ordered by distance from (centerRow, centerCol)
+func cellsByDistance(rows, cols int, centerRow, centerCol float64) [][2]int {
+	cells := make([][2]int, 0, rows*cols)
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			cells = append(cells, [2]int{row, col})
+		}
+	}
+	distance := func(cell [2]int) float64 {
+		dr, dc := float64(cell[0])-centerRow, float64(cell[1])-centerCol
+		return dr*dr + dc*dc
+	}
+	for i := 1; i < len(cells); i++ {
+		for j := i; j > 0 && distance(cells[j]) < distance(cells[j-1]); j-- {
+			cells[j], cells[j-1] = cells[j-1], cells[j]
+		}
+	}
+	return cells
+}
@@ -247,3 +247,27 @@ func TestTicTacToeClone(t *testing.T) {
 	assert.Equal(t, "O", clonedState.Board[1][1])
 }
 
+
+// TestTicTacToeLegalMoves tests legal move enumeration
+func TestTicTacToeLegalMoves(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	t.Run("Empty Squares Only", func(t *testing.T) {
+		state := NewTicTacToeState(player1, player2)
+		assert.Len(t, state.LegalMoves(), 9)
+
+		state.ApplyMove(player1, TicTacToeMove{Row: 1, Col: 1})
+		moves := state.LegalMoves()
+		assert.Len(t, moves, 8)
+		assert.NotContains(t, moves, TicTacToeMove{Row: 1, Col: 1})
+		assert.Equal(t, TicTacToeMove{Row: 0, Col: 0}, moves[0])
+	})
+
+	t.Run("None After A Win", func(t *testing.T) {
+		state := NewTicTacToeState(player1, player2)
+		state.Board = [][]string{{"X", "X", "X"}, {"O", "O", ""}, {"", "", ""}}
+		state.MoveCount = 5
+		assert.Empty(t, state.LegalMoves())
+	})
+}
@@ -16,6 +16,11 @@ const (
 	GameTypeConnect4       GameType = "connect4"
 	GameTypeRockPaperScissors GameType = "rps"
 	GameTypeDotsAndBoxes   GameType = "dotsandboxes"
+	GameTypeGomoku         GameType = "gomoku"
+	GameTypeCheckers       GameType = "checkers"
+	GameTypeOthello        GameType = "othello"
+	GameTypeBattleship     GameType = "battleship"
+	GameTypeRPSElimination GameType = "rps_elimination"
 )
 
 // GameStatus represents the current status of a game
@@ -23,9 +28,28 @@ type GameStatus string
 
 const (
 	GameStatusWaiting   GameStatus = "waiting"
+	GameStatusSetup     GameStatus = "setup" // Both players seated and setting up in secret (see SetupPhase); the clock does not run
 	GameStatusActive    GameStatus = "active"
 	GameStatusCompleted GameStatus = "completed"
 	GameStatusAbandoned GameStatus = "abandoned"
+	GameStatusPaused    GameStatus = "paused" // Suspended until resumed (see Pause); no moves are accepted and the clock is stopped
+)
+
+// InProgress reports whether a game has started and not yet finished
+func (s GameStatus) InProgress() bool {
+	return s == GameStatusSetup || s == GameStatusActive
+}
+
+// EndReason records how a finished game ended
+type EndReason string
+
+const (
+	EndReasonNormal      EndReason = "normal"      // Terminal position reached (CheckWinner)
+	EndReasonResignation EndReason = "resignation" // A player resigned
+	EndReasonDrawAgreed  EndReason = "draw_agreed" // Players agreed to a draw
+	EndReasonTimeout     EndReason = "timeout"     // A player's clock ran out
+	EndReasonAborted     EndReason = "aborted"     // Aborted before the first move
+	EndReasonDisconnect  EndReason = "disconnect"  // A player did not reconnect within the grace period
 )
 
 // Game represents a game instance
@@ -33,15 +57,38 @@ type Game struct {
 	ID              uuid.UUID       `json:"id"`
 	Type            GameType        `json:"type"`
 	Status          GameStatus      `json:"status"`
-	Player1ID       uuid.UUID       `json:"player1_id"`
-	Player2ID       uuid.UUID       `json:"player2_id"`
+	Player1ID       uuid.UUID       `json:"player1_id"` // Seat 0
+	Player2ID       uuid.UUID       `json:"player2_id"` // Seat 1
 	Player1Name     string          `json:"player1_name"`
 	Player2Name     string          `json:"player2_name"`
+	Seats           []Seat          `json:"seats,omitempty"`      // Every player in seat order, see Seat
+	SeatCount       int             `json:"seat_count,omitempty"` // Players needed to start; 0 means two
 	CurrentTurn     uuid.UUID       `json:"current_turn"`
+	FirstPlayerID   *uuid.UUID      `json:"first_player_id,omitempty"` // Player who moved first in a two-player game
 	WinnerID        *uuid.UUID      `json:"winner_id,omitempty"`
+	Placements      [][]uuid.UUID   `json:"placements,omitempty"` // Final standings of a multiplayer game, best first; players in one group tied
 	State           GameState       `json:"-"` // Excluded from JSON
 	StateData       json.RawMessage `json:"state"` // Raw JSON for serialization
+	StateFingerprint string         `json:"state_fingerprint,omitempty"` // Fingerprint of the state when it was saved, checked on restore
+	Settings        map[string]interface{} `json:"settings,omitempty"` // Validated settings the state was created with
 	Spectators      []Spectator     `json:"spectators,omitempty"` // Users watching the game
+	MoveCount       int             `json:"move_count"`           // Number of accepted moves (ply of the last move)
+	Version         int64           `json:"version"`              // Incremented on every save, used for optimistic concurrency
+	Clock           *GameClock      `json:"clock,omitempty"`      // Nil when the game has no time control
+	DrawOfferedBy   *uuid.UUID      `json:"draw_offered_by,omitempty"` // Player with a pending draw offer
+	TakebackRequestedBy *uuid.UUID  `json:"takeback_requested_by,omitempty"` // Player asking to take back their last move
+	PauseRequestedBy *uuid.UUID     `json:"pause_requested_by,omitempty"`  // Player asking to pause the game
+	ResumeRequestedBy *uuid.UUID    `json:"resume_requested_by,omitempty"` // Player asking to resume the paused game
+	Paused          *Pause          `json:"paused,omitempty"`              // Set while the game is paused
+	RematchOfferedBy *uuid.UUID     `json:"rematch_offered_by,omitempty"` // Player with a pending rematch offer once the game is over
+	NextGameID      *uuid.UUID      `json:"next_game_id,omitempty"`     // The rematch or next series game that followed this one
+	PreviousGameID  *uuid.UUID      `json:"previous_game_id,omitempty"` // The game this one is a rematch or next series game of
+	Series          *Series         `json:"series,omitempty"`           // Best-of-N series the game belongs to
+	EndReason       EndReason       `json:"end_reason,omitempty"`
+	RoomID          *uuid.UUID      `json:"room_id,omitempty"` // Room the game was started from
+	Rated           bool            `json:"rated"`             // Rated games are forfeited on disconnect; unrated ones are abandoned
+	Disconnected    map[uuid.UUID]time.Time `json:"disconnected,omitempty"` // Player -> when their last connection dropped
+	Bot             *BotSeat        `json:"bot,omitempty"`     // Set when a bot occupies one of the seats
 	CreatedAt       time.Time       `json:"created_at"`
 	UpdatedAt       time.Time       `json:"updated_at"`
 	StartedAt       *time.Time      `json:"started_at,omitempty"`
@@ -52,6 +99,369 @@ type Game struct {
 	TournamentMatch *uuid.UUID      `json:"tournament_match_id,omitempty"` // Bracket match ID
 }
 
+// Seat is a player's place in a game. Seats are filled in join order and turns follow seat order.
+// Seats 0 and 1 are mirrored in Player1ID and Player2ID, which is all two-player clients need.
+type Seat struct {
+	Index    int       `json:"index"`
+	PlayerID uuid.UUID `json:"player_id"`
+	Name     string    `json:"name"`
+}
+
+// NumSeats returns how many players the game needs to start
+func (g *Game) NumSeats() int {
+	if g.SeatCount < 2 {
+		return 2
+	}
+	return g.SeatCount
+}
+
+// IsMultiplayer reports whether the game seats more than two players
+func (g *Game) IsMultiplayer() bool {
+	return g.NumSeats() > 2
+}
+
+// PlayerIDs returns the seated players in seat order. Games saved before seats were recorded
+// fall back to Player1ID and Player2ID.
+func (g *Game) PlayerIDs() []uuid.UUID {
+	if len(g.Seats) > 0 {
+		ids := make([]uuid.UUID, len(g.Seats))
+		for i, seat := range g.Seats {
+			ids[i] = seat.PlayerID
+		}
+		return ids
+	}
+
+	var ids []uuid.UUID
+	for _, id := range []uuid.UUID{g.Player1ID, g.Player2ID} {
+		if id != uuid.Nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// SeatOf returns the seat index of a player, or -1 if they are not seated
+func (g *Game) SeatOf(playerID uuid.UUID) int {
+	if playerID == uuid.Nil {
+		return -1
+	}
+	for i, id := range g.PlayerIDs() {
+		if id == playerID {
+			return i
+		}
+	}
+	return -1
+}
+
+// Full reports whether every seat is taken
+func (g *Game) Full() bool {
+	return len(g.PlayerIDs()) >= g.NumSeats()
+}
+
+// AddPlayer seats a player in the next free seat, keeping Player1ID/Player2ID in sync
+func (g *Game) AddPlayer(playerID uuid.UUID, name string) error {
+	if g.SeatOf(playerID) >= 0 {
+		return errors.New("player is already seated in this game")
+	}
+	if g.Full() {
+		return errors.New("game is full")
+	}
+	if len(g.Seats) == 0 {
+		// Seat whoever joined before seats were recorded
+		for i, id := range g.PlayerIDs() {
+			seatName := g.Player1Name
+			if i == 1 {
+				seatName = g.Player2Name
+			}
+			g.Seats = append(g.Seats, Seat{Index: i, PlayerID: id, Name: seatName})
+		}
+	}
+
+	seat := Seat{Index: len(g.Seats), PlayerID: playerID, Name: name}
+	g.Seats = append(g.Seats, seat)
+	switch seat.Index {
+	case 0:
+		g.Player1ID, g.Player1Name = playerID, name
+	case 1:
+		g.Player2ID, g.Player2Name = playerID, name
+	}
+	return nil
+}
+
+// IsPlayer reports whether a user is one of the game's players
+func (g *Game) IsPlayer(userID uuid.UUID) bool {
+	return g.SeatOf(userID) >= 0
+}
+
+// End finishes the game, stopping the clock and clearing any pending draw offer, takeback, pause
+// or resume request. winnerID is nil for draws and aborted games. Completed multiplayer games also
+// record their final placements from the state's standings unless the caller already set them,
+// and a game of a series adds its result to the series score (an abandoned game abandons the series).
+func (g *Game) End(status GameStatus, winnerID *uuid.UUID, reason EndReason, now time.Time) {
+	g.Status = status
+	g.WinnerID = winnerID
+	if status == GameStatusCompleted && g.Placements == nil && g.IsMultiplayer() {
+		if multiplayer, ok := g.State.(MultiplayerState); ok {
+			g.Placements = multiplayer.Standings()
+		}
+	}
+	if g.Series != nil {
+		switch status {
+		case GameStatusCompleted:
+			g.Series.Record(winnerID)
+		case GameStatusAbandoned:
+			g.Series.Abandon()
+		}
+	}
+	g.EndReason = reason
+	g.EndedAt = &now
+	g.UpdatedAt = now
+	g.DrawOfferedBy = nil
+	g.TakebackRequestedBy = nil
+	g.PauseRequestedBy = nil
+	g.ResumeRequestedBy = nil
+	if g.Clock != nil {
+		g.Clock.Stop(now)
+	}
+}
+
+// Begin starts a game once both players are seated. Games whose state opens with a setup phase
+// start in GameStatusSetup; the caller starts the clock only once the game is active.
+func (g *Game) Begin(now time.Time) {
+	g.Status = GameStatusActive
+	if setup, ok := g.State.(SetupPhase); ok && setup.InSetup() {
+		g.Status = GameStatusSetup
+	}
+	g.StartedAt = &now
+	g.UpdatedAt = now
+}
+
+// FinishSetup moves a game in setup to active once its state has left the setup phase, and
+// reports whether it did
+func (g *Game) FinishSetup() bool {
+	if g.Status != GameStatusSetup {
+		return false
+	}
+	if setup, ok := g.State.(SetupPhase); ok && setup.InSetup() {
+		return false
+	}
+	g.Status = GameStatusActive
+	return true
+}
+
+// AwaitingMove reports whether the game is waiting for a move from playerID: their turn while
+// active, or their unfinished setup during the setup phase
+func (g *Game) AwaitingMove(playerID uuid.UUID) bool {
+	switch g.Status {
+	case GameStatusActive:
+		return g.State != nil && g.State.GetCurrentPlayer() == playerID
+	case GameStatusSetup:
+		setup, ok := g.State.(SetupPhase)
+		return ok && setup.InSetup() && !setup.SetupComplete(playerID)
+	}
+	return false
+}
+
+// LegalMoves returns the moves open to the player to move, or nil when the game is not in progress
+func (g *Game) LegalMoves() []interface{} {
+	if g.Status != GameStatusActive || g.State == nil {
+		return nil
+	}
+	return g.State.LegalMoves()
+}
+
+// ViewFor returns a copy of the game whose serialized state is the one viewerID may see.
+// Anyone who is not a player gets the spectator view. The state fingerprint is left out of
+// every view: it hashes the whole state, hidden information included, so it could be used to
+// work out what the view hides.
+func (g *Game) ViewFor(viewerID uuid.UUID) *Game {
+	view := *g
+	view.StateFingerprint = ""
+	if g.State == nil {
+		return &view
+	}
+	if !g.IsPlayer(viewerID) {
+		viewerID = uuid.Nil
+	}
+	view.StateData = nil
+	if data, err := json.Marshal(g.State.StateFor(viewerID)); err == nil {
+		view.StateData = data
+	}
+	return &view
+}
+
+// SeatFirstMover gives the first move of a two-player game that has not begun to firstID and
+// records who moved first. Engines start with seat 0, so when firstID is seated second the seats
+// are swapped and the state is created again for the new order.
+func (g *Game) SeatFirstMover(firstID uuid.UUID) error {
+	if g.IsMultiplayer() || firstID == uuid.Nil || (firstID != g.Player1ID && firstID != g.Player2ID) {
+		return errors.New("the first mover must be one of the two players")
+	}
+	if firstID == g.Player2ID {
+		state, err := DefaultRegistry.NewState(g.Type, g.Player2ID, g.Player1ID, g.Settings)
+		if err != nil {
+			return err
+		}
+		g.State = state
+		g.Player1ID, g.Player2ID = g.Player2ID, g.Player1ID
+		g.Player1Name, g.Player2Name = g.Player2Name, g.Player1Name
+		g.Seats = []Seat{
+			{Index: 0, PlayerID: g.Player1ID, Name: g.Player1Name},
+			{Index: 1, PlayerID: g.Player2ID, Name: g.Player2Name},
+		}
+	}
+	g.CurrentTurn = g.State.GetCurrentPlayer()
+	first := firstID
+	g.FirstPlayerID = &first
+	return nil
+}
+
+// FirstMover returns the player who moved first, falling back to seat 0 for games saved before
+// it was recorded
+func (g *Game) FirstMover() uuid.UUID {
+	if g.FirstPlayerID != nil {
+		return *g.FirstPlayerID
+	}
+	return g.Player1ID
+}
+
+// Rematch returns a new game between the same two players, with the first move given by the
+// game's first-mover policy (by default the player who moved second moves first). It keeps the
+// settings, room, rating and bot of the game, and carries on its series unless the series is
+// over. The new game has already begun; the caller saves it.
+func (g *Game) Rematch(now time.Time, firstMover FirstMoverContext) (*Game, error) {
+	if g.IsMultiplayer() {
+		return nil, errors.New("rematches can only be played in two-player games")
+	}
+
+	settings, err := DefaultRegistry.ValidateSettings(g.Type, g.Settings)
+	if err != nil {
+		return nil, err
+	}
+	state, err := DefaultRegistry.NewState(g.Type, g.Player1ID, g.Player2ID, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	previousID := g.ID
+	next := &Game{
+		ID:          uuid.New(),
+		Type:        g.Type,
+		Player1ID:   g.Player1ID,
+		Player1Name: g.Player1Name,
+		Player2ID:   g.Player2ID,
+		Player2Name: g.Player2Name,
+		Seats: []Seat{
+			{Index: 0, PlayerID: g.Player1ID, Name: g.Player1Name},
+			{Index: 1, PlayerID: g.Player2ID, Name: g.Player2Name},
+		},
+		State:          state,
+		Settings:       settings,
+		Spectators:     []Spectator{},
+		RoomID:         g.RoomID,
+		Rated:          g.Rated,
+		PreviousGameID: &previousID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if g.Bot != nil {
+		bot := *g.Bot
+		next.Bot = &bot
+	}
+	if g.Series != nil && !g.Series.Over() {
+		next.Series = g.Series.Next()
+	}
+
+	previousFirst := g.FirstMover()
+	firstMover.PreviousFirst = &previousFirst
+	if err := next.SeatFirstMover(ChooseFirstMover(FirstMoverFromSettings(settings), g.Player1ID, g.Player2ID, firstMover)); err != nil {
+		return nil, err
+	}
+
+	next.Begin(now)
+	if next.Clock = NewGameClock(TimeControlFromSettings(settings), next.Player1ID, next.Player2ID); next.Clock != nil && next.Status == GameStatusActive {
+		next.Clock.Start(next.CurrentTurn, now)
+	}
+	return next, nil
+}
+
+// Opponent returns the other player in a two-player game
+func (g *Game) Opponent(playerID uuid.UUID) uuid.UUID {
+	if playerID == g.Player1ID {
+		return g.Player2ID
+	}
+	return g.Player1ID
+}
+
+// Forfeit ends a game lost by playerID (resignation, timeout or disconnect). In a two-player
+// game the opponent wins; in a multiplayer game the player finishes last and everyone else is
+// placed by the current standings.
+func (g *Game) Forfeit(playerID uuid.UUID, reason EndReason, now time.Time) {
+	if !g.IsMultiplayer() {
+		winnerID := g.Opponent(playerID)
+		g.End(GameStatusCompleted, &winnerID, reason, now)
+		return
+	}
+
+	standings := [][]uuid.UUID{g.PlayerIDs()}
+	if multiplayer, ok := g.State.(MultiplayerState); ok {
+		standings = multiplayer.Standings()
+	}
+	var placements [][]uuid.UUID
+	for _, group := range standings {
+		var rest []uuid.UUID
+		for _, id := range group {
+			if id != playerID {
+				rest = append(rest, id)
+			}
+		}
+		if len(rest) > 0 {
+			placements = append(placements, rest)
+		}
+	}
+	placements = append(placements, []uuid.UUID{playerID})
+	g.Placements = placements
+
+	var winnerID *uuid.UUID
+	if len(placements[0]) == 1 && placements[0][0] != playerID {
+		winner := placements[0][0]
+		winnerID = &winner
+	}
+	g.End(GameStatusCompleted, winnerID, reason, now)
+}
+
+// FinalStandings returns the players of a completed game grouped by finishing position, best
+// first: the recorded placements of a multiplayer game, otherwise the winner ahead of the loser
+// (or both together for a draw). It is nil while the game is not completed.
+func (g *Game) FinalStandings() [][]uuid.UUID {
+	if g.Status != GameStatusCompleted {
+		return nil
+	}
+	if len(g.Placements) > 0 {
+		return g.Placements
+	}
+	if g.WinnerID == nil {
+		return [][]uuid.UUID{g.PlayerIDs()}
+	}
+	return [][]uuid.UUID{{*g.WinnerID}, {g.Opponent(*g.WinnerID)}}
+}
+
+// PlacementOf returns a player's finishing position in a completed game (1 for first). Tied
+// players share a position and the next one is skipped, so two players tied for first are
+// followed by third. It returns 0 when the player has no placement.
+func (g *Game) PlacementOf(playerID uuid.UUID) int {
+	position := 1
+	for _, group := range g.FinalStandings() {
+		for _, id := range group {
+			if id == playerID {
+				return position
+			}
+		}
+		position += len(group)
+	}
+	return 0
+}
+
 // Spectator represents a user watching a game
 type Spectator struct {
 	UserID   uuid.UUID `json:"user_id"`
@@ -70,16 +480,46 @@ type GameState interface {
 	// CheckWinner checks if there's a winner
 	CheckWinner() (winner *uuid.UUID, gameOver bool)
 
-	// GetCurrentPlayer returns the ID of the player whose turn it is
+	// GetCurrentPlayer returns the ID of the player whose turn it is. While the game is in
+	// progress this player must have a legal move: engines where a player can be left without
+	// one pass their turn inside ApplyMove (see OthelloState).
 	GetCurrentPlayer() uuid.UUID
 
+	// LegalMoves returns every move the player to move may make (empty once the game is over)
+	LegalMoves() []interface{}
+
 	// GetState returns the current game state for serialization
 	GetState() interface{}
 
+	// StateFor returns the state as viewerID may see it, without information hidden from them.
+	// Players pass their own ID; spectators pass uuid.Nil.
+	StateFor(viewerID uuid.UUID) interface{}
+
 	// Clone creates a deep copy of the game state
 	Clone() GameState
 }
 
+// SetupPhase is implemented by game states that open with a setup phase, in which both players
+// make their setup moves (e.g. placing ships) at the same time and in secret before play starts.
+// Setup moves go through ValidateMove/ApplyMove like any other move, from either player.
+type SetupPhase interface {
+	// InSetup reports whether the setup phase is still running
+	InSetup() bool
+
+	// SetupComplete reports whether a player has finished their setup
+	SetupComplete(playerID uuid.UUID) bool
+}
+
+// MultiplayerState is implemented by game states that can seat more than two players
+type MultiplayerState interface {
+	// Players returns the seated players in turn order
+	Players() []uuid.UUID
+
+	// Standings groups the players by position, best first; players in the same group are tied.
+	// Once the game is over these are the final placements.
+	Standings() [][]uuid.UUID
+}
+
 // Common errors
 var (
 	ErrInvalidMove      = errors.New("invalid move")
@@ -87,5 +527,7 @@ var (
 	ErrGameNotActive    = errors.New("game is not active")
 	ErrGameAlreadyEnded = errors.New("game has already ended")
 	ErrInvalidPlayer    = errors.New("invalid player")
+	ErrVersionConflict  = errors.New("game was modified by another request, please retry")
+	ErrNotAPlayer       = errors.New("you are not a player in this game")
 )
 
@@ -0,0 +1,277 @@
+package game
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGameEnd tests finishing a game outside of CheckWinner (resignation, draws, aborts)
+func TestGameEnd(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+	now := time.Now()
+
+	newGame := func() *Game {
+		clock := NewGameClock(TimeControl{Type: TimeControlTotal, TotalSeconds: 60}, player1, player2)
+		clock.Start(player1, now)
+		return &Game{Player1ID: player1, Player2ID: player2, Status: GameStatusActive, Clock: clock}
+	}
+
+	t.Run("Resignation", func(t *testing.T) {
+		g := newGame()
+		winner := g.Opponent(player1)
+		assert.Equal(t, player2, winner)
+
+		g.End(GameStatusCompleted, &winner, EndReasonResignation, now.Add(5*time.Second))
+		assert.Equal(t, GameStatusCompleted, g.Status)
+		assert.Equal(t, player2, *g.WinnerID)
+		assert.Equal(t, EndReasonResignation, g.EndReason)
+		assert.NotNil(t, g.EndedAt)
+		assert.False(t, g.Clock.Running())
+		assert.Equal(t, int64(55000), g.Clock.RemainingMs[player1])
+	})
+
+	t.Run("Draw Clears Offer", func(t *testing.T) {
+		g := newGame()
+		g.DrawOfferedBy = &player1
+
+		g.End(GameStatusCompleted, nil, EndReasonDrawAgreed, now)
+		assert.Nil(t, g.WinnerID)
+		assert.Nil(t, g.DrawOfferedBy)
+	})
+
+	t.Run("Players", func(t *testing.T) {
+		g := &Game{Player1ID: player1}
+		assert.True(t, g.IsPlayer(player1))
+		assert.False(t, g.IsPlayer(player2))
+		assert.False(t, g.IsPlayer(uuid.Nil)) // Empty seat in a waiting game
+	})
+}
+
+// TestLegalMoves tests that every enumerated move is accepted by the engine
+func TestLegalMoves(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	t.Run("Every Legal Move Validates", func(t *testing.T) {
+		for _, gameType := range RegisteredTypes() {
+			state, err := DefaultRegistry.NewState(gameType, player1, player2, nil)
+			assert.NoError(t, err)
+
+			// Setup moves are not enumerated: let bots finish the setup phase first
+			if setup, ok := state.(SetupPhase); ok {
+				bot, _ := NewBot(gameType, BotEasy, rand.New(rand.NewSource(1)))
+				for i := 0; i < 2 && setup.InSetup(); i++ {
+					mover := state.GetCurrentPlayer()
+					move, err := bot.ChooseMove(state, mover)
+					assert.NoError(t, err, gameType)
+					assert.NoError(t, state.ApplyMove(mover, move), gameType)
+				}
+			}
+
+			moves := state.LegalMoves()
+			assert.NotEmpty(t, moves, gameType)
+			for _, move := range moves {
+				assert.NoError(t, state.ValidateMove(player1, move), gameType)
+			}
+		}
+	})
+
+	t.Run("Dots And Boxes Skips Drawn And Useless Lines", func(t *testing.T) {
+		state := NewDotsAndBoxesState(player1, player2)
+		total := len(state.LegalMoves())
+
+		assert.NoError(t, state.ApplyMove(player1, DotsAndBoxesMove{Row: 0, Col: 0, Orientation: LineHorizontal}))
+		moves := state.LegalMoves()
+		assert.Len(t, moves, total-1)
+		assert.NotContains(t, moves, DotsAndBoxesMove{Row: 0, Col: 0, Orientation: LineHorizontal})
+	})
+
+	t.Run("Game Not Active", func(t *testing.T) {
+		g := &Game{Status: GameStatusWaiting, State: NewTicTacToeState(player1, player2)}
+		assert.Nil(t, g.LegalMoves())
+
+		g.Status = GameStatusActive
+		assert.Len(t, g.LegalMoves(), 9)
+	})
+}
+
+// TestGameSetupPhase tests games that start with a setup phase
+func TestGameSetupPhase(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+	now := time.Now()
+
+	t.Run("Turn-Based Games Start Active", func(t *testing.T) {
+		g := &Game{Status: GameStatusWaiting, State: NewTicTacToeState(player1, player2)}
+		g.Begin(now)
+		assert.Equal(t, GameStatusActive, g.Status)
+		assert.Equal(t, &now, g.StartedAt)
+		assert.False(t, g.FinishSetup())
+		assert.True(t, g.AwaitingMove(player1))
+		assert.False(t, g.AwaitingMove(player2))
+	})
+
+	t.Run("Setup Until Every Player Is Ready", func(t *testing.T) {
+		state := NewBattleshipState(player1, player2)
+		g := &Game{Status: GameStatusWaiting, Player1ID: player1, Player2ID: player2, State: state}
+		g.Begin(now)
+		assert.Equal(t, GameStatusSetup, g.Status)
+		assert.True(t, g.Status.InProgress())
+		assert.True(t, g.AwaitingMove(player1))
+		assert.True(t, g.AwaitingMove(player2), "both players set up at the same time")
+		assert.Nil(t, g.LegalMoves())
+
+		assert.NoError(t, state.ApplyMove(player2, BattleshipMove{Ships: testFleet(0)}))
+		assert.False(t, g.FinishSetup())
+		assert.False(t, g.AwaitingMove(player2))
+
+		assert.NoError(t, state.ApplyMove(player1, BattleshipMove{Ships: testFleet(0)}))
+		assert.True(t, g.FinishSetup())
+		assert.Equal(t, GameStatusActive, g.Status)
+		assert.True(t, g.AwaitingMove(player1))
+		assert.False(t, g.AwaitingMove(player2))
+		assert.Len(t, g.LegalMoves(), 100)
+	})
+
+	t.Run("Finished Games Are Not In Progress", func(t *testing.T) {
+		assert.False(t, GameStatusWaiting.InProgress())
+		assert.False(t, GameStatusCompleted.InProgress())
+		assert.False(t, GameStatusAbandoned.InProgress())
+	})
+}
+
+// TestGameViewFor tests that serialized game views hide information per viewer
+func TestGameViewFor(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+	state := NewRPSState(player1, player2)
+	state.ApplyMove(player2, RPSMove{Choice: RPSChoiceScissors})
+	g := &Game{Player1ID: player1, Player2ID: player2, Status: GameStatusActive, State: state}
+
+	assert.Contains(t, string(g.ViewFor(player2).StateData), `"player2_choice":"scissors"`)
+	assert.NotContains(t, string(g.ViewFor(player1).StateData), "scissors")
+	assert.NotContains(t, string(g.ViewFor(uuid.New()).StateData), "scissors")
+	assert.Nil(t, g.StateData, "the game itself is not modified")
+}
+
+// TestGameViewForFingerprint tests that views leave out the fingerprint of the full state
+func TestGameViewForFingerprint(t *testing.T) {
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	// The rebuilt game has the fingerprint it is saved with
+	assertNoFingerprint := func(t *testing.T, sg *eventSourcedGame) {
+		t.Helper()
+		g := sg.assertRebuilt(t)
+		require.NotEmpty(t, g.StateFingerprint)
+		for _, viewerID := range []uuid.UUID{player1, player2, uuid.Nil, uuid.New()} {
+			data, err := json.Marshal(g.ViewFor(viewerID))
+			require.NoError(t, err)
+			var view map[string]interface{}
+			require.NoError(t, json.Unmarshal(data, &view))
+			assert.NotContains(t, view, "state_fingerprint")
+		}
+		assert.NotEmpty(t, g.StateFingerprint, "the game itself is not modified")
+	}
+
+	t.Run("Rock Paper Scissors", func(t *testing.T) {
+		sg := newEventSourcedGame(t, GameTypeRockPaperScissors, player1, nil)
+		sg.emit(t, EventJoined, player2, JoinedData{Name: "Bob"})
+		sg.emit(t, EventStarted, uuid.Nil, StartedData{FirstPlayerID: &player1})
+		sg.emit(t, EventMove, player1, RPSMove{Choice: RPSChoiceRock})
+		assertNoFingerprint(t, sg)
+	})
+
+	t.Run("Battleship", func(t *testing.T) {
+		sg := newEventSourcedGame(t, GameTypeBattleship, player1, nil)
+		sg.emit(t, EventJoined, player2, JoinedData{Name: "Bob"})
+		sg.emit(t, EventStarted, uuid.Nil, StartedData{FirstPlayerID: &player1})
+		sg.emit(t, EventMove, player1, BattleshipMove{Ships: testFleet(0)})
+		assertNoFingerprint(t, sg)
+
+		sg.emit(t, EventMove, player2, BattleshipMove{Ships: testFleet(5)})
+		sg.emit(t, EventMove, player1, BattleshipMove{Row: 9, Col: 9})
+		assertNoFingerprint(t, sg)
+	})
+}
+
+// TestGameSeats tests seating players and placing them at the end of a multiplayer game
+func TestGameSeats(t *testing.T) {
+	p1, p2, p3 := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+
+	t.Run("Seats Fill In Order", func(t *testing.T) {
+		g := &Game{SeatCount: 3}
+		assert.NoError(t, g.AddPlayer(p1, "alice"))
+		assert.NoError(t, g.AddPlayer(p2, "bob"))
+		assert.False(t, g.Full())
+		assert.Error(t, g.AddPlayer(p2, "bob"), "already seated")
+		assert.NoError(t, g.AddPlayer(p3, "carol"))
+		assert.True(t, g.Full())
+		assert.Error(t, g.AddPlayer(uuid.New(), "dave"), "full")
+
+		assert.Equal(t, []uuid.UUID{p1, p2, p3}, g.PlayerIDs())
+		assert.Equal(t, p2, g.Player2ID)
+		assert.Equal(t, "bob", g.Player2Name)
+		assert.Equal(t, 2, g.SeatOf(p3))
+		assert.True(t, g.IsMultiplayer())
+	})
+
+	t.Run("Games Without Seats Use Both Players", func(t *testing.T) {
+		g := &Game{Player1ID: p1, Player1Name: "alice"}
+		assert.Equal(t, 2, g.NumSeats())
+		assert.Equal(t, []uuid.UUID{p1}, g.PlayerIDs())
+		assert.False(t, g.IsPlayer(uuid.Nil))
+
+		assert.NoError(t, g.AddPlayer(p2, "bob"))
+		assert.True(t, g.Full())
+		assert.Equal(t, []Seat{{Index: 0, PlayerID: p1, Name: "alice"}, {Index: 1, PlayerID: p2, Name: "bob"}}, g.Seats)
+	})
+
+	t.Run("End Records Placements", func(t *testing.T) {
+		state := NewRPSEliminationState([]uuid.UUID{p1, p2, p3})
+		playRPSRound(t, state, map[uuid.UUID]RPSChoice{p1: RPSChoiceRock, p2: RPSChoiceRock, p3: RPSChoiceScissors})
+		playRPSRound(t, state, map[uuid.UUID]RPSChoice{p1: RPSChoiceRock, p2: RPSChoicePaper})
+		g := &Game{SeatCount: 3, Status: GameStatusActive, State: state}
+		for _, id := range state.PlayerIDs {
+			g.AddPlayer(id, "")
+		}
+
+		g.End(GameStatusCompleted, state.Winner, EndReasonNormal, now)
+		assert.Equal(t, [][]uuid.UUID{{p2}, {p1}, {p3}}, g.Placements)
+		assert.Equal(t, 2, g.PlacementOf(p1))
+		assert.Equal(t, 0, g.PlacementOf(uuid.New()))
+	})
+
+	t.Run("Forfeit Places The Player Last", func(t *testing.T) {
+		state := NewDotsAndBoxesStateForPlayers([]uuid.UUID{p1, p2, p3}, nil)
+		state.Scores = []int{2, 0, 1}
+		g := &Game{SeatCount: 3, Status: GameStatusActive, State: state}
+		for _, id := range state.PlayerIDs {
+			g.AddPlayer(id, "")
+		}
+
+		g.Forfeit(p1, EndReasonResignation, now)
+		assert.Equal(t, GameStatusCompleted, g.Status)
+		assert.Equal(t, [][]uuid.UUID{{p3}, {p2}, {p1}}, g.FinalStandings())
+		assert.Equal(t, p3, *g.WinnerID)
+	})
+
+	t.Run("Tied Placements Skip Positions", func(t *testing.T) {
+		g := &Game{Status: GameStatusCompleted, SeatCount: 3, Placements: [][]uuid.UUID{{p1, p2}, {p3}}}
+		assert.Equal(t, 1, g.PlacementOf(p2))
+		assert.Equal(t, 3, g.PlacementOf(p3))
+
+		draw := &Game{Player1ID: p1, Player2ID: p2, Status: GameStatusCompleted}
+		assert.Equal(t, 1, draw.PlacementOf(p2))
+		draw.WinnerID = &p2
+		assert.Equal(t, 2, draw.PlacementOf(p1))
+	})
+}
@@ -3,10 +3,12 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
+	"github.com/arenamatch/playforge/internal/domain"
 	"github.com/arenamatch/playforge/internal/game"
 	"github.com/arenamatch/playforge/internal/services"
 	ws "github.com/arenamatch/playforge/internal/websocket"
@@ -66,60 +68,245 @@ func (h *GameHandler) handleRedisEvent(payload string) {
 	switch event {
 	case "game_started":
 		h.handleGameStartedEvent(gameID, eventData)
-	case "game_move":
+	case "game_move", "game_timeout", "game_resigned", "game_drawn", "game_aborted", "game_forfeited", "game_abandoned":
 		h.handleGameMoveEvent(gameID, eventData)
+	case "draw_offered":
+		h.handleDrawOfferEvent(gameID, ws.MessageTypeDrawOffered, eventData)
+	case "draw_declined":
+		h.handleDrawOfferEvent(gameID, ws.MessageTypeDrawDeclined, eventData)
+	case "takeback_requested":
+		h.handleTakebackEvent(gameID, ws.MessageTypeTakebackRequested, eventData)
+	case "takeback_declined":
+		h.handleTakebackEvent(gameID, ws.MessageTypeTakebackDeclined, eventData)
+	case "takeback_accepted":
+		h.handleTakebackEvent(gameID, ws.MessageTypeTakebackAccepted, eventData)
+	case "pause_requested":
+		h.handlePauseEvent(gameID, ws.MessageTypePauseRequested, eventData)
+	case "pause_declined":
+		h.handlePauseEvent(gameID, ws.MessageTypePauseDeclined, eventData)
+	case "game_paused":
+		h.handlePauseEvent(gameID, ws.MessageTypeGamePaused, eventData)
+	case "resume_requested":
+		h.handlePauseEvent(gameID, ws.MessageTypeResumeRequested, eventData)
+	case "resume_declined":
+		h.handlePauseEvent(gameID, ws.MessageTypeResumeDeclined, eventData)
+	case "game_resumed":
+		h.handlePauseEvent(gameID, ws.MessageTypeGameResumed, eventData)
+	case "rematch_offered":
+		h.handleRematchEvent(gameID, ws.MessageTypeRematchOffered, eventData)
+	case "rematch_declined":
+		h.handleRematchEvent(gameID, ws.MessageTypeRematchDeclined, eventData)
+	case "rematch_started":
+		h.handleRematchEvent(gameID, ws.MessageTypeRematchStarted, eventData)
+	case "player_disconnected":
+		h.handlePresenceEvent(gameID, ws.MessageTypePlayerDisconnected, eventData)
+	case "player_reconnected":
+		h.handlePresenceEvent(gameID, ws.MessageTypePlayerReconnected, eventData)
 	}
 }
 
-// handleGameStartedEvent broadcasts game start to all players
-func (h *GameHandler) handleGameStartedEvent(gameID uuid.UUID, eventData map[string]interface{}) {
+// handlePresenceEvent tells everyone in a game that a player lost or regained their connection
+func (h *GameHandler) handlePresenceEvent(gameID uuid.UUID, msgType ws.MessageType, eventData map[string]interface{}) {
 	payloadData, ok := eventData["payload"].(map[string]interface{})
 	if !ok {
-		log.Printf("Invalid payload in game_started event")
+		log.Printf("Invalid payload in %s event", msgType)
 		return
 	}
 
-	// Create WebSocket message
 	wsMsg := ws.Message{
-		Type:      ws.MessageTypeGameState,
+		Type:      msgType,
 		Payload:   payloadData,
 		Timestamp: time.Now(),
 	}
 
 	data, err := json.Marshal(wsMsg)
 	if err != nil {
-		log.Printf("Error marshaling game_started message: %v", err)
+		log.Printf("Error marshaling %s message: %v", msgType, err)
+		return
+	}
+	h.hub.BroadcastToGame(gameID, data, nil)
+}
+
+// handleDrawOfferEvent notifies players of a draw offer change and broadcasts the updated game
+func (h *GameHandler) handleDrawOfferEvent(gameID uuid.UUID, msgType ws.MessageType, eventData map[string]interface{}) {
+	payloadData, ok := eventData["payload"].(map[string]interface{})
+	if !ok {
+		log.Printf("Invalid payload in %s event", msgType)
 		return
 	}
 
-	// Broadcast to all players in the game
+	wsMsg := ws.Message{
+		Type: msgType,
+		Payload: map[string]interface{}{
+			"game_id":         gameID.String(),
+			"draw_offered_by": payloadData["draw_offered_by"],
+		},
+		Timestamp: time.Now(),
+	}
+
+	data, err := json.Marshal(wsMsg)
+	if err != nil {
+		log.Printf("Error marshaling %s message: %v", msgType, err)
+		return
+	}
 	h.hub.BroadcastToGame(gameID, data, nil)
-	log.Printf("Broadcasted game_started event to game %s", gameID)
+
+	h.handleGameMoveEvent(gameID, eventData)
 }
 
-// handleGameMoveEvent broadcasts game moves to all players
-func (h *GameHandler) handleGameMoveEvent(gameID uuid.UUID, eventData map[string]interface{}) {
+// handleTakebackEvent notifies players of a takeback request or its answer and broadcasts the
+// updated game, which is the rewound position once a takeback is accepted
+func (h *GameHandler) handleTakebackEvent(gameID uuid.UUID, msgType ws.MessageType, eventData map[string]interface{}) {
 	payloadData, ok := eventData["payload"].(map[string]interface{})
 	if !ok {
-		log.Printf("Invalid payload in game_move event")
+		log.Printf("Invalid payload in %s event", msgType)
 		return
 	}
 
-	// Create WebSocket message
 	wsMsg := ws.Message{
-		Type:      ws.MessageTypeGameState,
-		Payload:   payloadData,
+		Type: msgType,
+		Payload: map[string]interface{}{
+			"game_id":               gameID.String(),
+			"takeback_requested_by": payloadData["takeback_requested_by"],
+			"move_count":            payloadData["move_count"],
+		},
+		Timestamp: time.Now(),
+	}
+
+	data, err := json.Marshal(wsMsg)
+	if err != nil {
+		log.Printf("Error marshaling %s message: %v", msgType, err)
+		return
+	}
+	h.hub.BroadcastToGame(gameID, data, nil)
+
+	h.handleGameMoveEvent(gameID, eventData)
+}
+
+// handlePauseEvent notifies players of a pause or resume request, its answer, or the game being
+// paused or resumed, and broadcasts the updated game
+func (h *GameHandler) handlePauseEvent(gameID uuid.UUID, msgType ws.MessageType, eventData map[string]interface{}) {
+	payloadData, ok := eventData["payload"].(map[string]interface{})
+	if !ok {
+		log.Printf("Invalid payload in %s event", msgType)
+		return
+	}
+
+	wsMsg := ws.Message{
+		Type: msgType,
+		Payload: map[string]interface{}{
+			"game_id":             gameID.String(),
+			"status":              payloadData["status"],
+			"paused":              payloadData["paused"],
+			"pause_requested_by":  payloadData["pause_requested_by"],
+			"resume_requested_by": payloadData["resume_requested_by"],
+		},
 		Timestamp: time.Now(),
 	}
 
 	data, err := json.Marshal(wsMsg)
 	if err != nil {
-		log.Printf("Error marshaling game_move message: %v", err)
+		log.Printf("Error marshaling %s message: %v", msgType, err)
 		return
 	}
+	h.hub.BroadcastToGame(gameID, data, nil)
 
-	// Broadcast to all players in the game
+	h.handleGameMoveEvent(gameID, eventData)
+}
+
+// handleRematchEvent tells everyone still in a finished game about a rematch offer, or where play
+// continues once the rematch (or next series game) has started, and broadcasts the updated game
+func (h *GameHandler) handleRematchEvent(gameID uuid.UUID, msgType ws.MessageType, eventData map[string]interface{}) {
+	payloadData, ok := eventData["payload"].(map[string]interface{})
+	if !ok {
+		log.Printf("Invalid payload in %s event", msgType)
+		return
+	}
+
+	wsMsg := ws.Message{
+		Type: msgType,
+		Payload: map[string]interface{}{
+			"game_id":            gameID.String(),
+			"rematch_offered_by": payloadData["rematch_offered_by"],
+			"next_game_id":       payloadData["next_game_id"],
+			"series":             payloadData["series"],
+		},
+		Timestamp: time.Now(),
+	}
+
+	data, err := json.Marshal(wsMsg)
+	if err != nil {
+		log.Printf("Error marshaling %s message: %v", msgType, err)
+		return
+	}
 	h.hub.BroadcastToGame(gameID, data, nil)
+
+	h.handleGameMoveEvent(gameID, eventData)
+}
+
+// handleGameStartedEvent broadcasts game start to all players
+func (h *GameHandler) handleGameStartedEvent(gameID uuid.UUID, eventData map[string]interface{}) {
+	g, err := decodeGamePayload(eventData)
+	if err != nil {
+		log.Printf("Invalid payload in game_started event: %v", err)
+		return
+	}
+
+	// Each player gets their own view of the state, spectators a redacted one
+	h.broadcastGameState(gameID, g)
+	log.Printf("Broadcasted game_started event to game %s", gameID)
+}
+
+// handleGameMoveEvent broadcasts game moves to all players
+func (h *GameHandler) handleGameMoveEvent(gameID uuid.UUID, eventData map[string]interface{}) {
+	g, err := decodeGamePayload(eventData)
+	if err != nil {
+		log.Printf("Invalid payload in game_move event: %v", err)
+		return
+	}
+
+	h.broadcastGameState(gameID, g)
+}
+
+// broadcastGameState sends everyone in a game the game as they may see it
+func (h *GameHandler) broadcastGameState(gameID uuid.UUID, g *game.Game) {
+	timestamp := time.Now()
+	h.hub.BroadcastToGameFor(gameID, func(userID uuid.UUID) []byte {
+		wsMsg := ws.Message{
+			Type:      ws.MessageTypeGameState,
+			Payload:   g.ViewFor(userID),
+			Timestamp: timestamp,
+		}
+
+		data, err := json.Marshal(wsMsg)
+		if err != nil {
+			log.Printf("Error marshaling game state message: %v", err)
+			return nil
+		}
+		return data
+	})
+}
+
+// decodeGamePayload restores the game published with an event, including its state
+func decodeGamePayload(eventData map[string]interface{}) (*game.Game, error) {
+	payloadData, ok := eventData["payload"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("payload is not a game")
+	}
+
+	data, err := json.Marshal(payloadData)
+	if err != nil {
+		return nil, err
+	}
+
+	var g game.Game
+	if err := json.Unmarshal(data, &g); err != nil {
+		return nil, err
+	}
+	if g.State, err = game.DefaultRegistry.DecodeState(g.Type, g.StateData); err != nil {
+		return nil, err
+	}
+	return &g, nil
 }
 
 type CreateGameRequest struct {
@@ -152,6 +339,9 @@ func (h *GameHandler) CreateGame(c *fiber.Ctx) error {
 
 	// Parse game type
 	gameType := game.GameType(req.GameType)
+	if !game.IsRegistered(gameType) {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid game type")
+	}
 
 	// Create game
 	g, err := h.gameService.CreateGame(c.Context(), gameType, userID, username)
@@ -159,7 +349,18 @@ func (h *GameHandler) CreateGame(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(g)
+	return c.Status(fiber.StatusCreated).JSON(g.ViewFor(userID))
+}
+
+// ListGameTypes returns every registered game with its settings schema
+// GET /api/v1/games/types
+func (h *GameHandler) ListGameTypes(c *fiber.Ctx) error {
+	definitions := game.Definitions()
+	return c.JSON(fiber.Map{
+		"game_types":      definitions,
+		"count":           len(definitions),
+		"common_settings": game.CommonSettings(),
+	})
 }
 
 // JoinGame allows a player to join a game
@@ -185,11 +386,13 @@ func (h *GameHandler) JoinGame(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	return c.JSON(g)
+	return c.JSON(g.ViewFor(userID))
 }
 
 // GetGame retrieves a game by ID (creates it on-demand if it's a tournament match)
 func (h *GameHandler) GetGame(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
 	gameID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid game ID")
@@ -227,7 +430,7 @@ func (h *GameHandler) GetGame(c *fiber.Ctx) error {
 									g, err = h.gameService.GetGame(c.Context(), gameID)
 									if err == nil {
 										log.Printf("✓ Successfully created and fetched game %s", gameID)
-										return c.JSON(g)
+										return c.JSON(g.ViewFor(userID))
 									} else {
 										log.Printf("ERROR: Game %s still not found after creation: %v", gameID, err)
 										return fiber.NewError(fiber.StatusInternalServerError, "Game created but could not be retrieved")
@@ -246,7 +449,7 @@ func (h *GameHandler) GetGame(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusNotFound, "Game not found")
 	}
 
-	return c.JSON(g)
+	return c.JSON(g.ViewFor(userID))
 }
 
 // HandleGameMessage processes WebSocket game messages
@@ -288,11 +491,25 @@ func (h *GameHandler) handleGameMove(ctx context.Context, client *ws.Client, msg
 	}
 
 	// Make move
-	g, err := h.gameService.MakeMove(ctx, gameID, playerID, moveMsg.Move)
+	var g *game.Game
+	var receipt *services.MoveReceipt
+	switch {
+	case moveMsg.ClientMoveID != "":
+		g, receipt, err = h.gameService.MakeMoveWithID(ctx, gameID, playerID, moveMsg.Move, moveMsg.Version, moveMsg.ClientMoveID)
+	case moveMsg.Version != nil:
+		g, err = h.gameService.MakeMoveAtVersion(ctx, gameID, playerID, moveMsg.Move, *moveMsg.Version)
+	default:
+		g, err = h.gameService.MakeMove(ctx, gameID, playerID, moveMsg.Move)
+	}
 	if err != nil {
 		return err
 	}
 
+	// Moves tagged with a client_move_id are acknowledged, resent copies as duplicates
+	if receipt != nil {
+		ws.SendMoveAck(client, gameID, receipt)
+	}
+
 	// Convert spectators to interface slice
 	spectators := make([]interface{}, len(g.Spectators))
 	for i, spec := range g.Spectators {
@@ -303,30 +520,55 @@ func (h *GameHandler) handleGameMove(ctx context.Context, client *ws.Client, msg
 		}
 	}
 	
-	// Broadcast game state to all players
-	stateMsg := ws.Message{
-		Type: ws.MessageTypeGameState,
-		Payload: ws.GameStateMessage{
-			GameID:      g.ID.String(),
-			GameType:    string(g.Type),
-			State:       g.State.GetState(),
-			CurrentTurn: g.CurrentTurn.String(),
-			Status:      string(g.Status),
-			Player1ID:   g.Player1ID.String(),
-			Player2ID:   g.Player2ID.String(),
-			Player1Name: g.Player1Name,
-			Player2Name: g.Player2Name,
-			WinnerID:    uuidToStringPtr(g.WinnerID),
-			Spectators:  spectators,
-		},
-	}
+	// Broadcast game state to all players, each seeing only what they may
+	h.hub.BroadcastToGameFor(gameID, func(userID uuid.UUID) []byte {
+		viewerID := userID
+		if !g.IsPlayer(viewerID) {
+			viewerID = uuid.Nil
+		}
+		stateMsg := ws.Message{
+			Type: ws.MessageTypeGameState,
+			Payload: ws.GameStateMessage{
+				GameID:        g.ID.String(),
+				GameType:      string(g.Type),
+				State:         g.State.StateFor(viewerID),
+				CurrentTurn:   g.CurrentTurn.String(),
+				Status:        string(g.Status),
+				Player1ID:     g.Player1ID.String(),
+				Player2ID:     g.Player2ID.String(),
+				Player1Name:   g.Player1Name,
+				Player2Name:   g.Player2Name,
+				Seats:         g.Seats,
+				WinnerID:      uuidToStringPtr(g.WinnerID),
+				Placements:    g.Placements,
+				Series:        g.Series,
+				NextGameID:    uuidToStringPtr(g.NextGameID),
+				Spectators:    spectators,
+				Version:       g.Version,
+				RemainingTime: remainingTime(g),
+				LegalMoves:    g.LegalMoves(),
+			},
+		}
 
-	data, _ := json.Marshal(stateMsg)
-	h.hub.BroadcastToGame(gameID, data, nil)
+		data, _ := json.Marshal(stateMsg)
+		return data
+	})
 
 	return nil
 }
 
+// remainingTime returns each player's remaining clock time in milliseconds (nil without a time control)
+func remainingTime(g *game.Game) map[string]int64 {
+	if g.Clock == nil {
+		return nil
+	}
+	remaining := make(map[string]int64)
+	for playerID, ms := range g.Clock.RemainingAll(time.Now()) {
+		remaining[playerID.String()] = ms
+	}
+	return remaining
+}
+
 func uuidToStringPtr(id *uuid.UUID) *string {
 	if id == nil {
 		return nil
@@ -335,6 +577,32 @@ func uuidToStringPtr(id *uuid.UUID) *string {
 	return &s
 }
 
+// GetLegalMoves lists the moves open to the player to move
+func (h *GameHandler) GetLegalMoves(c *fiber.Ctx) error {
+	gameID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid game ID")
+	}
+
+	g, err := h.gameService.GetGame(c.Context(), gameID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusNotFound, "Game not found")
+	}
+
+	moves := g.LegalMoves()
+	if moves == nil {
+		moves = []interface{}{}
+	}
+
+	return c.JSON(fiber.Map{
+		"game_id":      g.ID,
+		"status":       g.Status,
+		"current_turn": g.CurrentTurn,
+		"version":      g.Version,
+		"moves":        moves,
+	})
+}
+
 // JoinAsSpectator allows a user to join a game as a spectator
 func (h *GameHandler) JoinAsSpectator(c *fiber.Ctx) error {
 	// Get user from context
@@ -372,7 +640,7 @@ func (h *GameHandler) JoinAsSpectator(c *fiber.Ctx) error {
 
 	return c.JSON(fiber.Map{
 		"message":    "Joined as spectator",
-		"game":       g,
+		"game":       g.ViewFor(userID),
 		"spectators": g.Spectators,
 	})
 }
@@ -414,6 +682,219 @@ func (h *GameHandler) LeaveAsSpectator(c *fiber.Ctx) error {
 	})
 }
 
+// Resign resigns the current user from a game
+func (h *GameHandler) Resign(c *fiber.Ctx) error {
+	return h.gameAction(c, h.gameService.Resign)
+}
+
+// OfferDraw offers a draw to the opponent
+func (h *GameHandler) OfferDraw(c *fiber.Ctx) error {
+	return h.gameAction(c, h.gameService.OfferDraw)
+}
+
+// AcceptDraw accepts the opponent's draw offer
+func (h *GameHandler) AcceptDraw(c *fiber.Ctx) error {
+	return h.gameAction(c, h.gameService.AcceptDraw)
+}
+
+// DeclineDraw declines the opponent's draw offer
+func (h *GameHandler) DeclineDraw(c *fiber.Ctx) error {
+	return h.gameAction(c, h.gameService.DeclineDraw)
+}
+
+// Abort aborts a game before the first move
+func (h *GameHandler) Abort(c *fiber.Ctx) error {
+	return h.gameAction(c, h.gameService.Abort)
+}
+
+// RequestTakeback asks the opponent to let the player take back their last move
+func (h *GameHandler) RequestTakeback(c *fiber.Ctx) error {
+	return h.gameAction(c, h.gameService.RequestTakeback)
+}
+
+// AcceptTakeback accepts the opponent's takeback request
+func (h *GameHandler) AcceptTakeback(c *fiber.Ctx) error {
+	return h.gameAction(c, h.gameService.AcceptTakeback)
+}
+
+// DeclineTakeback declines the opponent's takeback request
+func (h *GameHandler) DeclineTakeback(c *fiber.Ctx) error {
+	return h.gameAction(c, h.gameService.DeclineTakeback)
+}
+
+// RequestPause asks the opponent to pause a casual game
+func (h *GameHandler) RequestPause(c *fiber.Ctx) error {
+	return h.gameAction(c, h.gameService.RequestPause)
+}
+
+// AcceptPause accepts the opponent's pause request
+func (h *GameHandler) AcceptPause(c *fiber.Ctx) error {
+	return h.gameAction(c, h.gameService.AcceptPause)
+}
+
+// DeclinePause declines the opponent's pause request
+func (h *GameHandler) DeclinePause(c *fiber.Ctx) error {
+	return h.gameAction(c, h.gameService.DeclinePause)
+}
+
+// RequestResume asks the opponent to resume a paused game
+func (h *GameHandler) RequestResume(c *fiber.Ctx) error {
+	return h.gameAction(c, h.gameService.RequestResume)
+}
+
+// AcceptResume accepts the opponent's resume request
+func (h *GameHandler) AcceptResume(c *fiber.Ctx) error {
+	return h.gameAction(c, h.gameService.AcceptResume)
+}
+
+// DeclineResume declines the opponent's resume request
+func (h *GameHandler) DeclineResume(c *fiber.Ctx) error {
+	return h.gameAction(c, h.gameService.DeclineResume)
+}
+
+// AdminPause pauses a tournament game for the tournament's host
+func (h *GameHandler) AdminPause(c *fiber.Ctx) error {
+	return h.gameAction(c, h.gameService.AdminPause)
+}
+
+// AdminResume resumes a tournament game the tournament's host paused
+func (h *GameHandler) AdminResume(c *fiber.Ctx) error {
+	return h.gameAction(c, h.gameService.AdminResume)
+}
+
+// ListPausedGames returns the current user's paused games
+// GET /api/v1/games/paused
+func (h *GameHandler) ListPausedGames(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	games, err := h.gameService.ListPausedGames(c.Context(), userID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(fiber.Map{
+		"games": games,
+		"count": len(games),
+	})
+}
+
+// OfferRematch offers the opponent a rematch once the game is over
+func (h *GameHandler) OfferRematch(c *fiber.Ctx) error {
+	return h.gameAction(c, h.gameService.OfferRematch)
+}
+
+// AcceptRematch accepts the opponent's rematch offer and returns the new game
+func (h *GameHandler) AcceptRematch(c *fiber.Ctx) error {
+	return h.gameAction(c, h.gameService.AcceptRematch)
+}
+
+// DeclineRematch declines the opponent's rematch offer
+func (h *GameHandler) DeclineRematch(c *fiber.Ctx) error {
+	return h.gameAction(c, h.gameService.DeclineRematch)
+}
+
+// gameAction runs a player action on the game in the URL for the current user
+func (h *GameHandler) gameAction(c *fiber.Ctx, action func(ctx context.Context, gameID, playerID uuid.UUID) (*game.Game, error)) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	gameID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid game ID")
+	}
+
+	g, err := action(c.Context(), gameID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrVersionConflict):
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		case errors.Is(err, game.ErrNotAPlayer), errors.Is(err, game.ErrTakebacksDisabled),
+			errors.Is(err, game.ErrPausesDisabled), errors.Is(err, domain.ErrNotTournamentHost):
+			return fiber.NewError(fiber.StatusForbidden, err.Error())
+		}
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(g.ViewFor(userID))
+}
+
+// GetReplay returns the move list and starting position of a finished game
+func (h *GameHandler) GetReplay(c *fiber.Ctx) error {
+	gameID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid game ID")
+	}
+
+	g, replay, err := h.gameService.GetReplay(c.Context(), gameID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	initial, err := replay.FrameAt(0)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(fiber.Map{
+		"game_id":       g.ID,
+		"game_type":     g.Type,
+		"status":        g.Status,
+		"player1_id":    g.Player1ID,
+		"player2_id":    g.Player2ID,
+		"player1_name":  g.Player1Name,
+		"player2_name":  g.Player2Name,
+		"seats":         g.Seats,
+		"winner_id":     g.WinnerID,
+		"placements":    g.Placements,
+		"settings":      g.Settings,
+		"total_plies":   replay.TotalPlies(),
+		"moves":         replay.Moves(),
+		"initial_state": initial,
+	})
+}
+
+// GetReplayFrame returns the position of a finished game after a given ply
+func (h *GameHandler) GetReplayFrame(c *fiber.Ctx) error {
+	gameID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid game ID")
+	}
+
+	ply, err := c.ParamsInt("ply")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ply")
+	}
+
+	_, replay, err := h.gameService.GetReplay(c.Context(), gameID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	frame, err := replay.FrameAt(ply)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(frame)
+}
+
+// GetEvents returns the lifecycle events of a finished game, oldest first
+func (h *GameHandler) GetEvents(c *fiber.Ctx) error {
+	gameID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid game ID")
+	}
+
+	events, err := h.gameService.GetGameEvents(c.Context(), gameID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(fiber.Map{
+		"game_id": gameID,
+		"events":  events,
+	})
+}
+
 // GetSpectators returns the list of spectators for a game
 func (h *GameHandler) GetSpectators(c *fiber.Ctx) error {
 	// Parse game ID from URL params
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/arenamatch/playforge/internal/game"
+	"github.com/arenamatch/playforge/internal/services"
+	ws "github.com/arenamatch/playforge/internal/websocket"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHandleGameMove tests moves sent through the game handler's WebSocket path
+func TestHandleGameMove(t *testing.T) {
+	t.Run("Resent Move Is Applied Once And Acknowledged As A Duplicate", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		t.Cleanup(func() { client.Close() })
+		gameService := services.NewGameService(client, nil, nil)
+		h := NewGameHandler(gameService, nil, ws.NewHub())
+		ctx := context.Background()
+
+		g, err := gameService.CreateGame(ctx, game.GameTypeTicTacToe, uuid.New(), "Alice")
+		require.NoError(t, err)
+		g, err = gameService.JoinGame(ctx, g.ID, uuid.New(), "Bob")
+		require.NoError(t, err)
+
+		mover := &ws.Client{ID: uuid.New(), UserID: g.CurrentTurn, Send: make(chan []byte, 8)}
+		msg := &ws.Message{Type: ws.MessageTypeGameMove, Payload: ws.GameMoveMessage{
+			GameID:       g.ID.String(),
+			PlayerID:     g.CurrentTurn.String(),
+			Move:         game.TicTacToeMove{Row: 1, Col: 1},
+			ClientMoveID: "move-1",
+		}}
+		require.NoError(t, h.HandleGameMessage(ctx, mover, msg))
+		require.NoError(t, h.HandleGameMessage(ctx, mover, msg))
+
+		var acks []ws.GameMoveAckMessage
+		for len(mover.Send) > 0 {
+			var reply struct {
+				Type    ws.MessageType        `json:"type"`
+				Payload ws.GameMoveAckMessage `json:"payload"`
+			}
+			require.NoError(t, json.Unmarshal(<-mover.Send, &reply))
+			if reply.Type == ws.MessageTypeGameMoveAck {
+				acks = append(acks, reply.Payload)
+			}
+		}
+		require.Len(t, acks, 2)
+		assert.False(t, acks[0].Duplicate)
+		assert.True(t, acks[1].Duplicate)
+		assert.Equal(t, "move-1", acks[1].ClientMoveID)
+		assert.Equal(t, acks[0].Version, acks[1].Version)
+
+		played, err := gameService.GetGame(ctx, g.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, played.MoveCount)
+		assert.Equal(t, acks[0].Version, played.Version)
+	})
+}
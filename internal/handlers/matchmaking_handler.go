@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"github.com/arenamatch/playforge/internal/domain"
+	"github.com/arenamatch/playforge/internal/game"
 	"github.com/arenamatch/playforge/internal/services"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -37,13 +38,7 @@ func (h *MatchmakingHandler) JoinQueue(c *fiber.Ctx) error {
 	}
 
 	// Validate game type
-	validGameTypes := map[string]bool{
-		"tictactoe":     true,
-		"connect4":      true,
-		"rps":           true,
-		"dotsandboxes":  true,
-	}
-	if !validGameTypes[req.GameType] {
+	if !game.IsRegistered(game.GameType(req.GameType)) {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "Invalid game type",
 		})
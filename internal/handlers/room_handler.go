@@ -4,6 +4,7 @@ import (
 	"fmt"
 	
 	"github.com/arenamatch/playforge/internal/domain"
+	"github.com/arenamatch/playforge/internal/game"
 	"github.com/arenamatch/playforge/internal/services"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -41,22 +42,41 @@ func (h *RoomHandler) CreateRoom(c *fiber.Ctx) error {
 	fmt.Printf("CreateRoom request received: GameType=%s, GameSettings=%+v\n", req.GameType, req.GameSettings)
 
 	// Validate game type
-	validGameTypes := map[string]bool{
-		"tictactoe":    true,
-		"connect4":     true,
-		"rps":          true,
-		"dotsandboxes": true,
-	}
-	if !validGameTypes[req.GameType] {
+	if !game.IsRegistered(game.GameType(req.GameType)) {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "Invalid game type",
 		})
 	}
 
-	// Validate max players
-	if req.MaxPlayers < 2 || req.MaxPlayers > 4 {
+	// Validate bot opponent
+	if req.BotDifficulty != "" {
+		if _, err := game.ParseBotDifficulty(req.BotDifficulty); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Bot difficulty must be easy, medium or hard",
+			})
+		}
+		if !game.HasBot(game.GameType(req.GameType)) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "This game type cannot be played against a bot",
+			})
+		}
+	}
+
+	// Validate series length
+	if req.SeriesBestOf != 0 && req.SeriesBestOf != 3 && req.SeriesBestOf != 5 && req.SeriesBestOf != 7 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Series must be best of 3, 5 or 7",
+		})
+	}
+
+	// Validate max players (rooms for multiplayer games may seat everyone the game allows)
+	maxPlayers := 4
+	if def, ok := game.Lookup(game.GameType(req.GameType)); ok && def.MaxPlayers > maxPlayers {
+		maxPlayers = def.MaxPlayers
+	}
+	if req.MaxPlayers < 2 || req.MaxPlayers > maxPlayers {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Max players must be between 2 and 4",
+			"error": fmt.Sprintf("Max players must be between 2 and %d", maxPlayers),
 		})
 	}
 
@@ -6,6 +6,7 @@ import (
 	"log"
 
 	"github.com/arenamatch/playforge/internal/domain"
+	"github.com/arenamatch/playforge/internal/game"
 	"github.com/arenamatch/playforge/internal/services"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -35,6 +36,10 @@ func (h *TournamentHandler) CreateTournament(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
 	}
 
+	if !game.IsRegistered(game.GameType(req.GameType)) {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid game type")
+	}
+
 	tournament, err := h.tournamentService.CreateTournament(c.Context(), userID, username, req)
 	if err != nil {
 		// Log the actual error for debugging
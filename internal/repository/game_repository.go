@@ -2,17 +2,18 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5"
 )
 
 type GameRepository struct {
-	db *pgxpool.Pool
+	db DBTX
 }
 
-func NewGameRepository(db *pgxpool.Pool) *GameRepository {
+func NewGameRepository(db DBTX) *GameRepository {
 	return &GameRepository{db: db}
 }
 
@@ -25,10 +26,11 @@ func (r *GameRepository) CreateGame(
 	player2ID uuid.UUID,
 	createdAt time.Time,
 	gameState []byte,
+	settings []byte,
 ) error {
 	query := `
-		INSERT INTO game_matches (id, game_type, player1_id, player2_id, status, started_at, created_at, game_state)
-		VALUES ($1, $2, $3, $4, 'active', $5, $6, $7)
+		INSERT INTO game_matches (id, game_type, player1_id, player2_id, status, started_at, created_at, game_state, settings)
+		VALUES ($1, $2, $3, $4, 'active', $5, $6, $7, $8)
 		ON CONFLICT (id) DO NOTHING
 	`
 
@@ -42,6 +44,7 @@ func (r *GameRepository) CreateGame(
 		createdAt,
 		createdAt,
 		gameState,
+		settings,
 	)
 
 	return err
@@ -58,15 +61,20 @@ func (r *GameRepository) SaveCompletedGame(
 	startedAt time.Time,
 	endedAt *time.Time,
 	gameState []byte,
+	moves []byte,
+	settings []byte,
 ) error {
 	query := `
-		INSERT INTO game_matches (id, game_type, player1_id, player2_id, winner_id, status, started_at, ended_at, created_at, game_state)
-		VALUES ($1, $2, $3, $4, $5, 'completed', $6, $7, $8, $9)
+		INSERT INTO game_matches (id, game_type, player1_id, player2_id, winner_id, status, started_at, ended_at, created_at, game_state, moves, settings)
+		VALUES ($1, $2, $3, $4, $5, 'completed', $6, $7, $8, $9, $10, $11)
 		ON CONFLICT (id) DO UPDATE SET
 			winner_id = EXCLUDED.winner_id,
 			status = EXCLUDED.status,
 			ended_at = EXCLUDED.ended_at,
-			game_state = EXCLUDED.game_state
+			game_state = EXCLUDED.game_state,
+			moves = EXCLUDED.moves,
+			settings = COALESCE(EXCLUDED.settings, game_matches.settings),
+			snapshot = NULL
 	`
 
 	_, err := r.db.Exec(
@@ -81,17 +89,493 @@ func (r *GameRepository) SaveCompletedGame(
 		endedAt,
 		startedAt,
 		gameState,
+		moves,
+		settings,
 	)
 
 	return err
 }
 
+// GameCompletion is a finished game as saved for match history
+type GameCompletion struct {
+	GameID        uuid.UUID
+	GameType      string
+	Player1ID     uuid.UUID
+	Player2ID     uuid.UUID
+	WinnerID      *uuid.UUID
+	StartedAt     time.Time
+	EndedAt       *time.Time
+	State         []byte
+	Moves         []byte
+	Settings      []byte
+	Participants  []GameParticipant
+	FirstPlayerID uuid.UUID
+	Series        *SeriesRecord // Set for series games
+	SeriesGame    int           // Number of the game in its series
+}
+
+// OutboxEntry is a side effect of a finished game (e.g. its stats update) waiting in the
+// completion outbox to be applied
+type OutboxEntry struct {
+	ID       int64
+	GameID   uuid.UUID
+	Kind     string
+	Payload  []byte
+	Attempts int // Failed attempts so far
+}
+
+// CompleteGame saves a finished game for match history together with the outbox entries of its
+// side effects, in one transaction. A game that is already recorded as completed is left as it
+// is, so completing it again (when its completion event is delivered again) changes nothing.
+func (r *GameRepository) CompleteGame(ctx context.Context, completion GameCompletion, outbox []OutboxEntry) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		txRepo := &GameRepository{db: tx}
+
+		var completed bool
+		err := tx.QueryRow(ctx, `SELECT status = 'completed' FROM game_matches WHERE id = $1 FOR UPDATE`, completion.GameID).Scan(&completed)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+		if completed {
+			return nil
+		}
+
+		err = txRepo.SaveCompletedGame(ctx, completion.GameID, completion.GameType, completion.Player1ID, completion.Player2ID, completion.WinnerID,
+			completion.StartedAt, completion.EndedAt, completion.State, completion.Moves, completion.Settings)
+		if err != nil {
+			return err
+		}
+		if err := txRepo.SaveParticipants(ctx, completion.GameID, completion.Participants); err != nil {
+			return err
+		}
+		if err := txRepo.SetFirstPlayer(ctx, completion.GameID, completion.FirstPlayerID); err != nil {
+			return err
+		}
+		if completion.Series != nil {
+			if err := txRepo.SaveSeries(ctx, *completion.Series); err != nil {
+				return err
+			}
+			if err := txRepo.LinkSeriesGame(ctx, completion.GameID, completion.Series.ID, completion.SeriesGame); err != nil {
+				return err
+			}
+		}
+
+		query := `
+			INSERT INTO game_outbox (game_id, kind, payload)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (game_id, kind) DO NOTHING
+		`
+		for _, entry := range outbox {
+			if _, err := tx.Exec(ctx, query, completion.GameID, entry.Kind, entry.Payload); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListDueOutboxEntries retrieves the IDs of pending outbox entries due to be applied, oldest first
+func (r *GameRepository) ListDueOutboxEntries(ctx context.Context, limit int) ([]int64, error) {
+	query := `
+		SELECT id FROM game_outbox
+		WHERE status = 'pending' AND next_attempt_at <= CURRENT_TIMESTAMP
+		ORDER BY next_attempt_at
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// ProcessOutboxEntry applies a due outbox entry with handle, in a transaction that marks the
+// entry done, so the changes handle makes in tx commit together with it. The entry stays locked
+// while handle runs; one that another worker holds or has already applied is skipped.
+func (r *GameRepository) ProcessOutboxEntry(ctx context.Context, id int64, handle func(tx pgx.Tx, entry OutboxEntry) error) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		query := `
+			SELECT id, game_id, kind, payload, attempts FROM game_outbox
+			WHERE id = $1 AND status = 'pending' AND next_attempt_at <= CURRENT_TIMESTAMP
+			FOR UPDATE SKIP LOCKED
+		`
+
+		var entry OutboxEntry
+		err := tx.QueryRow(ctx, query, id).Scan(&entry.ID, &entry.GameID, &entry.Kind, &entry.Payload, &entry.Attempts)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := handle(tx, entry); err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE game_outbox
+			SET status = 'done', last_error = NULL, processed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $1
+		`, id)
+		return err
+	})
+}
+
+// FailOutboxEntry records a failed attempt at an outbox entry. The entry is retried after a delay
+// starting at retryBase and doubling with each attempt up to retryMax; after maxAttempts it is
+// marked dead instead, and reported as such, leaving it in the game_outbox_dead_letters view.
+func (r *GameRepository) FailOutboxEntry(ctx context.Context, id int64, lastError string, retryBase, retryMax time.Duration, maxAttempts int) (bool, error) {
+	query := `
+		UPDATE game_outbox
+		SET attempts = attempts + 1,
+			last_error = $2,
+			status = CASE WHEN attempts + 1 >= $3 THEN 'dead' ELSE status END,
+			next_attempt_at = CURRENT_TIMESTAMP + LEAST($4 * POWER(2, attempts), $5) * INTERVAL '1 second',
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'pending'
+		RETURNING status = 'dead'
+	`
+
+	var dead bool
+	err := r.db.QueryRow(ctx, query, id, lastError, maxAttempts, retryBase.Seconds(), retryMax.Seconds()).Scan(&dead)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	return dead, err
+}
+
+// GameCheckpoint is the stored copy of an in-progress game, written behind its Redis copy so the
+// game can be restored if Redis loses it
+type GameCheckpoint struct {
+	GameID    uuid.UUID
+	GameType  string
+	Player1ID uuid.UUID
+	Player2ID uuid.UUID
+	StartedAt time.Time
+	State     []byte // Engine state
+	MoveCount int
+	Moves     []byte // Move log so far
+	Snapshot  []byte // The whole game as stored in Redis
+	Version   int64  // Version of the snapshot; an older checkpoint never replaces a newer one
+	Settings  []byte
+	Paused    bool // Paused games are kept until they are resumed, however long that takes
+}
+
+// SaveCheckpoint creates or updates the stored copy of an in-progress game, stored as active or
+// paused. Games already saved as finished are left alone.
+func (r *GameRepository) SaveCheckpoint(ctx context.Context, checkpoint GameCheckpoint) error {
+	query := `
+		INSERT INTO game_matches (id, game_type, player1_id, player2_id, status, started_at, created_at, game_state, move_count, moves, snapshot, snapshot_version, settings, checkpointed_at)
+		VALUES ($1, $2, $3, $4, CASE WHEN $13 THEN 'paused' ELSE 'active' END, $5, $6, $7, $8, $9, $10, $11, $12, CURRENT_TIMESTAMP)
+		ON CONFLICT (id) DO UPDATE SET
+			status = EXCLUDED.status,
+			game_state = EXCLUDED.game_state,
+			move_count = EXCLUDED.move_count,
+			moves = EXCLUDED.moves,
+			snapshot = EXCLUDED.snapshot,
+			snapshot_version = EXCLUDED.snapshot_version,
+			settings = COALESCE(EXCLUDED.settings, game_matches.settings),
+			checkpointed_at = EXCLUDED.checkpointed_at
+		WHERE game_matches.status IN ('active', 'paused')
+			AND COALESCE(game_matches.snapshot_version, 0) <= EXCLUDED.snapshot_version
+	`
+
+	_, err := r.db.Exec(
+		ctx,
+		query,
+		checkpoint.GameID,
+		checkpoint.GameType,
+		checkpoint.Player1ID,
+		checkpoint.Player2ID,
+		checkpoint.StartedAt,
+		checkpoint.StartedAt,
+		checkpoint.State,
+		checkpoint.MoveCount,
+		checkpoint.Moves,
+		checkpoint.Snapshot,
+		checkpoint.Version,
+		checkpoint.Settings,
+		checkpoint.Paused,
+	)
+
+	return err
+}
+
+// AbandonCheckpoint marks the stored copy of an in-progress game as abandoned so it is never restored
+func (r *GameRepository) AbandonCheckpoint(ctx context.Context, gameID uuid.UUID, endedAt time.Time) error {
+	query := `
+		UPDATE game_matches SET status = 'abandoned', ended_at = $2, snapshot = NULL
+		WHERE id = $1 AND status IN ('active', 'paused')
+	`
+
+	_, err := r.db.Exec(ctx, query, gameID, endedAt)
+	return err
+}
+
+// GetCheckpoint retrieves the latest snapshot and move log of an in-progress game checkpointed
+// after since, or of a paused game however old (nil if there is none)
+func (r *GameRepository) GetCheckpoint(ctx context.Context, gameID uuid.UUID, since time.Time) (*GameCheckpoint, error) {
+	query := `
+		SELECT snapshot, moves, snapshot_version, status = 'paused'
+		FROM game_matches
+		WHERE id = $1 AND snapshot IS NOT NULL
+			AND (status = 'paused' OR (status = 'active' AND checkpointed_at > $2))
+	`
+
+	checkpoint := &GameCheckpoint{GameID: gameID}
+	err := r.db.QueryRow(ctx, query, gameID, since).Scan(&checkpoint.Snapshot, &checkpoint.Moves, &checkpoint.Version, &checkpoint.Paused)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return checkpoint, nil
+}
+
+// ListCheckpoints returns the IDs of in-progress games checkpointed after since
+func (r *GameRepository) ListCheckpoints(ctx context.Context, since time.Time) ([]uuid.UUID, error) {
+	query := `
+		SELECT id FROM game_matches
+		WHERE status = 'active' AND snapshot IS NOT NULL AND checkpointed_at > $1
+		ORDER BY checkpointed_at
+	`
+
+	rows, err := r.db.Query(ctx, query, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var gameIDs []uuid.UUID
+	for rows.Next() {
+		var gameID uuid.UUID
+		if err := rows.Scan(&gameID); err != nil {
+			return nil, err
+		}
+		gameIDs = append(gameIDs, gameID)
+	}
+
+	return gameIDs, rows.Err()
+}
+
+// ListPausedGames returns the snapshots of the paused games a user plays in, most recently
+// paused first
+func (r *GameRepository) ListPausedGames(ctx context.Context, userID uuid.UUID) ([]GameCheckpoint, error) {
+	query := `
+		SELECT id, snapshot, snapshot_version
+		FROM game_matches
+		WHERE status = 'paused' AND snapshot IS NOT NULL AND (player1_id = $1 OR player2_id = $1)
+		ORDER BY checkpointed_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var checkpoints []GameCheckpoint
+	for rows.Next() {
+		checkpoint := GameCheckpoint{Paused: true}
+		if err := rows.Scan(&checkpoint.GameID, &checkpoint.Snapshot, &checkpoint.Version); err != nil {
+			return nil, err
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+
+	return checkpoints, rows.Err()
+}
+
+// SaveEvent archives one event of a game. The event is stored as given; version and index
+// place it in the game's event stream, and an event already archived is left alone.
+func (r *GameRepository) SaveEvent(ctx context.Context, gameID uuid.UUID, version int64, index int, eventType string, occurredAt time.Time, event []byte) error {
+	query := `
+		INSERT INTO game_events (game_id, version, event_index, event_type, occurred_at, event)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (game_id, version, event_index) DO NOTHING
+	`
+
+	_, err := r.db.Exec(ctx, query, gameID, version, index, eventType, occurredAt, event)
+	return err
+}
+
+// GetEvents retrieves the archived events of a game in order
+func (r *GameRepository) GetEvents(ctx context.Context, gameID uuid.UUID) ([][]byte, error) {
+	query := `
+		SELECT event FROM game_events
+		WHERE game_id = $1
+		ORDER BY version, event_index
+	`
+
+	rows, err := r.db.Query(ctx, query, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events [][]byte
+	for rows.Next() {
+		var event []byte
+		if err := rows.Scan(&event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// GameParticipant is a player's seat in a stored game
+type GameParticipant struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Username  string    `json:"username"`
+	Seat      int       `json:"seat"`
+	Placement int       `json:"placement,omitempty"` // 1 for first; 0 when not placed
+}
+
+// SaveParticipants records the seat and placement of every player in a game
+func (r *GameRepository) SaveParticipants(ctx context.Context, gameID uuid.UUID, participants []GameParticipant) error {
+	query := `
+		INSERT INTO game_participants (game_id, user_id, seat, placement)
+		VALUES ($1, $2, $3, NULLIF($4, 0))
+		ON CONFLICT (game_id, seat) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			placement = EXCLUDED.placement
+	`
+
+	for _, participant := range participants {
+		if _, err := r.db.Exec(ctx, query, gameID, participant.UserID, participant.Seat, participant.Placement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetParticipants retrieves the players of a stored game in seat order (empty for games
+// saved before participants were recorded)
+func (r *GameRepository) GetParticipants(ctx context.Context, gameID uuid.UUID) ([]GameParticipant, error) {
+	query := `
+		SELECT gp.user_id, u.username, gp.seat, COALESCE(gp.placement, 0)
+		FROM game_participants gp
+		INNER JOIN users u ON gp.user_id = u.id
+		WHERE gp.game_id = $1
+		ORDER BY gp.seat
+	`
+
+	rows, err := r.db.Query(ctx, query, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var participants []GameParticipant
+	for rows.Next() {
+		var participant GameParticipant
+		if err := rows.Scan(&participant.UserID, &participant.Username, &participant.Seat, &participant.Placement); err != nil {
+			return nil, err
+		}
+		participants = append(participants, participant)
+	}
+
+	return participants, rows.Err()
+}
+
+// SeriesRecord is the stored score of a best-of-N series. Player 1 and 2 are the seats of the
+// series' first game.
+type SeriesRecord struct {
+	ID          uuid.UUID
+	GameType    string
+	BestOf      int
+	Games       int // Games played so far
+	Player1ID   uuid.UUID
+	Player2ID   uuid.UUID
+	Player1Wins int
+	Player2Wins int
+	Draws       int
+	WinnerID    *uuid.UUID
+	Status      string
+}
+
+// SaveSeries creates or updates the score of a series
+func (r *GameRepository) SaveSeries(ctx context.Context, series SeriesRecord) error {
+	query := `
+		INSERT INTO game_series (id, game_type, best_of, games_played, player1_id, player2_id, player1_wins, player2_wins, draws, winner_id, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (id) DO UPDATE SET
+			games_played = EXCLUDED.games_played,
+			player1_wins = EXCLUDED.player1_wins,
+			player2_wins = EXCLUDED.player2_wins,
+			draws = EXCLUDED.draws,
+			winner_id = EXCLUDED.winner_id,
+			status = EXCLUDED.status,
+			updated_at = CURRENT_TIMESTAMP
+	`
+
+	_, err := r.db.Exec(
+		ctx,
+		query,
+		series.ID,
+		series.GameType,
+		series.BestOf,
+		series.Games,
+		series.Player1ID,
+		series.Player2ID,
+		series.Player1Wins,
+		series.Player2Wins,
+		series.Draws,
+		series.WinnerID,
+		series.Status,
+	)
+
+	return err
+}
+
+// LinkSeriesGame records which series a stored game belongs to and its number in the series
+func (r *GameRepository) LinkSeriesGame(ctx context.Context, gameID, seriesID uuid.UUID, gameNumber int) error {
+	_, err := r.db.Exec(ctx, `UPDATE game_matches SET series_id = $2, series_game = $3 WHERE id = $1`, gameID, seriesID, gameNumber)
+	return err
+}
+
+// SetFirstPlayer records which player moved first in a stored game
+func (r *GameRepository) SetFirstPlayer(ctx context.Context, gameID, firstPlayerID uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `UPDATE game_matches SET first_player_id = $2 WHERE id = $1`, gameID, firstPlayerID)
+	return err
+}
+
+// GetMoves retrieves the stored move log for a game (nil if none was recorded)
+func (r *GameRepository) GetMoves(ctx context.Context, gameID uuid.UUID) ([]byte, error) {
+	query := `SELECT moves FROM game_matches WHERE id = $1`
+
+	var moves []byte
+	err := r.db.QueryRow(ctx, query, gameID).Scan(&moves)
+	if err != nil {
+		return nil, err
+	}
+
+	return moves, nil
+}
+
 // GetByID retrieves a game from the database by ID
 func (r *GameRepository) GetByID(ctx context.Context, gameID uuid.UUID) (map[string]interface{}, error) {
 	query := `
 		SELECT 
 			gm.id, gm.game_type, gm.player1_id, u1.username, gm.player2_id, u2.username,
-			gm.winner_id, gm.status, gm.started_at, gm.ended_at, gm.game_state, gm.created_at
+			gm.winner_id, gm.status, gm.started_at, gm.ended_at, gm.game_state, gm.settings, gm.created_at
 		FROM game_matches gm
 		INNER JOIN users u1 ON gm.player1_id = u1.id
 		INNER JOIN users u2 ON gm.player2_id = u2.id
@@ -105,12 +589,12 @@ func (r *GameRepository) GetByID(ctx context.Context, gameID uuid.UUID) (map[str
 		winnerID                           *uuid.UUID
 		startedAt, createdAt               time.Time
 		endedAt                            *time.Time
-		gameState                          []byte
+		gameState, settings                []byte
 	)
 
 	err := r.db.QueryRow(ctx, query, gameID).Scan(
 		&id, &gameType, &player1ID, &player1Name, &player2ID, &player2Name,
-		&winnerID, &status, &startedAt, &endedAt, &gameState, &createdAt,
+		&winnerID, &status, &startedAt, &endedAt, &gameState, &settings, &createdAt,
 	)
 	if err != nil {
 		return nil, err
@@ -128,6 +612,7 @@ func (r *GameRepository) GetByID(ctx context.Context, gameID uuid.UUID) (map[str
 		"started_at":   startedAt,
 		"ended_at":     endedAt,
 		"game_state":   gameState,
+		"settings":     settings,
 		"created_at":   createdAt,
 	}
 
@@ -5,36 +5,48 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type PlayerStats struct {
-	ID           uuid.UUID `json:"id"`
-	UserID       uuid.UUID `json:"user_id"`
-	GameType     string    `json:"game_type"`
-	Wins         int       `json:"wins"`
-	Losses       int       `json:"losses"`
-	Draws        int       `json:"draws"`
-	CurrentStreak int      `json:"current_streak"`
-	BestStreak   int       `json:"best_streak"`
-	TotalGames   int       `json:"total_games"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID             uuid.UUID `json:"id"`
+	UserID         uuid.UUID `json:"user_id"`
+	GameType       string    `json:"game_type"`
+	Wins           int       `json:"wins"`
+	Losses         int       `json:"losses"`
+	Draws          int       `json:"draws"`
+	CurrentStreak  int       `json:"current_streak"`
+	BestStreak     int       `json:"best_streak"`
+	TotalGames     int       `json:"total_games"`
+	FirstPlaces    int       `json:"first_places"` // Multiplayer finishes, ties included
+	SecondPlaces   int       `json:"second_places"`
+	ThirdPlaces    int       `json:"third_places"`
+	FirstMoveGames int       `json:"first_move_games"` // Two-player games in which the player moved first
+	FirstMoveWins  int       `json:"first_move_wins"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
 }
 
 type StatsRepository struct {
-	db *pgxpool.Pool
+	db DBTX
 }
 
 func NewStatsRepository(db *pgxpool.Pool) *StatsRepository {
 	return &StatsRepository{db: db}
 }
 
+// WithTx returns a copy of the repository that runs its queries in tx
+func (r *StatsRepository) WithTx(tx pgx.Tx) *StatsRepository {
+	return &StatsRepository{db: tx}
+}
+
 // GetOrCreateStats gets or creates player stats for a game type
 func (r *StatsRepository) GetOrCreateStats(ctx context.Context, userID uuid.UUID, gameType string) (*PlayerStats, error) {
 	// Try to get existing stats
 	query := `
-		SELECT id, user_id, game_type, wins, losses, draws, current_streak, best_streak, total_games, created_at, updated_at
+		SELECT id, user_id, game_type, wins, losses, draws, current_streak, best_streak, total_games,
+		       first_places, second_places, third_places, first_move_games, first_move_wins, created_at, updated_at
 		FROM player_stats
 		WHERE user_id = $1 AND game_type = $2
 	`
@@ -50,6 +62,11 @@ func (r *StatsRepository) GetOrCreateStats(ctx context.Context, userID uuid.UUID
 		&stats.CurrentStreak,
 		&stats.BestStreak,
 		&stats.TotalGames,
+		&stats.FirstPlaces,
+		&stats.SecondPlaces,
+		&stats.ThirdPlaces,
+		&stats.FirstMoveGames,
+		&stats.FirstMoveWins,
 		&stats.CreatedAt,
 		&stats.UpdatedAt,
 	)
@@ -62,7 +79,8 @@ func (r *StatsRepository) GetOrCreateStats(ctx context.Context, userID uuid.UUID
 	insertQuery := `
 		INSERT INTO player_stats (id, user_id, game_type, wins, losses, draws, current_streak, best_streak, total_games, created_at, updated_at)
 		VALUES ($1, $2, $3, 0, 0, 0, 0, 0, 0, $4, $4)
-		RETURNING id, user_id, game_type, wins, losses, draws, current_streak, best_streak, total_games, created_at, updated_at
+		RETURNING id, user_id, game_type, wins, losses, draws, current_streak, best_streak, total_games,
+		          first_places, second_places, third_places, first_move_games, first_move_wins, created_at, updated_at
 	`
 
 	now := time.Now()
@@ -76,6 +94,11 @@ func (r *StatsRepository) GetOrCreateStats(ctx context.Context, userID uuid.UUID
 		&stats.CurrentStreak,
 		&stats.BestStreak,
 		&stats.TotalGames,
+		&stats.FirstPlaces,
+		&stats.SecondPlaces,
+		&stats.ThirdPlaces,
+		&stats.FirstMoveGames,
+		&stats.FirstMoveWins,
 		&stats.CreatedAt,
 		&stats.UpdatedAt,
 	)
@@ -121,6 +144,43 @@ func (r *StatsRepository) UpdateStats(ctx context.Context, userID uuid.UUID, gam
 	return err
 }
 
+// RecordPlacement counts a top-three finish in a multiplayer game; other placements are not tracked
+func (r *StatsRepository) RecordPlacement(ctx context.Context, userID uuid.UUID, gameType string, placement int) error {
+	var column string
+	switch placement {
+	case 1:
+		column = "first_places"
+	case 2:
+		column = "second_places"
+	case 3:
+		column = "third_places"
+	default:
+		return nil
+	}
+
+	query := `UPDATE player_stats SET ` + column + ` = ` + column + ` + 1, updated_at = $3 WHERE user_id = $1 AND game_type = $2`
+	_, err := r.db.Exec(ctx, query, userID, gameType, time.Now())
+	return err
+}
+
+// RecordFirstMove counts a two-player game the player moved first in, and whether they won it
+func (r *StatsRepository) RecordFirstMove(ctx context.Context, userID uuid.UUID, gameType string, won bool) error {
+	winInc := 0
+	if won {
+		winInc = 1
+	}
+
+	query := `
+		UPDATE player_stats
+		SET first_move_games = first_move_games + 1,
+			first_move_wins = first_move_wins + $3,
+			updated_at = $4
+		WHERE user_id = $1 AND game_type = $2
+	`
+	_, err := r.db.Exec(ctx, query, userID, gameType, winInc, time.Now())
+	return err
+}
+
 // LeaderboardEntry represents a player on the leaderboard
 type LeaderboardEntry struct {
 	UserID      uuid.UUID `json:"user_id"`
@@ -196,52 +256,60 @@ func (r *StatsRepository) GetLeaderboard(ctx context.Context, gameType string, l
 
 // MatchHistoryEntry represents a match in user's history
 type MatchHistoryEntry struct {
-	ID          uuid.UUID  `json:"id"`
-	GameType    string     `json:"game_type"`
-	Player1ID   uuid.UUID  `json:"player1_id"`
-	Player1Name string     `json:"player1_name"`
-	Player2ID   uuid.UUID  `json:"player2_id"`
-	Player2Name string     `json:"player2_name"`
-	WinnerID    *uuid.UUID `json:"winner_id"`
-	Status      string     `json:"status"`
-	StartedAt   time.Time  `json:"started_at"`
-	EndedAt     *time.Time `json:"ended_at"`
+	ID            uuid.UUID    `json:"id"`
+	GameType      string       `json:"game_type"`
+	Player1ID     uuid.UUID    `json:"player1_id"`
+	Player1Name   string       `json:"player1_name"`
+	Player2ID     uuid.UUID    `json:"player2_id"`
+	Player2Name   string       `json:"player2_name"`
+	WinnerID      *uuid.UUID   `json:"winner_id"`
+	FirstPlayerID *uuid.UUID   `json:"first_player_id,omitempty"` // Who moved first, when recorded
+	Placement     int          `json:"placement,omitempty"`       // The user's finishing position, when recorded
+	Players       int          `json:"players"`                   // Number of players in the game
+	Status        string       `json:"status"`
+	Series        *MatchSeries `json:"series,omitempty"` // Set when the match was part of a series
+	StartedAt     time.Time    `json:"started_at"`
+	EndedAt       *time.Time   `json:"ended_at"`
+}
+
+// MatchSeries is the series a match belongs to, scored from the history owner's side
+type MatchSeries struct {
+	ID           uuid.UUID  `json:"id"`
+	BestOf       int        `json:"best_of"`
+	Game         int        `json:"game"`          // Number of the match in the series
+	Wins         int        `json:"wins"`          // Series games won by the user
+	OpponentWins int        `json:"opponent_wins"` // Series games won by the opponent
+	Draws        int        `json:"draws"`
+	WinnerID     *uuid.UUID `json:"winner_id,omitempty"`
+	Status       string     `json:"status"` // in_progress, completed or abandoned
 }
 
 // GetMatchHistory retrieves match history for a user, optionally filtered by game type
 func (r *StatsRepository) GetMatchHistory(ctx context.Context, userID uuid.UUID, gameType string, limit int) ([]MatchHistoryEntry, error) {
-	var query string
-	var args []interface{}
-
-	if gameType == "" || gameType == "all" {
-		query = `
-			SELECT gm.id, gm.game_type, gm.player1_id, u1.username, gm.player2_id, u2.username,
-				   gm.winner_id, gm.status, gm.started_at, gm.ended_at
-			FROM game_matches gm
-			INNER JOIN users u1 ON gm.player1_id = u1.id
-			INNER JOIN users u2 ON gm.player2_id = u2.id
-			WHERE (gm.player1_id = $1 OR gm.player2_id = $1) AND gm.status = 'completed'
-			ORDER BY gm.started_at DESC
-			LIMIT $2
-		`
-		args = []interface{}{userID, limit}
-	} else {
-		query = `
-			SELECT gm.id, gm.game_type, gm.player1_id, u1.username, gm.player2_id, u2.username,
-				   gm.winner_id, gm.status, gm.started_at, gm.ended_at
-			FROM game_matches gm
-			INNER JOIN users u1 ON gm.player1_id = u1.id
-			INNER JOIN users u2 ON gm.player2_id = u2.id
-			WHERE (gm.player1_id = $1 OR gm.player2_id = $1) 
-			  AND gm.game_type = $2 
-			  AND gm.status = 'completed'
-			ORDER BY gm.started_at DESC
-			LIMIT $3
-		`
-		args = []interface{}{userID, gameType, limit}
-	}
+	// Player 1 and 2 are seats 0 and 1; game_participants also holds the other seats of
+	// multiplayer games. Series scores are the current score of the whole series.
+	query := `
+		SELECT gm.id, gm.game_type, gm.player1_id, u1.username, gm.player2_id, u2.username,
+			   gm.winner_id, gm.first_player_id, COALESCE(me.placement, 0),
+			   GREATEST((SELECT COUNT(*) FROM game_participants gp WHERE gp.game_id = gm.id), 2),
+			   gm.status, gm.started_at, gm.ended_at,
+			   gs.id, gs.best_of, gm.series_game,
+			   CASE WHEN gs.player1_id = $1 THEN gs.player1_wins ELSE gs.player2_wins END,
+			   CASE WHEN gs.player1_id = $1 THEN gs.player2_wins ELSE gs.player1_wins END,
+			   gs.draws, gs.winner_id, gs.status
+		FROM game_matches gm
+		INNER JOIN users u1 ON gm.player1_id = u1.id
+		INNER JOIN users u2 ON gm.player2_id = u2.id
+		LEFT JOIN game_participants me ON me.game_id = gm.id AND me.user_id = $1
+		LEFT JOIN game_series gs ON gs.id = gm.series_id
+		WHERE (gm.player1_id = $1 OR gm.player2_id = $1 OR me.user_id IS NOT NULL)
+		  AND ($2 = '' OR $2 = 'all' OR gm.game_type = $2)
+		  AND gm.status = 'completed'
+		ORDER BY gm.started_at DESC
+		LIMIT $3
+	`
 
-	rows, err := r.db.Query(ctx, query, args...)
+	rows, err := r.db.Query(ctx, query, userID, gameType, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -250,6 +318,10 @@ func (r *StatsRepository) GetMatchHistory(ctx context.Context, userID uuid.UUID,
 	var entries []MatchHistoryEntry
 	for rows.Next() {
 		var entry MatchHistoryEntry
+		var seriesID *uuid.UUID
+		var series MatchSeries
+		var seriesBestOf, seriesGame, seriesWins, seriesOpponentWins, seriesDraws *int
+		var seriesStatus *string
 		err := rows.Scan(
 			&entry.ID,
 			&entry.GameType,
@@ -258,16 +330,47 @@ func (r *StatsRepository) GetMatchHistory(ctx context.Context, userID uuid.UUID,
 			&entry.Player2ID,
 			&entry.Player2Name,
 			&entry.WinnerID,
+			&entry.FirstPlayerID,
+			&entry.Placement,
+			&entry.Players,
 			&entry.Status,
 			&entry.StartedAt,
 			&entry.EndedAt,
+			&seriesID,
+			&seriesBestOf,
+			&seriesGame,
+			&seriesWins,
+			&seriesOpponentWins,
+			&seriesDraws,
+			&series.WinnerID,
+			&seriesStatus,
 		)
 		if err != nil {
 			return nil, err
 		}
+		if seriesID != nil {
+			series.ID = *seriesID
+			series.BestOf = derefInt(seriesBestOf)
+			series.Game = derefInt(seriesGame)
+			series.Wins = derefInt(seriesWins)
+			series.OpponentWins = derefInt(seriesOpponentWins)
+			series.Draws = derefInt(seriesDraws)
+			if seriesStatus != nil {
+				series.Status = *seriesStatus
+			}
+			entry.Series = &series
+		}
 		entries = append(entries, entry)
 	}
 
 	return entries, rows.Err()
 }
 
+
+// derefInt returns the value of a nullable integer column, or 0 for NULL
+func derefInt(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
@@ -22,10 +22,19 @@ func NewTournamentRepository(db *pgxpool.Pool) *TournamentRepository {
 // Create creates a new tournament
 func (r *TournamentRepository) Create(ctx context.Context, tournament *domain.Tournament) error {
 	query := `
-		INSERT INTO tournaments (id, room_id, name, game_type, tournament_type, status, max_participants, is_private, join_code, total_rounds, created_by, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		INSERT INTO tournaments (id, room_id, name, game_type, tournament_type, status, max_participants, is_private, join_code, total_rounds, created_by, created_at, updated_at, game_settings)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	`
 
+	var gameSettingsJSON []byte
+	if tournament.GameSettings != nil {
+		var err error
+		gameSettingsJSON, err = json.Marshal(tournament.GameSettings)
+		if err != nil {
+			return err
+		}
+	}
+
 	tournament.ID = uuid.New()
 	tournament.CreatedAt = time.Now()
 	tournament.UpdatedAt = time.Now()
@@ -45,6 +54,7 @@ func (r *TournamentRepository) Create(ctx context.Context, tournament *domain.To
 		tournament.CreatedBy,
 		tournament.CreatedAt,
 		tournament.UpdatedAt,
+		gameSettingsJSON,
 	)
 
 	return err
@@ -54,13 +64,13 @@ func (r *TournamentRepository) Create(ctx context.Context, tournament *domain.To
 func (r *TournamentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Tournament, error) {
 	query := `
 		SELECT id, room_id, name, game_type, tournament_type, status, max_participants, is_private, join_code,
-		       total_rounds, current_round, bracket_data, winner_id, created_by, started_at, ended_at, created_at, updated_at
+		       total_rounds, current_round, bracket_data, winner_id, created_by, started_at, ended_at, created_at, updated_at, game_settings
 		FROM tournaments
 		WHERE id = $1
 	`
 
 	var tournament domain.Tournament
-	var bracketDataJSON []byte
+	var bracketDataJSON, gameSettingsJSON []byte
 	var winnerID *uuid.UUID
 	var startedAt, endedAt *time.Time
 	var joinCode *string
@@ -84,6 +94,7 @@ func (r *TournamentRepository) GetByID(ctx context.Context, id uuid.UUID) (*doma
 		&endedAt,
 		&tournament.CreatedAt,
 		&tournament.UpdatedAt,
+		&gameSettingsJSON,
 	)
 
 	if err == pgx.ErrNoRows {
@@ -110,6 +121,14 @@ func (r *TournamentRepository) GetByID(ctx context.Context, id uuid.UUID) (*doma
 		tournament.BracketData = &bracketData
 	}
 
+	if len(gameSettingsJSON) > 0 {
+		var gameSettings domain.GameSettings
+		if err := json.Unmarshal(gameSettingsJSON, &gameSettings); err != nil {
+			return nil, err
+		}
+		tournament.GameSettings = &gameSettings
+	}
+
 	return &tournament, nil
 }
 
@@ -117,13 +136,13 @@ func (r *TournamentRepository) GetByID(ctx context.Context, id uuid.UUID) (*doma
 func (r *TournamentRepository) GetByRoomID(ctx context.Context, roomID uuid.UUID) (*domain.Tournament, error) {
 	query := `
 		SELECT id, room_id, name, game_type, tournament_type, status, max_participants, is_private, join_code,
-		       total_rounds, bracket_data, winner_id, created_by, started_at, ended_at, created_at, updated_at
+		       total_rounds, bracket_data, winner_id, created_by, started_at, ended_at, created_at, updated_at, game_settings
 		FROM tournaments
 		WHERE room_id = $1
 	`
 
 	var tournament domain.Tournament
-	var bracketDataJSON []byte
+	var bracketDataJSON, gameSettingsJSON []byte
 	var winnerID *uuid.UUID
 	var startedAt, endedAt *time.Time
 	var joinCode *string
@@ -146,6 +165,7 @@ func (r *TournamentRepository) GetByRoomID(ctx context.Context, roomID uuid.UUID
 		&endedAt,
 		&tournament.CreatedAt,
 		&tournament.UpdatedAt,
+		&gameSettingsJSON,
 	)
 
 	if err == pgx.ErrNoRows {
@@ -172,6 +192,14 @@ func (r *TournamentRepository) GetByRoomID(ctx context.Context, roomID uuid.UUID
 		tournament.BracketData = &bracketData
 	}
 
+	if len(gameSettingsJSON) > 0 {
+		var gameSettings domain.GameSettings
+		if err := json.Unmarshal(gameSettingsJSON, &gameSettings); err != nil {
+			return nil, err
+		}
+		tournament.GameSettings = &gameSettings
+	}
+
 	return &tournament, nil
 }
 
@@ -179,7 +207,7 @@ func (r *TournamentRepository) GetByRoomID(ctx context.Context, roomID uuid.UUID
 func (r *TournamentRepository) List(ctx context.Context, status *domain.TournamentStatus, limit int) ([]domain.Tournament, error) {
 	query := `
 		SELECT id, room_id, name, game_type, tournament_type, status, max_participants, is_private, join_code,
-		       total_rounds, current_round, bracket_data, winner_id, created_by, started_at, ended_at, created_at, updated_at
+		       total_rounds, current_round, bracket_data, winner_id, created_by, started_at, ended_at, created_at, updated_at, game_settings
 		FROM tournaments
 	`
 
@@ -203,7 +231,7 @@ func (r *TournamentRepository) List(ctx context.Context, status *domain.Tourname
 	var tournaments []domain.Tournament
 	for rows.Next() {
 		var tournament domain.Tournament
-		var bracketDataJSON []byte
+		var bracketDataJSON, gameSettingsJSON []byte
 		var winnerID *uuid.UUID
 		var startedAt, endedAt *time.Time
 		var joinCode *string
@@ -227,6 +255,7 @@ func (r *TournamentRepository) List(ctx context.Context, status *domain.Tourname
 			&endedAt,
 			&tournament.CreatedAt,
 			&tournament.UpdatedAt,
+			&gameSettingsJSON,
 		)
 		if err != nil {
 			return nil, err
@@ -249,6 +278,14 @@ func (r *TournamentRepository) List(ctx context.Context, status *domain.Tourname
 			tournament.BracketData = &bracketData
 		}
 
+		if len(gameSettingsJSON) > 0 {
+			var gameSettings domain.GameSettings
+			if err := json.Unmarshal(gameSettingsJSON, &gameSettings); err != nil {
+				return nil, err
+			}
+			tournament.GameSettings = &gameSettings
+		}
+
 		tournaments = append(tournaments, tournament)
 	}
 
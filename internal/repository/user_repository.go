@@ -12,13 +12,18 @@ import (
 )
 
 type UserRepository struct {
-	db *pgxpool.Pool
+	db DBTX
 }
 
 func NewUserRepository(db *pgxpool.Pool) *UserRepository {
 	return &UserRepository{db: db}
 }
 
+// WithTx returns a copy of the repository that runs its queries in tx
+func (r *UserRepository) WithTx(tx pgx.Tx) *UserRepository {
+	return &UserRepository{db: tx}
+}
+
 func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 	query := `
 		INSERT INTO users (id, username, email, password_hash, elo_rating, created_at, updated_at)
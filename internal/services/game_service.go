@@ -3,22 +3,156 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/arenamatch/playforge/internal/domain"
 	"github.com/arenamatch/playforge/internal/game"
 	"github.com/arenamatch/playforge/internal/repository"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/redis/go-redis/v9"
 )
 
+const (
+	gameTTL          = 4 * time.Hour // Games expire from Redis after 4 hours without activity
+	maxSaveAttempts  = 5             // Attempts for a read-modify-write before reporting a conflict
+	saveRetryBackoff = 10 * time.Millisecond
+
+	clockDeadlinesKey  = "games:clock_deadlines" // Sorted set of game IDs scored by flag-fall time (unix ms)
+	clockSweepInterval = time.Second
+
+	disconnectDeadlinesKey      = "games:disconnect_deadlines" // Sorted set of "gameID:playerID" scored by forfeit time (unix ms)
+	defaultReconnectGracePeriod = 60 * time.Second
+
+	botMoveDelay = 500 * time.Millisecond // Pause before a bot replies so its moves are visible
+
+	seriesNextGameDelay = 3 * time.Second // Pause between the games of a series so players see the result
+
+	maxClientMoveIDLength = 64 // Longest client_move_id accepted with a move
+
+	checkpointPendingKey = "games:checkpoint_pending" // Sorted set of game IDs scored by when they were last saved (unix ms)
+	checkpointInterval   = 5 * time.Second
+
+	gameEventsStream     = "games:events" // Stream of every game's events, read by the event consumer groups
+	gameEventsMaxLen     = 100000         // Approximate length the consumer stream is trimmed to
+	eventConsumerBlock   = 5 * time.Second
+	eventConsumerBatch   = 50
+	eventConsumerMinIdle = time.Minute // Pending events unacknowledged this long are delivered again
+
+	outboxDispatchInterval = time.Second
+	outboxBatch            = 50
+	outboxMaxAttempts      = 10              // Failed attempts before an outbox entry is dead-lettered
+	outboxRetryBase        = 5 * time.Second // Delay after the first failure, doubling with each attempt
+	outboxRetryMax         = time.Hour
+)
+
+// Side effects of a completed game, applied from the completion outbox
+const (
+	outboxStats         = "stats"
+	outboxTournament    = "tournament"
+	outboxNotifications = "notifications"
+)
+
+// saveGameScript atomically replaces a game only if its stored version still matches the version
+// it was loaded at, appending the save's move records to the move log and its events to the
+// game's event stream and the stream read by the event consumers in the same step. A move a
+// client tagged with an ID is remembered with the version it produced.
+// KEYS: game key, move log key, game event stream, consumer event stream, client move IDs.
+// ARGV: game JSON, expected version, TTL (ms), consumer stream length, client move ID ('' for
+// none), number of move records, move record JSON..., event JSON...
+var saveGameScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if current then
+	local version = cjson.decode(current)['version']
+	if (tonumber(version) or 0) ~= tonumber(ARGV[2]) then
+		return 0
+	end
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[3])
+local records = tonumber(ARGV[6])
+if records > 0 then
+	for i = 7, 6 + records do
+		redis.call('RPUSH', KEYS[2], ARGV[i])
+	end
+	redis.call('PEXPIRE', KEYS[2], ARGV[3])
+end
+if #ARGV > 6 + records then
+	for i = 7 + records, #ARGV do
+		redis.call('XADD', KEYS[3], '*', 'event', ARGV[i])
+		redis.call('XADD', KEYS[4], 'MAXLEN', '~', ARGV[4], '*', 'event', ARGV[i])
+	end
+	redis.call('PEXPIRE', KEYS[3], ARGV[3])
+end
+if ARGV[5] ~= '' then
+	redis.call('HSET', KEYS[5], ARGV[5], tonumber(ARGV[2]) + 1)
+end
+redis.call('PEXPIRE', KEYS[5], ARGV[3])
+return 1
+`)
+
+// restoreGameScript puts a game restored from its checkpoint back into Redis with its move log,
+// unless the game was saved again in the meantime.
+// KEYS: game key, move log key. ARGV: game JSON, TTL (ms), move record JSON...
+var restoreGameScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+redis.call('DEL', KEYS[2])
+for i = 3, #ARGV do
+	redis.call('RPUSH', KEYS[2], ARGV[i])
+end
+redis.call('PEXPIRE', KEYS[2], ARGV[2])
+return 1
+`)
+
+// clearCheckpointScript removes a game from the pending checkpoints unless it was saved again
+// after its checkpoint was taken. KEYS: pending set. ARGV: game ID, score the checkpoint was taken at.
+var clearCheckpointScript = redis.NewScript(`
+local score = redis.call('ZSCORE', KEYS[1], ARGV[1])
+if score and tonumber(score) <= tonumber(ARGV[2]) then
+	redis.call('ZREM', KEYS[1], ARGV[1])
+end
+return 1
+`)
+
+// gameMutation checks a command against a loaded game and records what it changes as events
+// through emit, which applies each event to the game. It may return errGameUnchanged (or emit
+// nothing) to skip the save.
+type gameMutation func(g *game.Game, emit emitFunc) error
+
+// emitFunc applies an event to the game being updated and adds it to the save
+type emitFunc func(eventType game.EventType, playerID uuid.UUID, at time.Time, data interface{}) error
+
+// clientMove identifies a move by the ID its client tagged it with, so a resent copy is recognized
+type clientMove struct {
+	playerID uuid.UUID
+	id       string
+}
+
+// field returns the move's field in the game's client move hash
+func (m clientMove) field() string {
+	return m.playerID.String() + ":" + m.id
+}
+
+// errGameUnchanged signals that a mutation had nothing to change
+var errGameUnchanged = errors.New("game unchanged")
+
 type GameService struct {
-	redisClient        *redis.Client
-	statsService       *StatsService
-	gameRepo           *repository.GameRepository
-	tournamentService  TournamentServiceInterface // Interface to avoid circular dependency
+	redisClient         *redis.Client
+	statsService        *StatsService
+	gameRepo            *repository.GameRepository
+	tournamentService   TournamentServiceInterface // Interface to avoid circular dependency
+	roomService         RoomServiceInterface       // Moves rooms on to rematches and series games
+	notificationService *NotificationService       // Tells tournament players how their games ended
+	reconnectGrace      time.Duration              // How long a disconnected player has to come back
 }
 
 // TournamentServiceInterface defines the methods game service needs from tournament service
@@ -26,21 +160,61 @@ type TournamentServiceInterface interface {
 	AdvanceWinner(ctx context.Context, tournamentID uuid.UUID, matchID uuid.UUID, winnerID uuid.UUID) error
 	CreateGamesForNextRound(ctx context.Context, tournamentID uuid.UUID) error
 	ListTournaments(ctx context.Context, status *domain.TournamentStatus, limit int) ([]domain.Tournament, error)
+	GetTournament(ctx context.Context, tournamentID uuid.UUID) (*domain.Tournament, error)
+}
+
+// RoomServiceInterface defines the methods game service needs from room service
+type RoomServiceInterface interface {
+	SetCurrentGame(ctx context.Context, roomID, gameID uuid.UUID) error
 }
 
 func NewGameService(redisClient *redis.Client, statsService *StatsService, gameRepo *repository.GameRepository) *GameService {
 	return &GameService{
-		redisClient:  redisClient,
-		statsService: statsService,
-		gameRepo:     gameRepo,
+		redisClient:    redisClient,
+		statsService:   statsService,
+		gameRepo:       gameRepo,
+		reconnectGrace: defaultReconnectGracePeriod,
+	}
+}
+
+// SetReconnectGracePeriod sets how long a disconnected player has to reconnect before the game is forfeited
+func (s *GameService) SetReconnectGracePeriod(grace time.Duration) {
+	if grace > 0 {
+		s.reconnectGrace = grace
 	}
 }
 
+// moveLogKey returns the Redis key holding a game's move log
+func moveLogKey(gameID uuid.UUID) string {
+	return fmt.Sprintf("game:%s:moves", gameID.String())
+}
+
+// clientMovesKey returns the Redis key of the hash remembering which version each client move ID
+// of a game produced
+func clientMovesKey(gameID uuid.UUID) string {
+	return fmt.Sprintf("game:%s:client_moves", gameID.String())
+}
+
+// gameEventsKey returns the Redis key of a game's event stream
+func gameEventsKey(gameID uuid.UUID) string {
+	return fmt.Sprintf("game:%s:events", gameID.String())
+}
+
 // SetTournamentService sets the tournament service (called after initialization to avoid circular dependency)
 func (s *GameService) SetTournamentService(tournamentService TournamentServiceInterface) {
 	s.tournamentService = tournamentService
 }
 
+// SetNotificationService sets the notification service (to avoid circular dependency)
+func (s *GameService) SetNotificationService(notificationService *NotificationService) {
+	s.notificationService = notificationService
+}
+
+// SetRoomService sets the room service (called after initialization to avoid circular dependency)
+func (s *GameService) SetRoomService(roomService RoomServiceInterface) {
+	s.roomService = roomService
+}
+
 // CreateGame creates a new game with default settings
 func (s *GameService) CreateGame(ctx context.Context, gameType game.GameType, player1ID uuid.UUID, player1Name string) (*game.Game, error) {
 	return s.CreateGameWithSettings(ctx, gameType, player1ID, player1Name, nil)
@@ -48,48 +222,28 @@ func (s *GameService) CreateGame(ctx context.Context, gameType game.GameType, pl
 
 // CreateGameWithSettings creates a new game with custom settings
 func (s *GameService) CreateGameWithSettings(ctx context.Context, gameType game.GameType, player1ID uuid.UUID, player1Name string, settings interface{}) (*game.Game, error) {
+	return s.createGame(ctx, gameType, player1ID, player1Name, settings, nil, false, nil)
+}
+
+// CreateRoomGame creates a new game for a room. Rated games are forfeited when a player
+// fails to reconnect; unrated (casual) games are abandoned instead. A non-nil series makes the
+// game the first of a best-of-N series, whose later games start as each one ends.
+func (s *GameService) CreateRoomGame(ctx context.Context, roomID uuid.UUID, rated bool, series *game.Series, gameType game.GameType, player1ID uuid.UUID, player1Name string, settings interface{}) (*game.Game, error) {
+	return s.createGame(ctx, gameType, player1ID, player1Name, settings, &roomID, rated, series)
+}
+
+func (s *GameService) createGame(ctx context.Context, gameType game.GameType, player1ID uuid.UUID, player1Name string, settings interface{}, roomID *uuid.UUID, rated bool, series *game.Series) (*game.Game, error) {
 	gameID := uuid.New()
 	now := time.Now()
 
-	// Convert settings to map for easier access in game constructors
-	var settingsMap map[string]interface{}
-	if settings != nil {
-		// Marshal and unmarshal to convert struct to map
-		settingsJSON, err := json.Marshal(settings)
-		if err == nil {
-			json.Unmarshal(settingsJSON, &settingsMap)
-			fmt.Printf("Game settings received: %+v\n", settingsMap)
-		}
+	settingsMap, err := game.DefaultRegistry.ValidateSettings(gameType, settingsToMap(settings))
+	if err != nil {
+		return nil, err
 	}
 
-	var gameState game.GameState
-	switch gameType {
-	case game.GameTypeTicTacToe:
-		if settingsMap != nil {
-			gameState = game.NewTicTacToeStateWithSettings(player1ID, uuid.Nil, settingsMap)
-		} else {
-			gameState = game.NewTicTacToeState(player1ID, uuid.Nil)
-		}
-	case game.GameTypeConnect4:
-		if settingsMap != nil {
-			gameState = game.NewConnect4StateWithSettings(player1ID, uuid.Nil, settingsMap)
-		} else {
-			gameState = game.NewConnect4State(player1ID, uuid.Nil)
-		}
-	case game.GameTypeRockPaperScissors:
-		if settingsMap != nil {
-			gameState = game.NewRPSStateWithSettings(player1ID, uuid.Nil, settingsMap)
-		} else {
-			gameState = game.NewRPSState(player1ID, uuid.Nil)
-		}
-	case game.GameTypeDotsAndBoxes:
-		if settingsMap != nil {
-			gameState = game.NewDotsAndBoxesStateWithSettings(player1ID, uuid.Nil, settingsMap)
-		} else {
-			gameState = game.NewDotsAndBoxesState(player1ID, uuid.Nil)
-		}
-	default:
-		return nil, fmt.Errorf("unsupported game type: %s", gameType)
+	gameState, err := game.DefaultRegistry.NewState(gameType, player1ID, uuid.Nil, settingsMap)
+	if err != nil {
+		return nil, err
 	}
 
 	g := &game.Game{
@@ -98,16 +252,23 @@ func (s *GameService) CreateGameWithSettings(ctx context.Context, gameType game.
 		Status:      game.GameStatusWaiting,
 		Player1ID:   player1ID,
 		Player1Name: player1Name,
+		Seats:       []game.Seat{{Index: 0, PlayerID: player1ID, Name: player1Name}},
+		SeatCount:   game.PlayersFromSettings(settingsMap),
 		CurrentTurn: player1ID,
 		State:       gameState,
+		Settings:    settingsMap,
+		Clock:       game.NewGameClock(game.TimeControlFromSettings(settingsMap), player1ID),
 		Spectators:  []game.Spectator{}, // Initialize as empty slice, not nil
+		RoomID:      roomID,
+		Rated:       rated,
+		Series:      series,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
 
 	// Save to Redis
 	fmt.Printf("Saving game %s to Redis...\n", gameID.String())
-	if err := s.SaveGame(ctx, g); err != nil {
+	if err := s.saveNewGame(ctx, g); err != nil {
 		fmt.Printf("Error saving game: %v\n", err)
 		return nil, err
 	}
@@ -116,46 +277,73 @@ func (s *GameService) CreateGameWithSettings(ctx context.Context, gameType game.
 	return g, nil
 }
 
+// settingsToMap converts typed settings (e.g. *domain.GameSettings) to the map form used by the registry
+func settingsToMap(settings interface{}) map[string]interface{} {
+	var settingsMap map[string]interface{}
+	if settings != nil {
+		// Marshal and unmarshal to convert struct to map
+		settingsJSON, err := json.Marshal(settings)
+		if err == nil {
+			json.Unmarshal(settingsJSON, &settingsMap)
+			fmt.Printf("Game settings received: %+v\n", settingsMap)
+		}
+	}
+	return settingsMap
+}
+
 // CreateGameForTournament creates a game with both players already assigned for tournament matches
-func (s *GameService) CreateGameForTournament(ctx context.Context, gameID uuid.UUID, gameType game.GameType, player1ID uuid.UUID, player1Name string, player2ID uuid.UUID, player2Name string, tournamentID uuid.UUID, tournamentRound int) (*game.Game, error) {
+func (s *GameService) CreateGameForTournament(ctx context.Context, gameID uuid.UUID, gameType game.GameType, player1ID uuid.UUID, player1Name string, player2ID uuid.UUID, player2Name string, tournamentID uuid.UUID, tournamentRound int, settings interface{}) (*game.Game, error) {
 	log.Printf("CreateGameForTournament called: gameID=%s, type=%s, player1=%s(%s), player2=%s(%s), tournament=%s, round=%d",
 		gameID, gameType, player1Name, player1ID, player2Name, player2ID, tournamentID, tournamentRound)
 	
 	now := time.Now()
 
-	var gameState game.GameState
-	switch gameType {
-	case game.GameTypeTicTacToe:
-		gameState = game.NewTicTacToeState(player1ID, player2ID)
-	case game.GameTypeConnect4:
-		gameState = game.NewConnect4State(player1ID, player2ID)
-	case game.GameTypeRockPaperScissors:
-		gameState = game.NewRPSState(player1ID, player2ID)
-	case game.GameTypeDotsAndBoxes:
-		gameState = game.NewDotsAndBoxesState(player1ID, player2ID)
-	default:
-		return nil, fmt.Errorf("unsupported game type: %s", gameType)
+	settingsMap, err := game.DefaultRegistry.ValidateSettings(gameType, settingsToMap(settings))
+	if err != nil {
+		return nil, err
+	}
+
+	gameState, err := game.DefaultRegistry.NewState(gameType, player1ID, player2ID, settingsMap)
+	if err != nil {
+		return nil, err
 	}
 
 	g := &game.Game{
 		ID:              gameID,
 		Type:            gameType,
-		Status:          game.GameStatusActive, // Both players assigned, ready to play
 		Player1ID:       player1ID,
 		Player1Name:     player1Name,
 		Player2ID:       player2ID,
 		Player2Name:     player2Name,
-		CurrentTurn:     player1ID, // Player 1 goes first
+		Seats: []game.Seat{
+			{Index: 0, PlayerID: player1ID, Name: player1Name},
+			{Index: 1, PlayerID: player2ID, Name: player2Name},
+		},
 		State:           gameState,
+		Settings:        settingsMap,
 		Spectators:      []game.Spectator{}, // Initialize as empty slice, not nil
 		TournamentID:    &tournamentID,
 		TournamentRound: tournamentRound,
+		Rated:           true,
 		CreatedAt:       now,
 		UpdatedAt:       now,
 	}
 
+	// The first-mover policy may seat player 2 first
+	if err := g.SeatFirstMover(game.ChooseFirstMover(game.FirstMoverFromSettings(settingsMap), player1ID, player2ID, s.firstMoverContext(ctx, g))); err != nil {
+		return nil, err
+	}
+	gameState = g.State
+
+	// Both players are assigned, so the game starts (or enters its setup phase) right away.
+	// Start the first player's clock now so a no-show loses on time.
+	g.Begin(now)
+	if g.Clock = game.NewGameClock(game.TimeControlFromSettings(settingsMap), player1ID, player2ID); g.Clock != nil && g.Status == game.GameStatusActive {
+		g.Clock.Start(g.CurrentTurn, now)
+	}
+
 	// Save to Redis
-	if err := s.SaveGame(ctx, g); err != nil {
+	if err := s.saveNewGame(ctx, g); err != nil {
 		return nil, fmt.Errorf("failed to save tournament game to Redis: %w", err)
 	}
 
@@ -174,341 +362,2218 @@ func (s *GameService) CreateGameForTournament(ctx context.Context, gameID uuid.U
 		log.Printf("WARNING: Marshaled game state is empty during creation for game %s", gameID)
 	}
 
-	if err := s.gameRepo.CreateGame(ctx, gameID, string(gameType), player1ID, player2ID, now, stateData); err != nil {
+	settingsData, _ := json.Marshal(settingsMap)
+	if err := s.gameRepo.CreateGame(ctx, gameID, string(gameType), g.Player1ID, g.Player2ID, now, stateData, settingsData); err != nil {
 		log.Printf("ERROR: Failed to save tournament game to database: %v", err)
 		// Don't fail if DB save fails - Redis is the primary store
 	} else {
 		log.Printf("Successfully saved initial game state for %s (%d bytes)", gameID, len(stateData))
 	}
 
-	log.Printf("Created tournament game %s: %s vs %s (saved to Redis and DB)", gameID, player1Name, player2Name)
+	log.Printf("Created tournament game %s: %s vs %s (saved to Redis and DB)", gameID, g.Player1Name, g.Player2Name)
 
 	return g, nil
 }
 
-// JoinGame allows a second player to join a waiting game
-func (s *GameService) JoinGame(ctx context.Context, gameID, player2ID uuid.UUID, player2Name string) (*game.Game, error) {
-	g, err := s.GetGame(ctx, gameID)
-	if err != nil {
+// JoinGame seats a player in a waiting game. The game starts once every seat is taken.
+func (s *GameService) JoinGame(ctx context.Context, gameID, playerID uuid.UUID, playerName string) (*game.Game, error) {
+	return s.joinGame(ctx, gameID, playerID, playerName, nil)
+}
+
+// AddBot seats a bot in the next free seat of a waiting game
+func (s *GameService) AddBot(ctx context.Context, gameID uuid.UUID, difficulty game.BotDifficulty) (*game.Game, error) {
+	if _, err := game.ParseBotDifficulty(string(difficulty)); err != nil {
 		return nil, err
 	}
+	botID := game.BotPlayerID(difficulty)
+	return s.joinGame(ctx, gameID, botID, game.BotName(difficulty), &game.BotSeat{PlayerID: botID, Difficulty: difficulty})
+}
 
-	if g.Status != game.GameStatusWaiting {
-		return nil, fmt.Errorf("game is not waiting for players")
-	}
+func (s *GameService) joinGame(ctx context.Context, gameID, playerID uuid.UUID, playerName string, bot *game.BotSeat) (*game.Game, error) {
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		if g.Status != game.GameStatusWaiting {
+			return fmt.Errorf("game is not waiting for players")
+		}
+		if bot != nil {
+			if !game.HasBot(g.Type) {
+				return fmt.Errorf("%s cannot be played against a bot", g.Type)
+			}
+			if g.Bot != nil {
+				return fmt.Errorf("game already has a bot")
+			}
+		}
 
-	// Update game with player 2
-	g.Player2ID = player2ID
-	g.Player2Name = player2Name
-	g.Status = game.GameStatusActive
-	now := time.Now()
-	g.StartedAt = &now
-	g.UpdatedAt = now
+		now := time.Now()
+		if err := emit(game.EventJoined, playerID, now, game.JoinedData{Name: playerName, Bot: bot}); err != nil {
+			return err
+		}
+		if !g.Full() {
+			return nil // Wait for the remaining seats
+		}
 
-	// Update game state with player 2
-	switch state := g.State.(type) {
-	case *game.TicTacToeState:
-		state.Player2ID = player2ID
-	case *game.Connect4State:
-		state.Player2ID = player2ID
-	case *game.RPSState:
-		state.Player2ID = player2ID
-	case *game.DotsAndBoxesState:
-		state.Player2ID = player2ID
+		// The first-mover policy picks who starts a two-player game
+		var started game.StartedData
+		if !g.IsMultiplayer() {
+			first := game.ChooseFirstMover(game.FirstMoverFromSettings(g.Settings), g.Player1ID, g.Player2ID, s.firstMoverContext(ctx, g))
+			started.FirstPlayerID = &first
+		}
+		return emit(game.EventStarted, playerID, now, started)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Save to Redis
-	if err := s.SaveGame(ctx, g); err != nil {
-		return nil, err
+	if g.Status == game.GameStatusWaiting {
+		s.PublishGameEvent(ctx, gameID, "player_joined", g)
+		return g, nil
 	}
 
 	// Publish game started event
 	s.PublishGameEvent(ctx, gameID, "game_started", g)
+	s.scheduleBotMove(g)
 
 	return g, nil
 }
 
 // MakeMove processes a player's move
 func (s *GameService) MakeMove(ctx context.Context, gameID, playerID uuid.UUID, move interface{}) (*game.Game, error) {
-	g, err := s.GetGame(ctx, gameID)
-	if err != nil {
-		return nil, err
-	}
+	g, _, err := s.makeMove(ctx, gameID, playerID, move, nil, "")
+	return g, err
+}
 
-	if g.Status != game.GameStatusActive {
-		return nil, game.ErrGameNotActive
-	}
+// MakeMoveAtVersion processes a move only if the game is still at the version the client last saw
+func (s *GameService) MakeMoveAtVersion(ctx context.Context, gameID, playerID uuid.UUID, move interface{}, expectedVersion int64) (*game.Game, error) {
+	g, _, err := s.makeMove(ctx, gameID, playerID, move, &expectedVersion, "")
+	return g, err
+}
 
-	// Validate player is a participant in this game
-	if playerID != g.Player1ID && playerID != g.Player2ID {
-		return nil, fmt.Errorf("you are not a participant in this game - spectators cannot make moves")
+// MoveReceipt tells a client what became of a move it tagged with an ID
+type MoveReceipt struct {
+	ClientMoveID string `json:"client_move_id"`
+	Version      int64  `json:"version"`             // Version of the game the move produced
+	Duplicate    bool   `json:"duplicate,omitempty"` // An earlier copy of the move was applied; this one was not
+}
+
+// MakeMoveWithID processes a move a client tagged with clientMoveID, only if the game is still
+// at expectedVersion when that is given. A move resent with an ID that was already applied (e.g.
+// retried after a dropped connection) is not applied again: the current game is returned with a
+// receipt of the original move.
+func (s *GameService) MakeMoveWithID(ctx context.Context, gameID, playerID uuid.UUID, move interface{}, expectedVersion *int64, clientMoveID string) (*game.Game, *MoveReceipt, error) {
+	if clientMoveID == "" {
+		return nil, nil, fmt.Errorf("client_move_id is required")
 	}
+	if len(clientMoveID) > maxClientMoveIDLength {
+		return nil, nil, fmt.Errorf("client_move_id must be at most %d characters", maxClientMoveIDLength)
+	}
+	return s.makeMove(ctx, gameID, playerID, move, expectedVersion, clientMoveID)
+}
 
-	// Apply move
-	if err := g.State.ApplyMove(playerID, move); err != nil {
-		return nil, err
+func (s *GameService) makeMove(ctx context.Context, gameID, playerID uuid.UUID, move interface{}, expectedVersion *int64, clientMoveID string) (*game.Game, *MoveReceipt, error) {
+	var tag *clientMove
+	if clientMoveID != "" {
+		tag = &clientMove{playerID: playerID, id: clientMoveID}
 	}
 
-	// Check for winner
-	winner, gameOver := g.State.CheckWinner()
-	if gameOver {
-		g.Status = game.GameStatusCompleted
-		g.WinnerID = winner
-		now := time.Now()
-		g.EndedAt = &now
-
-		// Update player stats and ELO ratings
-		if s.statsService != nil {
-			// Check if this is a tournament game
-			if g.TournamentID != nil && g.TournamentRound > 0 {
-				// Tournament game - use progressive bonuses
-				if err := s.statsService.UpdateTournamentGameStats(ctx, string(g.Type), g.Player1ID, g.Player2ID, g.WinnerID, g.TournamentRound); err != nil {
-					fmt.Printf("Error updating tournament game stats: %v\n", err)
-					// Don't fail the move if stats update fails
-				}
-			} else {
-				// Regular casual game
-				if err := s.statsService.UpdateGameStats(ctx, string(g.Type), g.Player1ID, g.Player2ID, g.WinnerID); err != nil {
-					fmt.Printf("Error updating game stats: %v\n", err)
-					// Don't fail the move if stats update fails
-				}
+	var appliedVersion int64 // Version an earlier copy of the move produced
+	g, err := s.updateGameTagged(ctx, gameID, tag, func(g *game.Game, emit emitFunc) error {
+		appliedVersion = 0
+		if tag != nil {
+			version, err := s.redisClient.HGet(ctx, clientMovesKey(gameID), tag.field()).Int64()
+			if err != nil && err != redis.Nil {
+				return fmt.Errorf("failed to check client move ID: %w", err)
+			}
+			if err == nil {
+				appliedVersion = version
+				return errGameUnchanged
 			}
 		}
 
-		// Save completed game to database for match history
-		if s.gameRepo != nil {
-			// Serialize the game state to JSON
-			gameState := g.State.GetState()
-			if gameState == nil {
-				log.Printf("CRITICAL ERROR: Game state is nil for completed game %s (type: %s)", g.ID, g.Type)
-				// This should never happen - log detailed debug info
-				log.Printf("Game details - Player1: %s, Player2: %s, Winner: %v, Status: %s", 
-					g.Player1ID, g.Player2ID, g.WinnerID, g.Status)
-			} else {
-				gameStateData, err := json.Marshal(gameState)
-				if err != nil {
-					log.Printf("CRITICAL ERROR: Failed to marshal game state for game %s: %v", g.ID, err)
-					log.Printf("Game state type: %T, value: %+v", gameState, gameState)
-				} else if len(gameStateData) == 0 {
-					log.Printf("CRITICAL ERROR: Marshaled game state is empty for game %s", g.ID)
-				} else {
-					log.Printf("Saving completed game %s to database with %d bytes of game state", g.ID, len(gameStateData))
-					
-					// Retry logic: Try to save 3 times before giving up
-					var saveErr error
-					for attempt := 1; attempt <= 3; attempt++ {
-						saveErr = s.gameRepo.SaveCompletedGame(ctx, g.ID, string(g.Type), g.Player1ID, g.Player2ID, g.WinnerID, g.CreatedAt, g.EndedAt, gameStateData)
-						if saveErr == nil {
-							log.Printf("Successfully saved completed game %s to database on attempt %d", g.ID, attempt)
-							break
-						}
-						log.Printf("ERROR: Failed to save completed game %s to database (attempt %d/3): %v", g.ID, attempt, saveErr)
-						if attempt < 3 {
-							time.Sleep(time.Millisecond * 100 * time.Duration(attempt)) // Exponential backoff
-						}
-					}
-					
-					if saveErr != nil {
-						log.Printf("CRITICAL ERROR: Failed to save game state to database after 3 attempts for game %s", g.ID)
-					}
-				}
-			}
+		if expectedVersion != nil && g.Version != *expectedVersion {
+			return game.ErrVersionConflict
 		}
 
-		// Tournament game: Advance winner to next round
-		// AdvanceWinner will automatically create games for the next round if ready
-		if g.TournamentID != nil && g.WinnerID != nil && s.tournamentService != nil {
-			log.Printf("Tournament game completed - advancing winner %s to next round", g.WinnerID.String())
-			if err := s.tournamentService.AdvanceWinner(ctx, *g.TournamentID, g.ID, *g.WinnerID); err != nil {
-				log.Printf("Error advancing tournament winner: %v", err)
-				// Don't fail the move if advancement fails
+		if g.Status == game.GameStatusPaused {
+			return game.ErrGamePaused
+		}
+		if !g.Status.InProgress() {
+			return game.ErrGameNotActive
+		}
+
+		// Validate player is a participant in this game
+		if !g.IsPlayer(playerID) {
+			return fmt.Errorf("you are not a participant in this game - spectators cannot make moves")
+		}
+
+		// The clock sweeper ends the game shortly after a flag falls; reject moves until then
+		now := time.Now()
+		if g.Clock != nil {
+			if _, expired := g.Clock.Expired(now); expired {
+				return game.ErrTimeExpired
 			}
 		}
+
+		return emit(game.EventMove, playerID, now, move)
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Update current turn
-	g.CurrentTurn = g.State.GetCurrentPlayer()
-	g.UpdatedAt = time.Now()
+	if appliedVersion > 0 {
+		log.Printf("Ignoring resent move %s of player %s in game %s", clientMoveID, playerID, gameID)
+		return g, &MoveReceipt{ClientMoveID: clientMoveID, Version: appliedVersion, Duplicate: true}, nil
+	}
 
-	// Save to Redis
-	if err := s.SaveGame(ctx, g); err != nil {
-		return nil, err
+	if g.Status == game.GameStatusCompleted {
+		s.completeGame(ctx, g)
 	}
 
 	// Publish move event
 	s.PublishGameEvent(ctx, gameID, "game_move", g)
+	s.scheduleBotMove(g)
 
-	return g, nil
+	var receipt *MoveReceipt
+	if tag != nil {
+		receipt = &MoveReceipt{ClientMoveID: clientMoveID, Version: g.Version}
+	}
+	return g, receipt, nil
 }
 
-// GetGame retrieves a game from Redis or falls back to the database
-func (s *GameService) GetGame(ctx context.Context, gameID uuid.UUID) (*game.Game, error) {
-	key := fmt.Sprintf("game:%s", gameID.String())
-	fmt.Printf("Getting game from Redis with key: %s\n", key)
-	data, err := s.redisClient.Get(ctx, key).Result()
-	if err != nil {
-		if err == redis.Nil {
-			fmt.Printf("Game not found in Redis, checking database...\n")
-			// Fall back to database for completed games
-			if s.gameRepo != nil {
-				return s.getGameFromDatabase(ctx, gameID)
-			}
-			return nil, fmt.Errorf("game not found")
-		}
-		fmt.Printf("Redis error: %v\n", err)
-		return nil, err
+// scheduleBotMove lets the game's bot reply in the background when it is the bot's turn
+func (s *GameService) scheduleBotMove(g *game.Game) {
+	if g.Bot == nil || !g.AwaitingMove(g.Bot.PlayerID) {
+		return
 	}
-	fmt.Printf("Game found in Redis: %d bytes\n", len(data))
+	go s.playBotMove(context.Background(), g.ID)
+}
 
-	var g game.Game
-	if err := json.Unmarshal([]byte(data), &g); err != nil {
-		fmt.Printf("Error unmarshaling game: %v\n", err)
-		return nil, fmt.Errorf("failed to unmarshal game: %w", err)
-	}
-	fmt.Printf("Game unmarshaled successfully, Type: %s, Status: %s\n", g.Type, g.Status)
+// playBotMove chooses and plays the bot's move. The move is made against the version the bot
+// looked at, so a game that changed in the meantime is simply re-read.
+func (s *GameService) playBotMove(ctx context.Context, gameID uuid.UUID) {
+	time.Sleep(botMoveDelay)
 
-	// Deserialize game state based on type
-	if err := s.deserializeGameState(&g); err != nil {
-		return nil, err
-	}
+	for attempt := 1; attempt <= maxSaveAttempts; attempt++ {
+		g, err := s.GetGame(ctx, gameID)
+		if err != nil {
+			log.Printf("Bot failed to load game %s: %v", gameID, err)
+			return
+		}
+		if g.Bot == nil || !g.AwaitingMove(g.Bot.PlayerID) {
+			return
+		}
 
-	// Ensure Spectators is never nil (initialize as empty slice if nil)
-	if g.Spectators == nil {
-		g.Spectators = []game.Spectator{}
-	}
+		bot, err := game.NewBot(g.Type, g.Bot.Difficulty, nil)
+		if err != nil {
+			log.Printf("Failed to create bot for game %s: %v", gameID, err)
+			return
+		}
+		move, err := bot.ChooseMove(g.State.Clone(), g.Bot.PlayerID)
+		if err != nil {
+			log.Printf("Bot failed to choose a move in game %s: %v", gameID, err)
+			return
+		}
 
-	fmt.Printf("Returning game %s\n", g.ID.String())
-	return &g, nil
+		_, err = s.MakeMoveAtVersion(ctx, gameID, g.Bot.PlayerID, move, g.Version)
+		if err == nil {
+			return
+		}
+		if !errors.Is(err, game.ErrVersionConflict) {
+			log.Printf("Bot move rejected in game %s: %v", gameID, err)
+			return
+		}
+	}
 }
 
-// getGameFromDatabase retrieves a completed game from the database
-func (s *GameService) getGameFromDatabase(ctx context.Context, gameID uuid.UUID) (*game.Game, error) {
-	log.Printf("Attempting to retrieve game %s from database...", gameID)
-	dbGame, err := s.gameRepo.GetByID(ctx, gameID)
+// Resign ends an active game with the opponent as the winner. In a multiplayer game the player
+// who resigns finishes last and the others are placed by the current standings.
+func (s *GameService) Resign(ctx context.Context, gameID, playerID uuid.UUID) (*game.Game, error) {
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		if err := checkActivePlayer(g, playerID); err != nil {
+			return err
+		}
+
+		return emit(game.EventResigned, playerID, time.Now(), nil)
+	})
 	if err != nil {
-		log.Printf("Game %s not found in database: %v", gameID, err)
-		return nil, fmt.Errorf("game not found")
+		return nil, err
 	}
-	log.Printf("Game %s found in database", gameID)
-	log.Printf("Reconstructing game object from database fields...")
 
-	// Debug: Print all field types
-	for key, value := range dbGame {
-		log.Printf("  Field %s: type=%T, value=%v", key, value, value)
-	}
+	// Stats, match history and tournament advancement
+	s.completeGame(ctx, g)
 
-	// Helper function to convert time fields (handles both time.Time and *time.Time)
-	getTimePtr := func(field interface{}) *time.Time {
-		if field == nil {
-			return nil
+	s.PublishGameEvent(ctx, gameID, "game_resigned", g)
+	return g, nil
+}
+
+// OfferDraw records a draw offer from a player; the opponent can accept or decline it
+func (s *GameService) OfferDraw(ctx context.Context, gameID, playerID uuid.UUID) (*game.Game, error) {
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		if err := checkActivePlayer(g, playerID); err != nil {
+			return err
 		}
-		switch v := field.(type) {
-		case *time.Time:
-			return v
-		case time.Time:
-			return &v
-		default:
-			return nil
+		// Single elimination brackets need a winner
+		if g.TournamentID != nil {
+			return fmt.Errorf("draws cannot be offered in tournament games")
+		}
+		if g.IsMultiplayer() {
+			return fmt.Errorf("draws can only be agreed in two-player games")
+		}
+		if g.DrawOfferedBy != nil {
+			if *g.DrawOfferedBy == playerID {
+				return errGameUnchanged // Already offered
+			}
+			return fmt.Errorf("your opponent has already offered a draw")
 		}
-	}
 
-	// Reconstruct game object
-	g := &game.Game{
-		ID:          dbGame["id"].(uuid.UUID),
-		Type:        game.GameType(dbGame["game_type"].(string)),
-		Status:      game.GameStatus(dbGame["status"].(string)),
-		Player1ID:   dbGame["player1_id"].(uuid.UUID),
-		Player1Name: dbGame["player1_name"].(string),
-		Player2ID:   dbGame["player2_id"].(uuid.UUID),
-		Player2Name: dbGame["player2_name"].(string),
-		CurrentTurn: dbGame["player1_id"].(uuid.UUID), // Default to player1 for completed games
-		CreatedAt:   dbGame["created_at"].(time.Time),
-		StartedAt:   getTimePtr(dbGame["started_at"]),
-		EndedAt:     getTimePtr(dbGame["ended_at"]),
-		Spectators:  []game.Spectator{},
+		return emit(game.EventDrawOffered, playerID, time.Now(), nil)
+	})
+	if err != nil {
+		return nil, err
 	}
-	log.Printf("Game object reconstructed successfully")
 
-	// Set winner ID if present
-	if winnerID, ok := dbGame["winner_id"].(*uuid.UUID); ok && winnerID != nil {
-		g.WinnerID = winnerID
-	}
+	s.PublishGameEvent(ctx, gameID, "draw_offered", g)
+	return g, nil
+}
 
-	// Deserialize game state
-	if gameStateData, ok := dbGame["game_state"].([]byte); ok && len(gameStateData) > 0 {
-		g.StateData = gameStateData
-		if err := s.deserializeGameState(g); err != nil {
-			return nil, err
+// AcceptDraw accepts the opponent's pending draw offer and ends the game as a draw
+func (s *GameService) AcceptDraw(ctx context.Context, gameID, playerID uuid.UUID) (*game.Game, error) {
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		if err := checkActivePlayer(g, playerID); err != nil {
+			return err
 		}
+		if g.DrawOfferedBy == nil || *g.DrawOfferedBy == playerID {
+			return fmt.Errorf("there is no draw offer to accept")
+		}
+
+		return emit(game.EventDrawAgreed, playerID, time.Now(), nil)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	fmt.Printf("Returning game from database: %s\n", g.ID.String())
+	// Stats and match history (a nil winner counts as a draw)
+	s.completeGame(ctx, g)
+
+	s.PublishGameEvent(ctx, gameID, "game_drawn", g)
 	return g, nil
 }
 
-// deserializeGameState deserializes the game state based on game type
-func (s *GameService) deserializeGameState(g *game.Game) error {
-	switch g.Type {
-	case game.GameTypeTicTacToe:
-		fmt.Printf("Deserializing TicTacToe state from %d bytes...\n", len(g.StateData))
-		var state game.TicTacToeState
-		if err := json.Unmarshal(g.StateData, &state); err != nil {
-			fmt.Printf("Error unmarshaling TicTacToe state: %v\n", err)
-			return fmt.Errorf("failed to unmarshal game state: %w", err)
-		}
-		g.State = &state
-		fmt.Printf("TicTacToe state deserialized successfully\n")
-	case game.GameTypeConnect4:
-		fmt.Printf("Deserializing Connect4 state from %d bytes...\n", len(g.StateData))
-		var state game.Connect4State
-		if err := json.Unmarshal(g.StateData, &state); err != nil {
-			fmt.Printf("Error unmarshaling Connect4 state: %v\n", err)
-			return fmt.Errorf("failed to unmarshal game state: %w", err)
-		}
-		g.State = &state
-		fmt.Printf("Connect4 state deserialized successfully\n")
-	case game.GameTypeRockPaperScissors:
-		fmt.Printf("Deserializing RPS state from %d bytes...\n", len(g.StateData))
-		var state game.RPSState
-		if err := json.Unmarshal(g.StateData, &state); err != nil {
-			fmt.Printf("Error unmarshaling RPS state: %v\n", err)
-			return fmt.Errorf("failed to unmarshal game state: %w", err)
-		}
-		g.State = &state
-		fmt.Printf("RPS state deserialized successfully\n")
-	case game.GameTypeDotsAndBoxes:
-		fmt.Printf("Deserializing DotsAndBoxes state from %d bytes...\n", len(g.StateData))
-		var state game.DotsAndBoxesState
-		if err := json.Unmarshal(g.StateData, &state); err != nil {
-			fmt.Printf("Error unmarshaling DotsAndBoxes state: %v\n", err)
-			return fmt.Errorf("failed to unmarshal game state: %w", err)
-		}
-		g.State = &state
-		fmt.Printf("DotsAndBoxes state deserialized successfully\n")
+// DeclineDraw rejects the opponent's pending draw offer
+func (s *GameService) DeclineDraw(ctx context.Context, gameID, playerID uuid.UUID) (*game.Game, error) {
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		if err := checkActivePlayer(g, playerID); err != nil {
+			return err
+		}
+		if g.DrawOfferedBy == nil || *g.DrawOfferedBy == playerID {
+			return fmt.Errorf("there is no draw offer to decline")
+		}
+
+		return emit(game.EventDrawDeclined, playerID, time.Now(), nil)
+	})
+	if err != nil {
+		return nil, err
 	}
-	return nil
+
+	s.PublishGameEvent(ctx, gameID, "draw_declined", g)
+	return g, nil
 }
 
-// SaveGame saves a game to Redis
-func (s *GameService) SaveGame(ctx context.Context, g *game.Game) error {
-	key := fmt.Sprintf("game:%s", g.ID.String())
-	
-	// Serialize the state to JSON
+// RequestTakeback asks the opponent to let the player take back their last move (and any reply
+// to it) in a casual game. A bot opponent agrees straight away.
+func (s *GameService) RequestTakeback(ctx context.Context, gameID, playerID uuid.UUID) (*game.Game, error) {
+	takenBack := false
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		takenBack = false
+		if err := checkTakeback(g, playerID); err != nil {
+			return err
+		}
+		if g.TakebackRequestedBy != nil {
+			if *g.TakebackRequestedBy == playerID {
+				return errGameUnchanged // Already requested
+			}
+			return fmt.Errorf("answer your opponent's takeback request first")
+		}
+		if g.MoveCount == 0 {
+			return fmt.Errorf("you have no move to take back")
+		}
+
+		if g.Bot != nil && g.Bot.PlayerID != playerID {
+			takenBack = true
+			return emit(game.EventTakenBack, playerID, time.Now(), nil)
+		}
+		moves, err := s.GetMoveLog(ctx, g.ID)
+		if err != nil {
+			return err
+		}
+		if game.TakebackPlies(game.ActiveMoves(moves), playerID) == 0 {
+			return fmt.Errorf("you have no move to take back")
+		}
+
+		return emit(game.EventTakebackRequested, playerID, time.Now(), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if takenBack {
+		s.PublishGameEvent(ctx, gameID, "takeback_accepted", g)
+		return g, nil
+	}
+	s.PublishGameEvent(ctx, gameID, "takeback_requested", g)
+	return g, nil
+}
+
+// AcceptTakeback accepts the opponent's takeback request and rewinds the game to before their
+// last move. The takeback is recorded in the move log so replays undo the same moves.
+func (s *GameService) AcceptTakeback(ctx context.Context, gameID, playerID uuid.UUID) (*game.Game, error) {
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		if err := checkTakeback(g, playerID); err != nil {
+			return err
+		}
+		if g.TakebackRequestedBy == nil || *g.TakebackRequestedBy == playerID {
+			return fmt.Errorf("there is no takeback request to accept")
+		}
+
+		return emit(game.EventTakenBack, *g.TakebackRequestedBy, time.Now(), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.PublishGameEvent(ctx, gameID, "takeback_accepted", g)
+	s.scheduleBotMove(g)
+	return g, nil
+}
+
+// DeclineTakeback rejects the opponent's takeback request
+func (s *GameService) DeclineTakeback(ctx context.Context, gameID, playerID uuid.UUID) (*game.Game, error) {
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		if err := checkTakeback(g, playerID); err != nil {
+			return err
+		}
+		if g.TakebackRequestedBy == nil || *g.TakebackRequestedBy == playerID {
+			return fmt.Errorf("there is no takeback request to decline")
+		}
+
+		return emit(game.EventTakebackDeclined, playerID, time.Now(), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.PublishGameEvent(ctx, gameID, "takeback_declined", g)
+	return g, nil
+}
+
+// checkTakeback verifies that a player may ask for or answer a takeback in the game
+func checkTakeback(g *game.Game, playerID uuid.UUID) error {
+	if err := checkActivePlayer(g, playerID); err != nil {
+		return err
+	}
+	if !g.TakebacksAllowed() {
+		return game.ErrTakebacksDisabled
+	}
+	if g.Status != game.GameStatusActive {
+		return fmt.Errorf("moves cannot be taken back during setup")
+	}
+	return nil
+}
+
+// RequestPause asks the opponent to pause a casual game so it can be resumed later. A bot
+// opponent agrees straight away.
+func (s *GameService) RequestPause(ctx context.Context, gameID, playerID uuid.UUID) (*game.Game, error) {
+	paused := false
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		paused = false
+		if err := checkPause(g, playerID); err != nil {
+			return err
+		}
+		if g.PauseRequestedBy != nil {
+			if *g.PauseRequestedBy == playerID {
+				return errGameUnchanged // Already requested
+			}
+			return fmt.Errorf("answer your opponent's pause request first")
+		}
+
+		if g.Bot != nil && g.Bot.PlayerID != playerID {
+			paused = true
+			return emit(game.EventPaused, playerID, time.Now(), game.PausedData{})
+		}
+		return emit(game.EventPauseRequested, playerID, time.Now(), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if paused {
+		s.PublishGameEvent(ctx, gameID, "game_paused", g)
+		return g, nil
+	}
+	s.PublishGameEvent(ctx, gameID, "pause_requested", g)
+	return g, nil
+}
+
+// AcceptPause accepts the opponent's pause request. The clock stops and the game is kept, listed
+// as paused for both players, until they agree to resume it.
+func (s *GameService) AcceptPause(ctx context.Context, gameID, playerID uuid.UUID) (*game.Game, error) {
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		if err := checkPause(g, playerID); err != nil {
+			return err
+		}
+		if g.PauseRequestedBy == nil || *g.PauseRequestedBy == playerID {
+			return fmt.Errorf("there is no pause request to accept")
+		}
+
+		return emit(game.EventPaused, *g.PauseRequestedBy, time.Now(), game.PausedData{})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.PublishGameEvent(ctx, gameID, "game_paused", g)
+	return g, nil
+}
+
+// DeclinePause rejects the opponent's pause request
+func (s *GameService) DeclinePause(ctx context.Context, gameID, playerID uuid.UUID) (*game.Game, error) {
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		if err := checkPause(g, playerID); err != nil {
+			return err
+		}
+		if g.PauseRequestedBy == nil || *g.PauseRequestedBy == playerID {
+			return fmt.Errorf("there is no pause request to decline")
+		}
+
+		return emit(game.EventPauseDeclined, playerID, time.Now(), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.PublishGameEvent(ctx, gameID, "pause_declined", g)
+	return g, nil
+}
+
+// checkPause verifies that a player may ask for or answer a pause in the game
+func checkPause(g *game.Game, playerID uuid.UUID) error {
+	if err := checkActivePlayer(g, playerID); err != nil {
+		return err
+	}
+	if !g.PausesAllowed() {
+		return game.ErrPausesDisabled
+	}
+	return nil
+}
+
+// RequestResume asks the opponent to resume a game the players paused. A bot opponent agrees
+// straight away.
+func (s *GameService) RequestResume(ctx context.Context, gameID, playerID uuid.UUID) (*game.Game, error) {
+	resumed := false
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		resumed = false
+		if err := checkResume(g, playerID); err != nil {
+			return err
+		}
+		if g.ResumeRequestedBy != nil {
+			if *g.ResumeRequestedBy == playerID {
+				return errGameUnchanged // Already requested
+			}
+			return fmt.Errorf("answer your opponent's resume request first")
+		}
+
+		if g.Bot != nil && g.Bot.PlayerID != playerID {
+			resumed = true
+			return emit(game.EventResumed, playerID, time.Now(), nil)
+		}
+		return emit(game.EventResumeRequested, playerID, time.Now(), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resumed {
+		s.gameResumed(ctx, g)
+		return g, nil
+	}
+	s.PublishGameEvent(ctx, gameID, "resume_requested", g)
+	return g, nil
+}
+
+// AcceptResume accepts the opponent's resume request and continues the game where it was paused
+func (s *GameService) AcceptResume(ctx context.Context, gameID, playerID uuid.UUID) (*game.Game, error) {
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		if err := checkResume(g, playerID); err != nil {
+			return err
+		}
+		if g.ResumeRequestedBy == nil || *g.ResumeRequestedBy == playerID {
+			return fmt.Errorf("there is no resume request to accept")
+		}
+
+		return emit(game.EventResumed, playerID, time.Now(), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.gameResumed(ctx, g)
+	return g, nil
+}
+
+// DeclineResume rejects the opponent's resume request; the game stays paused
+func (s *GameService) DeclineResume(ctx context.Context, gameID, playerID uuid.UUID) (*game.Game, error) {
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		if err := checkResume(g, playerID); err != nil {
+			return err
+		}
+		if g.ResumeRequestedBy == nil || *g.ResumeRequestedBy == playerID {
+			return fmt.Errorf("there is no resume request to decline")
+		}
+
+		return emit(game.EventResumeDeclined, playerID, time.Now(), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.PublishGameEvent(ctx, gameID, "resume_declined", g)
+	return g, nil
+}
+
+// checkResume verifies that a player may ask for or answer a resume in the game
+func checkResume(g *game.Game, playerID uuid.UUID) error {
+	if !g.IsPlayer(playerID) {
+		return game.ErrNotAPlayer
+	}
+	if g.Status != game.GameStatusPaused || g.Paused == nil {
+		return game.ErrGameNotPaused
+	}
+	if g.Paused.Admin {
+		return fmt.Errorf("the game was paused by a tournament admin and is resumed by one")
+	}
+	return nil
+}
+
+// AdminPause pauses a tournament game for the tournament's host, e.g. while a dispute is settled.
+// The clock stops and moves are rejected until the host resumes the game.
+func (s *GameService) AdminPause(ctx context.Context, gameID, adminID uuid.UUID) (*game.Game, error) {
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		if err := s.checkTournamentAdmin(ctx, g, adminID); err != nil {
+			return err
+		}
+		if g.Status == game.GameStatusPaused {
+			return errGameUnchanged // Already paused
+		}
+		if !g.Status.InProgress() {
+			return game.ErrGameNotActive
+		}
+
+		return emit(game.EventPaused, adminID, time.Now(), game.PausedData{Admin: true})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.PublishGameEvent(ctx, gameID, "game_paused", g)
+	return g, nil
+}
+
+// AdminResume resumes a tournament game its host paused
+func (s *GameService) AdminResume(ctx context.Context, gameID, adminID uuid.UUID) (*game.Game, error) {
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		if err := s.checkTournamentAdmin(ctx, g, adminID); err != nil {
+			return err
+		}
+		if g.Status != game.GameStatusPaused || g.Paused == nil {
+			return game.ErrGameNotPaused
+		}
+
+		return emit(game.EventResumed, adminID, time.Now(), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.gameResumed(ctx, g)
+	return g, nil
+}
+
+// checkTournamentAdmin verifies that the user hosts the tournament the game belongs to
+func (s *GameService) checkTournamentAdmin(ctx context.Context, g *game.Game, userID uuid.UUID) error {
+	if g.TournamentID == nil || s.tournamentService == nil {
+		return fmt.Errorf("only tournament games are paused by an admin")
+	}
+	tournament, err := s.tournamentService.GetTournament(ctx, *g.TournamentID)
+	if err != nil {
+		return err
+	}
+	if tournament.CreatedBy != userID {
+		return domain.ErrNotTournamentHost
+	}
+	return nil
+}
+
+// gameResumed restarts the reconnection grace period of players still disconnected from a
+// resumed game, announces the game and lets its bot move
+func (s *GameService) gameResumed(ctx context.Context, g *game.Game) {
+	for playerID, since := range g.Disconnected {
+		s.redisClient.ZAdd(ctx, disconnectDeadlinesKey, redis.Z{
+			Score:  float64(since.Add(s.reconnectGrace).UnixMilli()),
+			Member: disconnectMember(g.ID, playerID),
+		})
+	}
+
+	s.PublishGameEvent(ctx, g.ID, "game_resumed", g)
+	s.scheduleBotMove(g)
+}
+
+// ListPausedGames returns the paused games a user plays in, most recently paused first, each as
+// the user sees it
+func (s *GameService) ListPausedGames(ctx context.Context, userID uuid.UUID) ([]*game.Game, error) {
+	if s.gameRepo == nil {
+		return []*game.Game{}, nil
+	}
+	checkpoints, err := s.gameRepo.ListPausedGames(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list paused games: %w", err)
+	}
+
+	games := make([]*game.Game, 0, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		// The Redis copy is newer than the checkpoint while the game is still there
+		g, err := s.getGameFromRedis(ctx, checkpoint.GameID)
+		if err == redis.Nil {
+			g, err = s.decodeGame(checkpoint.Snapshot)
+		}
+		if err != nil {
+			log.Printf("ERROR: Failed to load paused game %s: %v", checkpoint.GameID, err)
+			continue
+		}
+		if g.Status == game.GameStatusPaused {
+			games = append(games, g.ViewFor(userID))
+		}
+	}
+	return games, nil
+}
+
+// Abort cancels a game before the first move. Aborted games are marked abandoned and
+// do not affect stats or ratings.
+func (s *GameService) Abort(ctx context.Context, gameID, playerID uuid.UUID) (*game.Game, error) {
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		if !g.IsPlayer(playerID) {
+			return game.ErrNotAPlayer
+		}
+		if g.Status == game.GameStatusPaused {
+			return game.ErrGamePaused
+		}
+		if g.Status != game.GameStatusWaiting && !g.Status.InProgress() {
+			return game.ErrGameAlreadyEnded
+		}
+		if g.MoveCount > 0 {
+			return fmt.Errorf("games can only be aborted before the first move")
+		}
+		// Tournament matches must be played (or resigned) so the bracket can advance
+		if g.TournamentID != nil {
+			return fmt.Errorf("tournament games cannot be aborted")
+		}
+
+		return emit(game.EventAborted, playerID, time.Now(), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordSeries(ctx, g)
+	s.PublishGameEvent(ctx, gameID, "game_aborted", g)
+	return g, nil
+}
+
+// OfferRematch records a rematch offer from a player once the game is over. An offer to a bot,
+// or to an opponent who already offered, is accepted straight away and the new game is returned.
+func (s *GameService) OfferRematch(ctx context.Context, gameID, playerID uuid.UUID) (*game.Game, error) {
+	accepted := false
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		accepted = false
+		if err := checkRematch(g, playerID); err != nil {
+			return err
+		}
+		if g.RematchOfferedBy != nil {
+			if *g.RematchOfferedBy == playerID {
+				return errGameUnchanged // Already offered
+			}
+			accepted = true // Both players asked for a rematch
+			return errGameUnchanged
+		}
+
+		return emit(game.EventRematchOffered, playerID, time.Now(), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case accepted:
+		return s.AcceptRematch(ctx, gameID, playerID)
+	case g.Bot != nil && g.Bot.PlayerID != playerID:
+		return s.AcceptRematch(ctx, gameID, g.Bot.PlayerID)
+	}
+
+	s.PublishGameEvent(ctx, gameID, "rematch_offered", g)
+	return g, nil
+}
+
+// AcceptRematch accepts the opponent's rematch offer and returns the new game, whose first mover
+// is picked by the game's first-mover policy
+func (s *GameService) AcceptRematch(ctx context.Context, gameID, playerID uuid.UUID) (*game.Game, error) {
+	var next *game.Game
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		next = nil
+		if err := checkRematch(g, playerID); err != nil {
+			return err
+		}
+		if g.RematchOfferedBy == nil || *g.RematchOfferedBy == playerID {
+			return fmt.Errorf("there is no rematch offer to accept")
+		}
+
+		now := time.Now()
+		rematch, err := g.Rematch(now, s.firstMoverContext(ctx, g))
+		if err != nil {
+			return err
+		}
+		next = rematch
+		return emit(game.EventNextGame, playerID, now, game.NextGameData{GameID: next.ID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.startNextGame(ctx, g, next); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// DeclineRematch rejects the opponent's rematch offer
+func (s *GameService) DeclineRematch(ctx context.Context, gameID, playerID uuid.UUID) (*game.Game, error) {
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		if err := checkRematch(g, playerID); err != nil {
+			return err
+		}
+		if g.RematchOfferedBy == nil || *g.RematchOfferedBy == playerID {
+			return fmt.Errorf("there is no rematch offer to decline")
+		}
+
+		return emit(game.EventRematchDeclined, playerID, time.Now(), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.PublishGameEvent(ctx, gameID, "rematch_declined", g)
+	return g, nil
+}
+
+// checkRematch verifies that a finished two-player game can be followed by a rematch
+func checkRematch(g *game.Game, playerID uuid.UUID) error {
+	if !g.IsPlayer(playerID) {
+		return game.ErrNotAPlayer
+	}
+	if g.Status != game.GameStatusCompleted && g.Status != game.GameStatusAbandoned {
+		return fmt.Errorf("a rematch can only be offered once the game is over")
+	}
+	// Tournament pairings come from the bracket
+	if g.TournamentID != nil {
+		return fmt.Errorf("tournament games cannot be rematched")
+	}
+	if g.IsMultiplayer() {
+		return fmt.Errorf("rematches can only be played in two-player games")
+	}
+	if g.Series != nil && !g.Series.Over() {
+		return fmt.Errorf("the next game of the series starts automatically")
+	}
+	if g.NextGameID != nil {
+		return fmt.Errorf("a rematch has already started")
+	}
+	return nil
+}
+
+// startNextGame saves the rematch or next series game that follows a finished game, moves the
+// room over to it and tells everyone still watching the finished game where play continues
+func (s *GameService) startNextGame(ctx context.Context, previous, next *game.Game) error {
+	if err := s.saveNewGame(ctx, next); err != nil {
+		// Release the finished game so the rematch can be tried again
+		s.updateGame(ctx, previous.ID, func(g *game.Game, emit emitFunc) error {
+			if g.NextGameID == nil || *g.NextGameID != next.ID {
+				return errGameUnchanged
+			}
+			return emit(game.EventNextGameReleased, uuid.Nil, time.Now(), nil)
+		})
+		return fmt.Errorf("failed to start the next game: %w", err)
+	}
+
+	if next.RoomID != nil && s.roomService != nil {
+		if err := s.roomService.SetCurrentGame(ctx, *next.RoomID, next.ID); err != nil {
+			log.Printf("Error moving room %s to game %s: %v", *next.RoomID, next.ID, err)
+		}
+	}
+
+	s.PublishGameEvent(ctx, previous.ID, "rematch_started", previous)
+	s.PublishGameEvent(ctx, next.ID, "game_started", next)
+	s.scheduleBotMove(next)
+	return nil
+}
+
+// scheduleNextSeriesGame starts the next game of an unfinished series in the background, after
+// a short pause so both players see how the last game ended
+func (s *GameService) scheduleNextSeriesGame(g *game.Game) {
+	if g.Series == nil || g.Series.Over() {
+		return
+	}
+	go func() {
+		time.Sleep(seriesNextGameDelay)
+		s.startNextSeriesGame(context.Background(), g.ID)
+	}()
+}
+
+// startNextSeriesGame starts the game that follows a finished game of an unfinished series,
+// unless it has been started already
+func (s *GameService) startNextSeriesGame(ctx context.Context, gameID uuid.UUID) {
+	var next *game.Game
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		next = nil
+		if g.Series == nil || g.Series.Over() || g.NextGameID != nil {
+			return errGameUnchanged
+		}
+
+		now := time.Now()
+		nextGame, err := g.Rematch(now, s.firstMoverContext(ctx, g))
+		if err != nil {
+			return err
+		}
+		next = nextGame
+		return emit(game.EventNextGame, uuid.Nil, now, game.NextGameData{GameID: next.ID})
+	})
+	if err != nil {
+		log.Printf("Error starting the next series game after game %s: %v", gameID, err)
+		return
+	}
+	if next == nil {
+		return
+	}
+
+	if err := s.startNextGame(ctx, g, next); err != nil {
+		log.Printf("Error starting the next series game after game %s: %v", gameID, err)
+	}
+}
+
+// firstMoverContext gathers what the first-mover policy of a two-player game needs to pick who
+// starts. Only FirstMoverLowerRated looks at ratings; bots play at their fixed rating.
+func (s *GameService) firstMoverContext(ctx context.Context, g *game.Game) game.FirstMoverContext {
+	var choice game.FirstMoverContext
+	if game.FirstMoverFromSettings(g.Settings) != game.FirstMoverLowerRated || s.statsService == nil {
+		return choice
+	}
+
+	choice.Ratings = s.statsService.GetRatings(ctx, g.Player1ID, g.Player2ID)
+	if g.Bot != nil {
+		choice.Ratings[g.Bot.PlayerID] = game.BotRating(g.Bot.Difficulty)
+	}
+	return choice
+}
+
+// checkActivePlayer verifies that a game is in progress and the user is one of its players
+func checkActivePlayer(g *game.Game, playerID uuid.UUID) error {
+	if !g.IsPlayer(playerID) {
+		return game.ErrNotAPlayer
+	}
+	if g.Status == game.GameStatusPaused {
+		return game.ErrGamePaused
+	}
+	if !g.Status.InProgress() {
+		return game.ErrGameNotActive
+	}
+	return nil
+}
+
+// completeGame runs the side effects of a game ending that must happen right away: the next
+// game of a series. It is shared by normal game ends and flag-fall. Match history, stats,
+// tournament advancement and notifications follow from the event that ended the game, through
+// the completion outbox (see StartOutboxDispatcher).
+func (s *GameService) completeGame(ctx context.Context, g *game.Game) {
+	// Series game: play on until the series is decided
+	s.scheduleNextSeriesGame(g)
+}
+
+// recordCompletion saves a completed game for match history, in the same transaction as the
+// outbox entries of its stats, tournament and notification side effects
+func (s *GameService) recordCompletion(ctx context.Context, g *game.Game) error {
+	gameState := g.State.GetState()
+	if gameState == nil {
+		return fmt.Errorf("game state is nil for completed game %s (type: %s)", g.ID, g.Type)
+	}
+	gameStateData, err := json.Marshal(gameState)
+	if err != nil {
+		return fmt.Errorf("failed to marshal game state: %w", err)
+	}
+	movesData, err := s.getMoveLogData(ctx, g.ID)
+	if err != nil {
+		return err
+	}
+	settingsData, err := json.Marshal(g.Settings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal settings: %w", err)
+	}
+	snapshot, err := json.Marshal(g)
+	if err != nil {
+		return fmt.Errorf("failed to marshal game: %w", err)
+	}
+
+	completion := repository.GameCompletion{
+		GameID:        g.ID,
+		GameType:      string(g.Type),
+		Player1ID:     g.Player1ID,
+		Player2ID:     g.Player2ID,
+		WinnerID:      g.WinnerID,
+		StartedAt:     g.CreatedAt,
+		EndedAt:       g.EndedAt,
+		State:         gameStateData,
+		Moves:         movesData,
+		Settings:      settingsData,
+		Participants:  gameParticipants(g),
+		FirstPlayerID: g.FirstMover(),
+	}
+	if g.Series != nil {
+		series := seriesRecord(g)
+		completion.Series = &series
+		completion.SeriesGame = g.Series.Game
+	}
+
+	outbox := []repository.OutboxEntry{{Kind: outboxStats, Payload: snapshot}}
+	if g.TournamentID != nil {
+		if g.WinnerID != nil {
+			outbox = append(outbox, repository.OutboxEntry{Kind: outboxTournament, Payload: snapshot})
+		}
+		outbox = append(outbox, repository.OutboxEntry{Kind: outboxNotifications, Payload: snapshot})
+	}
+
+	if err := s.gameRepo.CompleteGame(ctx, completion, outbox); err != nil {
+		return fmt.Errorf("failed to save completed game: %w", err)
+	}
+	log.Printf("Saved completed game %s to database with %d bytes of game state", g.ID, len(gameStateData))
+	return nil
+}
+
+// updateStats updates player stats and ELO ratings for a completed game with stats, which the
+// completion outbox binds to the transaction that marks the update applied
+func (s *GameService) updateStats(ctx context.Context, stats *StatsService, g *game.Game) error {
+	var err error
+	switch {
+	case g.IsMultiplayer():
+		// Bots play at a fixed rating outside the pool, so multiplayer games with a bot are not rated
+		if g.Bot == nil {
+			err = stats.UpdateMultiplayerGameStats(ctx, string(g.Type), g.FinalStandings())
+		}
+	case g.Bot != nil:
+		// Bot games only count when explicitly rated, and then only the human's rating changes
+		if g.Rated {
+			humanID := g.Opponent(g.Bot.PlayerID)
+			err = stats.UpdateGameStatsAgainstBot(ctx, string(g.Type), humanID, g.Bot.PlayerID, game.BotRating(g.Bot.Difficulty), g.WinnerID)
+		}
+	case g.TournamentID != nil && g.TournamentRound > 0:
+		// Tournament game - use progressive bonuses
+		err = stats.UpdateTournamentGameStats(ctx, string(g.Type), g.Player1ID, g.Player2ID, g.WinnerID, g.TournamentRound)
+	default:
+		// Regular casual game
+		err = stats.UpdateGameStats(ctx, string(g.Type), g.Player1ID, g.Player2ID, g.WinnerID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update stats: %w", err)
+	}
+
+	// Count the result for whoever moved first, when their stats were updated above
+	if !g.IsMultiplayer() && (g.Bot == nil || (g.Rated && g.FirstMover() != g.Bot.PlayerID)) {
+		if err := stats.RecordFirstMove(ctx, string(g.Type), g.FirstMover(), g.WinnerID); err != nil {
+			return fmt.Errorf("failed to record first move stats: %w", err)
+		}
+	}
+	return nil
+}
+
+// recordSeries stores the score of the series an abandoned game belongs to for match history.
+// Completed games store it with their completion record.
+func (s *GameService) recordSeries(ctx context.Context, g *game.Game) {
+	if g.Series == nil || s.gameRepo == nil {
+		return
+	}
+
+	if err := s.gameRepo.SaveSeries(ctx, seriesRecord(g)); err != nil {
+		log.Printf("ERROR: Failed to save series %s: %v", g.Series.ID, err)
+	}
+}
+
+// seriesRecord returns the score of the series a finished game belongs to as stored
+func seriesRecord(g *game.Game) repository.SeriesRecord {
+	series := g.Series
+	record := repository.SeriesRecord{
+		ID:       series.ID,
+		GameType: string(g.Type),
+		BestOf:   series.BestOf,
+		Games:    series.Game,
+		Draws:    series.Draws,
+		WinnerID: series.WinnerID,
+		Status:   string(series.Status),
+	}
+	if len(series.PlayerIDs) == 2 && len(series.Wins) == 2 {
+		record.Player1ID, record.Player2ID = series.PlayerIDs[0], series.PlayerIDs[1]
+		record.Player1Wins, record.Player2Wins = series.Wins[0], series.Wins[1]
+	}
+	return record
+}
+
+// gameParticipants lists every seated player of a game with their placement (0 if unplaced)
+func gameParticipants(g *game.Game) []repository.GameParticipant {
+	playerIDs := g.PlayerIDs()
+	participants := make([]repository.GameParticipant, len(playerIDs))
+	for seat, playerID := range playerIDs {
+		participants[seat] = repository.GameParticipant{
+			UserID:    playerID,
+			Seat:      seat,
+			Placement: g.PlacementOf(playerID),
+		}
+	}
+	return participants
+}
+
+// GetGame retrieves a game from Redis, restoring an in-progress or paused game from its
+// checkpoint or falling back to the database for finished games
+func (s *GameService) GetGame(ctx context.Context, gameID uuid.UUID) (*game.Game, error) {
+	g, err := s.getGameFromRedis(ctx, gameID)
+	if err != nil {
+		if err == redis.Nil {
+			fmt.Printf("Game not found in Redis, checking database...\n")
+			if s.gameRepo != nil {
+				restored, err := s.rehydrateGame(ctx, gameID)
+				if err != nil {
+					log.Printf("ERROR: Failed to restore game %s from its checkpoint: %v", gameID, err)
+				} else if restored != nil {
+					return restored, nil
+				}
+				// Fall back to database for completed games
+				g, err := s.getGameFromDatabase(ctx, gameID)
+				if err != nil {
+					// Games that were never saved to game_matches (e.g. abandoned) live on in their events
+					if rebuilt, rebuildErr := s.RebuildGame(ctx, gameID); rebuildErr == nil && rebuilt.Status.Finished() {
+						return rebuilt, nil
+					}
+				}
+				return g, err
+			}
+			return nil, fmt.Errorf("game not found")
+		}
+		return nil, err
+	}
+
+	// Games being played stay in Redis for as long as anyone is looking at them
+	if g.Status.InProgress() || g.Status == game.GameStatusPaused {
+		s.refreshGameTTL(ctx, gameID)
+	}
+
+	fmt.Printf("Returning game %s\n", g.ID.String())
+	return g, nil
+}
+
+// getGameFromRedis loads a game from Redis, returning redis.Nil if it is not there
+func (s *GameService) getGameFromRedis(ctx context.Context, gameID uuid.UUID) (*game.Game, error) {
+	key := fmt.Sprintf("game:%s", gameID.String())
+	fmt.Printf("Getting game from Redis with key: %s\n", key)
+	data, err := s.redisClient.Get(ctx, key).Result()
+	if err != nil {
+		if err != redis.Nil {
+			fmt.Printf("Redis error: %v\n", err)
+		}
+		return nil, err
+	}
+	fmt.Printf("Game found in Redis: %d bytes\n", len(data))
+
+	return s.decodeGame([]byte(data))
+}
+
+// decodeGame unmarshals a game as stored in Redis and deserializes its state
+func (s *GameService) decodeGame(data []byte) (*game.Game, error) {
+	var g game.Game
+	if err := json.Unmarshal(data, &g); err != nil {
+		fmt.Printf("Error unmarshaling game: %v\n", err)
+		return nil, fmt.Errorf("failed to unmarshal game: %w", err)
+	}
+	fmt.Printf("Game unmarshaled successfully, Type: %s, Status: %s\n", g.Type, g.Status)
+
+	// Deserialize game state based on type
+	if err := s.deserializeGameState(&g); err != nil {
+		return nil, err
+	}
+
+	// Ensure Spectators is never nil (initialize as empty slice if nil)
+	if g.Spectators == nil {
+		g.Spectators = []game.Spectator{}
+	}
+
+	return &g, nil
+}
+
+// refreshGameTTL restarts the expiry of a game, its move log, its event stream and its client move IDs
+func (s *GameService) refreshGameTTL(ctx context.Context, gameID uuid.UUID) {
+	pipe := s.redisClient.Pipeline()
+	pipe.PExpire(ctx, fmt.Sprintf("game:%s", gameID.String()), gameTTL)
+	pipe.PExpire(ctx, moveLogKey(gameID), gameTTL)
+	pipe.PExpire(ctx, gameEventsKey(gameID), gameTTL)
+	pipe.PExpire(ctx, clientMovesKey(gameID), gameTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("WARNING: Failed to refresh expiry of game %s: %v", gameID, err)
+	}
+}
+
+// getGameFromDatabase retrieves a completed game from the database
+func (s *GameService) getGameFromDatabase(ctx context.Context, gameID uuid.UUID) (*game.Game, error) {
+	log.Printf("Attempting to retrieve game %s from database...", gameID)
+	dbGame, err := s.gameRepo.GetByID(ctx, gameID)
+	if err != nil {
+		log.Printf("Game %s not found in database: %v", gameID, err)
+		return nil, fmt.Errorf("game not found")
+	}
+	log.Printf("Game %s found in database", gameID)
+	log.Printf("Reconstructing game object from database fields...")
+
+	// Debug: Print all field types
+	for key, value := range dbGame {
+		log.Printf("  Field %s: type=%T, value=%v", key, value, value)
+	}
+
+	// Helper function to convert time fields (handles both time.Time and *time.Time)
+	getTimePtr := func(field interface{}) *time.Time {
+		if field == nil {
+			return nil
+		}
+		switch v := field.(type) {
+		case *time.Time:
+			return v
+		case time.Time:
+			return &v
+		default:
+			return nil
+		}
+	}
+
+	// Reconstruct game object
+	g := &game.Game{
+		ID:          dbGame["id"].(uuid.UUID),
+		Type:        game.GameType(dbGame["game_type"].(string)),
+		Status:      game.GameStatus(dbGame["status"].(string)),
+		Player1ID:   dbGame["player1_id"].(uuid.UUID),
+		Player1Name: dbGame["player1_name"].(string),
+		Player2ID:   dbGame["player2_id"].(uuid.UUID),
+		Player2Name: dbGame["player2_name"].(string),
+		CurrentTurn: dbGame["player1_id"].(uuid.UUID), // Default to player1 for completed games
+		CreatedAt:   dbGame["created_at"].(time.Time),
+		StartedAt:   getTimePtr(dbGame["started_at"]),
+		EndedAt:     getTimePtr(dbGame["ended_at"]),
+		Spectators:  []game.Spectator{},
+	}
+	log.Printf("Game object reconstructed successfully")
+
+	// Set winner ID if present
+	if winnerID, ok := dbGame["winner_id"].(*uuid.UUID); ok && winnerID != nil {
+		g.WinnerID = winnerID
+	}
+
+	// Restore every seat and the placements of multiplayer games
+	if participants, err := s.gameRepo.GetParticipants(ctx, gameID); err != nil {
+		log.Printf("WARNING: Failed to load participants for game %s: %v", gameID, err)
+	} else if len(participants) > 0 {
+		restoreSeats(g, participants)
+	}
+
+	// Restore the settings the game was created with
+	if settingsData, ok := dbGame["settings"].([]byte); ok && len(settingsData) > 0 {
+		if err := json.Unmarshal(settingsData, &g.Settings); err != nil {
+			log.Printf("WARNING: Failed to unmarshal settings for game %s: %v", gameID, err)
+		}
+	}
+
+	// Deserialize game state
+	if gameStateData, ok := dbGame["game_state"].([]byte); ok && len(gameStateData) > 0 {
+		g.StateData = gameStateData
+		if err := s.deserializeGameState(g); err != nil {
+			return nil, err
+		}
+	}
+
+	fmt.Printf("Returning game from database: %s\n", g.ID.String())
+	return g, nil
+}
+
+// restoreSeats fills a game loaded from the database with its stored participants
+func restoreSeats(g *game.Game, participants []repository.GameParticipant) {
+	g.Seats = make([]game.Seat, len(participants))
+	placed := make(map[int][]uuid.UUID)
+	var positions []int
+	for i, participant := range participants {
+		g.Seats[i] = game.Seat{Index: participant.Seat, PlayerID: participant.UserID, Name: participant.Username}
+		if participant.Placement > 0 {
+			if _, seen := placed[participant.Placement]; !seen {
+				positions = append(positions, participant.Placement)
+			}
+			placed[participant.Placement] = append(placed[participant.Placement], participant.UserID)
+		}
+	}
+	g.SeatCount = len(participants)
+
+	if g.IsMultiplayer() {
+		sort.Ints(positions)
+		for _, position := range positions {
+			g.Placements = append(g.Placements, placed[position])
+		}
+	}
+}
+
+// deserializeGameState deserializes the game state based on game type
+func (s *GameService) deserializeGameState(g *game.Game) error {
+	fmt.Printf("Deserializing %s state from %d bytes...\n", g.Type, len(g.StateData))
+	state, err := game.DefaultRegistry.DecodeState(g.Type, g.StateData)
+	if err != nil {
+		fmt.Printf("Error deserializing %s state: %v\n", g.Type, err)
+		return err
+	}
+	if err := game.VerifyState(state, g.StateFingerprint); err != nil {
+		log.Printf("CRITICAL ERROR: Restored state of game %s failed its integrity check: %v", g.ID, err)
+		return err
+	}
+	g.State = state
+	return nil
+}
+
+// saveNewGame saves a game that has just been created to Redis, starting its event stream
+func (s *GameService) saveNewGame(ctx context.Context, g *game.Game) error {
+	created, err := game.NewCreatedEvent(g)
+	if err != nil {
+		return err
+	}
+	return s.saveGame(ctx, g, nil, []game.Event{created}, nil)
+}
+
+// saveGame performs the compare-and-set save, failing with game.ErrVersionConflict if another
+// request saved the game after it was loaded. The move records and events of the save are
+// appended in the same step and the events are stamped with the version they were saved at.
+// A tagged move (nil for none) is remembered with the version it produced.
+func (s *GameService) saveGame(ctx context.Context, g *game.Game, records []game.MoveRecord, events []game.Event, tag *clientMove) error {
+	key := fmt.Sprintf("game:%s", g.ID.String())
+	
+	// Serialize the state to JSON, with the fingerprint it is checked against when restored
 	if g.State != nil {
 		stateData, err := json.Marshal(g.State.GetState())
 		if err != nil {
-			return fmt.Errorf("failed to marshal game state: %w", err)
+			return fmt.Errorf("failed to marshal game state: %w", err)
+		}
+		g.StateData = stateData
+		if g.StateFingerprint, err = game.StateFingerprint(g.State); err != nil {
+			return fmt.Errorf("failed to fingerprint game state: %w", err)
+		}
+	}
+
+	expectedVersion := g.Version
+	g.Version++
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		g.Version = expectedVersion
+		return err
+	}
+
+	tagField := ""
+	if tag != nil {
+		tagField = tag.field()
+	}
+
+	args := []interface{}{data, expectedVersion, gameTTL.Milliseconds(), gameEventsMaxLen, tagField, len(records)}
+	for _, record := range records {
+		recordData, err := json.Marshal(record)
+		if err != nil {
+			g.Version = expectedVersion
+			return fmt.Errorf("failed to marshal move record: %w", err)
+		}
+		args = append(args, recordData)
+	}
+	for i := range events {
+		events[i].Version = g.Version
+		events[i].Index = i
+		eventData, err := json.Marshal(events[i])
+		if err != nil {
+			g.Version = expectedVersion
+			return fmt.Errorf("failed to marshal %s event: %w", events[i].Type, err)
+		}
+		args = append(args, eventData)
+	}
+
+	saved, err := saveGameScript.Run(ctx, s.redisClient,
+		[]string{key, moveLogKey(g.ID), gameEventsKey(g.ID), gameEventsStream, clientMovesKey(g.ID)}, args...).Int()
+	if err != nil {
+		g.Version = expectedVersion
+		return fmt.Errorf("failed to save game: %w", err)
+	}
+	if saved == 0 {
+		g.Version = expectedVersion
+		return game.ErrVersionConflict
+	}
+
+	s.updateClockDeadline(ctx, g)
+	s.markForCheckpoint(ctx, g)
+	return nil
+}
+
+// updateClockDeadline keeps the sweeper's index of running clocks in sync with a saved game
+func (s *GameService) updateClockDeadline(ctx context.Context, g *game.Game) {
+	if g.Clock != nil && g.Status == game.GameStatusActive {
+		if deadline, running := g.Clock.Deadline(); running {
+			s.redisClient.ZAdd(ctx, clockDeadlinesKey, redis.Z{
+				Score:  float64(deadline.UnixMilli()),
+				Member: g.ID.String(),
+			})
+			return
+		}
+	}
+	if g.Clock != nil {
+		s.redisClient.ZRem(ctx, clockDeadlinesKey, g.ID.String())
+	}
+}
+
+// markForCheckpoint queues a saved game for the checkpointer if its stored copy needs updating
+func (s *GameService) markForCheckpoint(ctx context.Context, g *game.Game) {
+	if s.gameRepo == nil || !(g.Status.InProgress() || g.Status == game.GameStatusPaused || g.Status == game.GameStatusAbandoned) {
+		return
+	}
+	s.redisClient.ZAdd(ctx, checkpointPendingKey, redis.Z{
+		Score:  float64(time.Now().UnixMilli()),
+		Member: g.ID.String(),
+	})
+}
+
+// StartCheckpointer starts a background worker that writes in-progress games behind to the
+// database, so they can be restored if Redis loses them. On start it restores the games whose
+// checkpoints are newer than their Redis copies.
+func (s *GameService) StartCheckpointer(ctx context.Context) {
+	if s.gameRepo == nil {
+		return
+	}
+	s.restoreCheckpointedGames(ctx)
+
+	ticker := time.NewTicker(checkpointInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pending, err := s.redisClient.ZRangeWithScores(ctx, checkpointPendingKey, 0, -1).Result()
+			if err != nil {
+				log.Printf("Checkpointer error: %v", err)
+				continue
+			}
+
+			for _, entry := range pending {
+				id, _ := entry.Member.(string)
+				gameID, err := uuid.Parse(id)
+				if err == nil {
+					if err := s.checkpointGame(ctx, gameID); err != nil {
+						log.Printf("Failed to checkpoint game %s: %v", gameID, err)
+						continue
+					}
+				}
+				clearCheckpointScript.Run(ctx, s.redisClient, []string{checkpointPendingKey}, id, int64(entry.Score))
+			}
+		}
+	}
+}
+
+// checkpointGame writes the current Redis copy of a game to the database. The game and its move
+// log are read in one transaction so the checkpoint never holds a move the state is missing.
+func (s *GameService) checkpointGame(ctx context.Context, gameID uuid.UUID) error {
+	var getData *redis.StringCmd
+	var getMoves *redis.StringSliceCmd
+	_, err := s.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		getData = pipe.Get(ctx, fmt.Sprintf("game:%s", gameID.String()))
+		getMoves = pipe.LRange(ctx, moveLogKey(gameID), 0, -1)
+		return nil
+	})
+	if err == redis.Nil {
+		return nil // Expired; its last checkpoint stands
+	}
+	if err != nil {
+		return err
+	}
+
+	data := []byte(getData.Val())
+	g, err := s.decodeGame(data)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case (g.Status.InProgress() || g.Status == game.GameStatusPaused) && g.Player2ID != uuid.Nil:
+		settingsData, err := json.Marshal(g.Settings)
+		if err != nil {
+			return fmt.Errorf("failed to marshal settings: %w", err)
+		}
+		startedAt := g.CreatedAt
+		if g.StartedAt != nil {
+			startedAt = *g.StartedAt
+		}
+		return s.gameRepo.SaveCheckpoint(ctx, repository.GameCheckpoint{
+			GameID:    g.ID,
+			GameType:  string(g.Type),
+			Player1ID: g.Player1ID,
+			Player2ID: g.Player2ID,
+			StartedAt: startedAt,
+			State:     g.StateData,
+			MoveCount: g.MoveCount,
+			Moves:     []byte("[" + strings.Join(getMoves.Val(), ",") + "]"),
+			Snapshot:  data,
+			Version:   g.Version,
+			Settings:  settingsData,
+			Paused:    g.Status == game.GameStatusPaused,
+		})
+	case g.Status == game.GameStatusAbandoned:
+		endedAt := time.Now()
+		if g.EndedAt != nil {
+			endedAt = *g.EndedAt
+		}
+		return s.gameRepo.AbandonCheckpoint(ctx, g.ID, endedAt)
+	}
+	return nil
+}
+
+// rehydrateGame restores an in-progress game missing from Redis from its checkpoint, putting it
+// and its move log back into Redis. It returns nil if the game has no checkpoint. Checkpoints
+// older than gameTTL belong to games that expired for inactivity and are not restored, unless the
+// game was paused: paused games are restored whenever a player comes back to them.
+func (s *GameService) rehydrateGame(ctx context.Context, gameID uuid.UUID) (*game.Game, error) {
+	checkpoint, err := s.gameRepo.GetCheckpoint(ctx, gameID, time.Now().Add(-gameTTL))
+	if err != nil || checkpoint == nil {
+		return nil, err
+	}
+
+	g, err := s.decodeGame(checkpoint.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	var moves []json.RawMessage
+	if len(checkpoint.Moves) > 0 {
+		if err := json.Unmarshal(checkpoint.Moves, &moves); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal move log: %w", err)
+		}
+	}
+	args := []interface{}{checkpoint.Snapshot, gameTTL.Milliseconds()}
+	for _, move := range moves {
+		args = append(args, []byte(move))
+	}
+
+	restored, err := restoreGameScript.Run(ctx, s.redisClient,
+		[]string{fmt.Sprintf("game:%s", gameID.String()), moveLogKey(gameID)}, args...).Int()
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore game: %w", err)
+	}
+	if restored == 0 {
+		// Restored or saved by another request since it was found missing
+		return s.getGameFromRedis(ctx, gameID)
+	}
+
+	s.updateClockDeadline(ctx, g)
+	for playerID, since := range g.Disconnected {
+		s.redisClient.ZAdd(ctx, disconnectDeadlinesKey, redis.Z{
+			Score:  float64(since.Add(s.reconnectGrace).UnixMilli()),
+			Member: disconnectMember(gameID, playerID),
+		})
+	}
+	log.Printf("Restored game %s from its checkpoint at move %d", gameID, g.MoveCount)
+	return g, nil
+}
+
+// restoreCheckpointedGames puts every recently checkpointed game missing from Redis back, so
+// their clocks and disconnect deadlines run again without waiting for a player to load them
+func (s *GameService) restoreCheckpointedGames(ctx context.Context) {
+	gameIDs, err := s.gameRepo.ListCheckpoints(ctx, time.Now().Add(-gameTTL))
+	if err != nil {
+		log.Printf("ERROR: Failed to list checkpointed games: %v", err)
+		return
+	}
+
+	for _, gameID := range gameIDs {
+		exists, err := s.redisClient.Exists(ctx, fmt.Sprintf("game:%s", gameID.String())).Result()
+		if err != nil || exists == 1 {
+			continue
+		}
+		if _, err := s.rehydrateGame(ctx, gameID); err != nil {
+			log.Printf("ERROR: Failed to restore game %s from its checkpoint: %v", gameID, err)
+		}
+	}
+}
+
+// gameEventHandler reacts to one event read by a consumer group. An error leaves the event
+// pending, so it is delivered again.
+type gameEventHandler func(ctx context.Context, e game.Event) error
+
+// StartEventConsumers starts the consumer groups that react to game events: recording completed
+// games with the outbox entries of their side effects, and the archive of every event in the
+// database. Each group gets every event at least once; several servers share a group's events
+// between them.
+func (s *GameService) StartEventConsumers(ctx context.Context) {
+	if s.gameRepo == nil {
+		return
+	}
+	groups := map[string]gameEventHandler{
+		"completions": s.handleCompletionEvent,
+		"archive":     s.archiveEvent,
+	}
+
+	hostname, _ := os.Hostname()
+	consumer := fmt.Sprintf("%s-%d", hostname, os.Getpid())
+
+	var wg sync.WaitGroup
+	for group, handler := range groups {
+		wg.Add(1)
+		go func(group string, handler gameEventHandler) {
+			defer wg.Done()
+			s.consumeEvents(ctx, group, consumer, handler)
+		}(group, handler)
+	}
+	wg.Wait()
+}
+
+// consumeEvents reads the event stream as one consumer of a group until ctx is cancelled,
+// taking over events another consumer read but never acknowledged. A group created on first
+// start reads the stream from its beginning, so events saved before any consumer ran (games
+// completed before the upgrade or while no server consumed) are still handled. Handlers must
+// be idempotent: events already handled elsewhere are replayed to the new group.
+func (s *GameService) consumeEvents(ctx context.Context, group, consumer string, handler gameEventHandler) {
+	err := s.redisClient.XGroupCreateMkStream(ctx, gameEventsStream, group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		log.Printf("ERROR: Failed to create %s event consumer group: %v", group, err)
+		return
+	}
+
+	lastClaim := time.Time{}
+	for ctx.Err() == nil {
+		if time.Since(lastClaim) >= eventConsumerMinIdle {
+			lastClaim = time.Now()
+			claimed, _, err := s.redisClient.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+				Stream:   gameEventsStream,
+				Group:    group,
+				Consumer: consumer,
+				MinIdle:  eventConsumerMinIdle,
+				Start:    "0-0",
+				Count:    eventConsumerBatch,
+			}).Result()
+			if err != nil && ctx.Err() == nil {
+				log.Printf("Event consumer %s error claiming pending events: %v", group, err)
+			}
+			s.handleEventMessages(ctx, group, claimed, handler)
+		}
+
+		streams, err := s.redisClient.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: consumer,
+			Streams:  []string{gameEventsStream, ">"},
+			Count:    eventConsumerBatch,
+			Block:    eventConsumerBlock,
+		}).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("Event consumer %s error: %v", group, err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(eventConsumerBlock):
+			}
+			continue
+		}
+
+		for _, stream := range streams {
+			s.handleEventMessages(ctx, group, stream.Messages, handler)
+		}
+	}
+}
+
+// handleEventMessages passes each message to the group's handler and acknowledges the ones it
+// handled. Messages that are not events are acknowledged and dropped.
+func (s *GameService) handleEventMessages(ctx context.Context, group string, messages []redis.XMessage, handler gameEventHandler) {
+	for _, message := range messages {
+		var e game.Event
+		data, _ := message.Values["event"].(string)
+		if err := json.Unmarshal([]byte(data), &e); err != nil {
+			log.Printf("ERROR: Dropping malformed event %s: %v", message.ID, err)
+		} else if err := handler(ctx, e); err != nil {
+			log.Printf("Event consumer %s failed to handle %s event of game %s: %v", group, e.Type, e.GameID, err)
+			continue
+		}
+		s.redisClient.XAck(ctx, gameEventsStream, group, message.ID)
+	}
+}
+
+// handleCompletionEvent records a game completed by the event. A game that is already recorded
+// (the event delivered again or replayed to a new group) is left as it is, with no new outbox
+// entries.
+func (s *GameService) handleCompletionEvent(ctx context.Context, e game.Event) error {
+	if !e.EndsGame {
+		return nil
+	}
+
+	g, err := s.GetGame(ctx, e.GameID)
+	if err != nil {
+		return err
+	}
+	if g.Status != game.GameStatusCompleted {
+		return nil
+	}
+	return s.recordCompletion(ctx, g)
+}
+
+// StartOutboxDispatcher starts a background worker that applies the side effects of completed
+// games from the completion outbox. Failed entries are retried with backoff and dead-lettered
+// after outboxMaxAttempts.
+func (s *GameService) StartOutboxDispatcher(ctx context.Context) {
+	if s.gameRepo == nil {
+		return
+	}
+
+	ticker := time.NewTicker(outboxDispatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ids, err := s.gameRepo.ListDueOutboxEntries(ctx, outboxBatch)
+			if err != nil {
+				log.Printf("Outbox dispatcher error: %v", err)
+				continue
+			}
+
+			for _, id := range ids {
+				err := s.gameRepo.ProcessOutboxEntry(ctx, id, func(tx pgx.Tx, entry repository.OutboxEntry) error {
+					return s.applyOutboxEntry(ctx, tx, entry)
+				})
+				if err == nil {
+					continue
+				}
+
+				dead, failErr := s.gameRepo.FailOutboxEntry(ctx, id, err.Error(), outboxRetryBase, outboxRetryMax, outboxMaxAttempts)
+				switch {
+				case failErr != nil:
+					log.Printf("ERROR: Failed to record failure of outbox entry %d (%v): %v", id, err, failErr)
+				case dead:
+					log.Printf("ERROR: Outbox entry %d failed %d times and was dead-lettered: %v", id, outboxMaxAttempts, err)
+				default:
+					log.Printf("Outbox entry %d failed, will retry: %v", id, err)
+				}
+			}
+		}
+	}
+}
+
+// applyOutboxEntry applies one side effect of a completed game. Stats are updated in tx, so they
+// commit exactly once with the entry; tournament advancement and notifications run outside it
+// and repeat if the entry fails to commit after them.
+func (s *GameService) applyOutboxEntry(ctx context.Context, tx pgx.Tx, entry repository.OutboxEntry) error {
+	g, err := s.decodeGame(entry.Payload)
+	if err != nil {
+		return err
+	}
+
+	switch entry.Kind {
+	case outboxStats:
+		if s.statsService == nil {
+			return nil
+		}
+		return s.updateStats(ctx, s.statsService.WithTx(tx), g)
+
+	case outboxTournament:
+		// AdvanceWinner will automatically create games for the next round if ready
+		if s.tournamentService == nil || g.TournamentID == nil || g.WinnerID == nil {
+			return nil
+		}
+		log.Printf("Tournament game completed - advancing winner %s to next round", g.WinnerID.String())
+		return s.tournamentService.AdvanceWinner(ctx, *g.TournamentID, g.ID, *g.WinnerID)
+
+	case outboxNotifications:
+		if s.notificationService == nil || g.TournamentID == nil {
+			return nil
+		}
+		return s.notificationService.NotifyTournamentGameResult(ctx, g.PlayerIDs(), g.WinnerID, g.TournamentRound, *g.TournamentID, g.ID)
+	}
+	return fmt.Errorf("unknown outbox entry kind %q", entry.Kind)
+}
+
+// archiveEvent stores an event in the database, where it outlives the game's Redis stream
+func (s *GameService) archiveEvent(ctx context.Context, e game.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return s.gameRepo.SaveEvent(ctx, e.GameID, e.Version, e.Index, string(e.Type), e.At, data)
+}
+
+// loadGameEvents returns a game's events in order, from its Redis stream and the archive. An
+// event can be in both while the archive catches up.
+func (s *GameService) loadGameEvents(ctx context.Context, gameID uuid.UUID) ([]game.Event, error) {
+	var data [][]byte
+	messages, err := s.redisClient.XRange(ctx, gameEventsKey(gameID), "-", "+").Result()
+	if err != nil {
+		return nil, err
+	}
+	for _, message := range messages {
+		event, _ := message.Values["event"].(string)
+		data = append(data, []byte(event))
+	}
+	if s.gameRepo != nil {
+		archived, err := s.gameRepo.GetEvents(ctx, gameID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load archived events: %w", err)
 		}
-		g.StateData = stateData
+		data = append(data, archived...)
 	}
-	
-	data, err := json.Marshal(g)
+
+	type eventKey struct {
+		version int64
+		index   int
+	}
+	seen := make(map[eventKey]bool)
+	events := make([]game.Event, 0, len(data))
+	for _, eventData := range data {
+		var e game.Event
+		if err := json.Unmarshal(eventData, &e); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event: %w", err)
+		}
+		key := eventKey{e.Version, e.Index}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		events = append(events, e)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Version != events[j].Version {
+			return events[i].Version < events[j].Version
+		}
+		return events[i].Index < events[j].Index
+	})
+	return events, nil
+}
+
+// GetGameEvents returns the events of a finished game in the order they happened
+func (s *GameService) GetGameEvents(ctx context.Context, gameID uuid.UUID) ([]game.Event, error) {
+	g, err := s.GetGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Events of live or paused games would leak hidden information (e.g. pending RPS choices)
+	if !g.Status.Finished() {
+		return nil, fmt.Errorf("events are only available for finished games")
+	}
+
+	return s.loadGameEvents(ctx, gameID)
+}
+
+// RebuildGame rebuilds a game by applying its events in order
+func (s *GameService) RebuildGame(ctx context.Context, gameID uuid.UUID) (*game.Game, error) {
+	events, err := s.loadGameEvents(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("game not found")
+	}
+	return game.Rebuild(events)
+}
+
+// StartClockSweeper starts a background worker that ends games whose clock has run out
+func (s *GameService) StartClockSweeper(ctx context.Context) {
+	ticker := time.NewTicker(clockSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			gameIDs, err := s.redisClient.ZRangeByScore(ctx, clockDeadlinesKey, &redis.ZRangeBy{
+				Min: "-inf",
+				Max: fmt.Sprintf("%d", time.Now().UnixMilli()),
+			}).Result()
+			if err != nil {
+				log.Printf("Clock sweeper error: %v", err)
+				continue
+			}
+
+			for _, id := range gameIDs {
+				gameID, err := uuid.Parse(id)
+				if err != nil {
+					s.redisClient.ZRem(ctx, clockDeadlinesKey, id)
+					continue
+				}
+				if err := s.expireClock(ctx, gameID); err != nil {
+					log.Printf("Failed to end game %s on time: %v", gameID, err)
+				}
+			}
+		}
+	}
+}
+
+// expireClock ends a game whose running clock has reached zero; the opponent wins on time
+func (s *GameService) expireClock(ctx context.Context, gameID uuid.UUID) error {
+	var flaggedID uuid.UUID
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		flaggedID = uuid.Nil
+		if g.Status != game.GameStatusActive || g.Clock == nil {
+			return errGameUnchanged
+		}
+
+		now := time.Now()
+		playerID, expired := g.Clock.Expired(now)
+		if !expired {
+			return errGameUnchanged
+		}
+
+		flaggedID = playerID
+		return emit(game.EventTimedOut, playerID, now, nil)
+	})
+	if err != nil {
+		if err.Error() == "game not found" {
+			s.redisClient.ZRem(ctx, clockDeadlinesKey, gameID.String())
+			return nil
+		}
+		return err
+	}
+	if flaggedID == uuid.Nil {
+		// Clock was stopped or moved on since it was indexed
+		s.updateClockDeadline(ctx, g)
+		return nil
+	}
+
+	log.Printf("Player %s ran out of time in game %s", flaggedID, gameID)
+	s.completeGame(ctx, g)
+
+	s.PublishGameEvent(ctx, gameID, "game_timeout", g)
+	return nil
+}
+
+// PlayerDisconnected records that a player's last connection to a game dropped and starts
+// their reconnection grace window
+func (s *GameService) PlayerDisconnected(ctx context.Context, gameID, playerID uuid.UUID) error {
+	now := time.Now()
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		if !g.IsPlayer(playerID) || !g.Status.InProgress() {
+			return errGameUnchanged
+		}
+		if _, ok := g.Disconnected[playerID]; ok {
+			return errGameUnchanged
+		}
+
+		return emit(game.EventDisconnected, playerID, now, nil)
+	})
+	if err != nil {
+		return err
+	}
+	since, ok := g.Disconnected[playerID]
+	if !ok || !g.Status.InProgress() {
+		return nil
+	}
+
+	deadline := since.Add(s.reconnectGrace)
+	s.redisClient.ZAdd(ctx, disconnectDeadlinesKey, redis.Z{
+		Score:  float64(deadline.UnixMilli()),
+		Member: disconnectMember(gameID, playerID),
+	})
+
+	s.PublishGameEvent(ctx, gameID, "player_disconnected", map[string]interface{}{
+		"game_id":       gameID,
+		"player_id":     playerID,
+		"grace_seconds": int(s.reconnectGrace.Seconds()),
+		"deadline":      deadline,
+	})
+	return nil
+}
+
+// PlayerReconnected clears a player's disconnection and cancels the pending forfeit
+func (s *GameService) PlayerReconnected(ctx context.Context, gameID, playerID uuid.UUID) error {
+	s.redisClient.ZRem(ctx, disconnectDeadlinesKey, disconnectMember(gameID, playerID))
+
+	wasDisconnected := false
+	_, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		_, wasDisconnected = g.Disconnected[playerID]
+		if !wasDisconnected {
+			return errGameUnchanged
+		}
+
+		return emit(game.EventReconnected, playerID, time.Now(), nil)
+	})
+	if err != nil || !wasDisconnected {
+		return err
+	}
+
+	s.PublishGameEvent(ctx, gameID, "player_reconnected", map[string]interface{}{
+		"game_id":   gameID,
+		"player_id": playerID,
+	})
+	return nil
+}
+
+// disconnectMember returns the disconnect deadline set member for a player in a game
+func disconnectMember(gameID, playerID uuid.UUID) string {
+	return gameID.String() + ":" + playerID.String()
+}
+
+// StartDisconnectSweeper starts a background worker that ends games whose disconnected
+// player did not come back within the grace period
+func (s *GameService) StartDisconnectSweeper(ctx context.Context) {
+	ticker := time.NewTicker(clockSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			members, err := s.redisClient.ZRangeByScore(ctx, disconnectDeadlinesKey, &redis.ZRangeBy{
+				Min: "-inf",
+				Max: fmt.Sprintf("%d", time.Now().UnixMilli()),
+			}).Result()
+			if err != nil {
+				log.Printf("Disconnect sweeper error: %v", err)
+				continue
+			}
+
+			for _, member := range members {
+				gameID, playerID, ok := parseDisconnectMember(member)
+				if !ok {
+					s.redisClient.ZRem(ctx, disconnectDeadlinesKey, member)
+					continue
+				}
+				if err := s.forfeitDisconnected(ctx, gameID, playerID); err != nil {
+					log.Printf("Failed to end game %s after disconnect: %v", gameID, err)
+				}
+			}
+		}
+	}
+}
+
+// parseDisconnectMember splits a disconnect deadline set member into its game and player IDs
+func parseDisconnectMember(member string) (uuid.UUID, uuid.UUID, bool) {
+	gamePart, playerPart, found := strings.Cut(member, ":")
+	if !found {
+		return uuid.Nil, uuid.Nil, false
+	}
+	gameID, err := uuid.Parse(gamePart)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, false
+	}
+	playerID, err := uuid.Parse(playerPart)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, false
+	}
+	return gameID, playerID, true
+}
+
+// forfeitDisconnected ends a game whose player stayed disconnected past the grace period.
+// Rated games are won by the opponent; casual games are abandoned without affecting ratings.
+func (s *GameService) forfeitDisconnected(ctx context.Context, gameID, playerID uuid.UUID) error {
+	defer s.redisClient.ZRem(ctx, disconnectDeadlinesKey, disconnectMember(gameID, playerID))
+
+	ended := false
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		ended = false
+		if !g.Status.InProgress() {
+			return errGameUnchanged
+		}
+		since, ok := g.Disconnected[playerID]
+		now := time.Now()
+		if !ok || now.Before(since.Add(s.reconnectGrace)) {
+			return errGameUnchanged // Reconnected since the deadline was indexed
+		}
+
+		ended = true
+		if g.Rated {
+			return emit(game.EventForfeited, playerID, now, nil)
+		}
+		return emit(game.EventAbandoned, playerID, now, nil)
+	})
 	if err != nil {
+		if err.Error() == "game not found" {
+			return nil
+		}
 		return err
 	}
+	if !ended {
+		return nil
+	}
+
+	log.Printf("Player %s did not reconnect to game %s (rated=%t)", playerID, gameID, g.Rated)
+	if g.Rated {
+		s.completeGame(ctx, g)
+		s.PublishGameEvent(ctx, gameID, "game_forfeited", g)
+	} else {
+		s.recordSeries(ctx, g)
+		s.PublishGameEvent(ctx, gameID, "game_abandoned", g)
+	}
+	return nil
+}
+
+// updateGame loads a game, applies mutate and saves it atomically, retrying from a fresh
+// copy when another request saved the game in between. A mutation that fails after a
+// conflict is reported as a conflict, since the request was based on a stale game.
+func (s *GameService) updateGame(ctx context.Context, gameID uuid.UUID, mutate gameMutation) (*game.Game, error) {
+	return s.updateGameTagged(ctx, gameID, nil, mutate)
+}
+
+// updateGameTagged is updateGame for a move a client tagged with an ID (nil for none), which is
+// remembered in the same step as the save
+func (s *GameService) updateGameTagged(ctx context.Context, gameID uuid.UUID, tag *clientMove, mutate gameMutation) (*game.Game, error) {
+	conflicted := false
+	for attempt := 1; attempt <= maxSaveAttempts; attempt++ {
+		g, err := s.GetGame(ctx, gameID)
+		if err != nil {
+			return nil, err
+		}
+
+		var events []game.Event
+		var records []game.MoveRecord
+		emit := func(eventType game.EventType, playerID uuid.UUID, at time.Time, data interface{}) error {
+			event, err := game.NewEvent(g.ID, eventType, playerID, at, data)
+			if err != nil {
+				return err
+			}
+			var moveLog []game.MoveRecord
+			if eventType == game.EventTakenBack {
+				if moveLog, err = s.GetMoveLog(ctx, g.ID); err != nil {
+					return err
+				}
+			}
+
+			finished := g.Status.Finished()
+			record, err := g.Apply(event, moveLog)
+			if err != nil {
+				return err
+			}
+			event.EndsGame = !finished && g.Status.Finished()
+			events = append(events, event)
+			if record != nil {
+				records = append(records, *record)
+			}
+			return nil
+		}
+
+		err = mutate(g, emit)
+		if errors.Is(err, errGameUnchanged) || (err == nil && len(events) == 0) {
+			return g, nil
+		}
+		if err != nil {
+			if conflicted && !errors.Is(err, game.ErrVersionConflict) {
+				return nil, fmt.Errorf("%w: %v", game.ErrVersionConflict, err)
+			}
+			return nil, err
+		}
+
+		err = s.saveGame(ctx, g, records, events, tag)
+		if err == nil {
+			return g, nil
+		}
+		if !errors.Is(err, game.ErrVersionConflict) {
+			return nil, err
+		}
+
+		conflicted = true
+		log.Printf("Version conflict saving game %s (attempt %d/%d), retrying", gameID, attempt, maxSaveAttempts)
+		time.Sleep(saveRetryBackoff * time.Duration(attempt))
+	}
+	return nil, game.ErrVersionConflict
+}
+
+// GetMoveLog returns the ordered move log for a game from Redis, falling back to the database
+func (s *GameService) GetMoveLog(ctx context.Context, gameID uuid.UUID) ([]game.MoveRecord, error) {
+	entries, err := s.redisClient.LRange(ctx, moveLogKey(gameID), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
 
-	// Set with 4 hour expiration
-	return s.redisClient.Set(ctx, key, data, 4*time.Hour).Err()
+	if len(entries) > 0 {
+		moves := make([]game.MoveRecord, 0, len(entries))
+		for _, entry := range entries {
+			var record game.MoveRecord
+			if err := json.Unmarshal([]byte(entry), &record); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal move record: %w", err)
+			}
+			moves = append(moves, record)
+		}
+		return moves, nil
+	}
+
+	// Not in Redis - completed games keep their log in the database
+	moves := []game.MoveRecord{}
+	if s.gameRepo == nil {
+		return moves, nil
+	}
+	data, err := s.gameRepo.GetMoves(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("game not found")
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &moves); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal move log: %w", err)
+		}
+	}
+	return moves, nil
+}
+
+// GetReplay rebuilds a finished game from its original settings and move log
+func (s *GameService) GetReplay(ctx context.Context, gameID uuid.UUID) (*game.Game, *game.Replay, error) {
+	g, err := s.GetGame(ctx, gameID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Replays of live or paused games would leak hidden information (e.g. pending RPS choices)
+	if !g.Status.Finished() {
+		return nil, nil, fmt.Errorf("replay is only available for finished games")
+	}
+
+	moves, err := s.GetMoveLog(ctx, gameID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	replay, err := game.NewReplayForPlayers(game.DefaultRegistry, g.Type, g.PlayerIDs(), g.Settings, moves)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return g, replay, nil
+}
+
+// getMoveLogData returns the move log serialized for storage in game_matches.moves
+func (s *GameService) getMoveLogData(ctx context.Context, gameID uuid.UUID) ([]byte, error) {
+	moves, err := s.GetMoveLog(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load move log: %w", err)
+	}
+	data, err := json.Marshal(moves)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal move log: %w", err)
+	}
+	return data, nil
 }
 
 // PublishGameEvent publishes a game event to Redis pub/sub
@@ -543,36 +2608,37 @@ func (s *GameService) SubscribeToGamePattern(ctx context.Context, pattern string
 
 // AddSpectator adds a spectator to a game
 func (s *GameService) AddSpectator(ctx context.Context, gameID, userID uuid.UUID, username string) (*game.Game, error) {
-	g, err := s.GetGame(ctx, gameID)
-	if err != nil {
-		return nil, err
-	}
-
-	// Check if user is already a player
-	if userID == g.Player1ID || userID == g.Player2ID {
-		return nil, fmt.Errorf("players cannot spectate their own game")
-	}
+	var spectator game.Spectator
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		spectator = game.Spectator{}
 
-	// Check if already spectating
-	for _, spec := range g.Spectators {
-		if spec.UserID == userID {
-			return g, nil // Already spectating, return current state
+		// Check if user is already a player
+		if g.IsPlayer(userID) {
+			return fmt.Errorf("players cannot spectate their own game")
 		}
-	}
 
-	// Add new spectator
-	spectator := game.Spectator{
-		UserID:   userID,
-		Username: username,
-		JoinedAt: time.Now(),
-	}
-	g.Spectators = append(g.Spectators, spectator)
-	g.UpdatedAt = time.Now()
+		// Check if already spectating
+		for _, spec := range g.Spectators {
+			if spec.UserID == userID {
+				return errGameUnchanged // Already spectating, return current state
+			}
+		}
 
-	// Save to Redis
-	if err := s.SaveGame(ctx, g); err != nil {
+		// Add new spectator
+		now := time.Now()
+		spectator = game.Spectator{
+			UserID:   userID,
+			Username: username,
+			JoinedAt: now,
+		}
+		return emit(game.EventSpectatorJoined, userID, now, spectator)
+	})
+	if err != nil {
 		return nil, err
 	}
+	if spectator.UserID == uuid.Nil {
+		return g, nil
+	}
 
 	// Publish spectator joined event
 	s.PublishGameEvent(ctx, gameID, "spectator_joined", map[string]interface{}{
@@ -587,33 +2653,31 @@ func (s *GameService) AddSpectator(ctx context.Context, gameID, userID uuid.UUID
 
 // RemoveSpectator removes a spectator from a game
 func (s *GameService) RemoveSpectator(ctx context.Context, gameID, userID uuid.UUID) (*game.Game, error) {
-	g, err := s.GetGame(ctx, gameID)
-	if err != nil {
-		return nil, err
-	}
-
-	// Find and remove spectator
-	found := false
-	newSpectators := make([]game.Spectator, 0)
-	for _, spec := range g.Spectators {
-		if spec.UserID != userID {
-			newSpectators = append(newSpectators, spec)
-		} else {
-			found = true
+	removed := false
+	g, err := s.updateGame(ctx, gameID, func(g *game.Game, emit emitFunc) error {
+		removed = false
+
+		// Find the spectator
+		found := false
+		for _, spec := range g.Spectators {
+			if spec.UserID == userID {
+				found = true
+			}
 		}
-	}
 
-	if !found {
-		return g, nil // Not spectating, return current state
-	}
-
-	g.Spectators = newSpectators
-	g.UpdatedAt = time.Now()
+		if !found {
+			return errGameUnchanged // Not spectating, return current state
+		}
 
-	// Save to Redis
-	if err := s.SaveGame(ctx, g); err != nil {
+		removed = true
+		return emit(game.EventSpectatorLeft, userID, time.Now(), nil)
+	})
+	if err != nil {
 		return nil, err
 	}
+	if !removed {
+		return g, nil
+	}
 
 	// Publish spectator left event
 	s.PublishGameEvent(ctx, gameID, "spectator_left", map[string]interface{}{
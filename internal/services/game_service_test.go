@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/arenamatch/playforge/internal/game"
+	"github.com/arenamatch/playforge/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestGameService creates a game service backed by an in-memory Redis and no database
+func newTestGameService(t *testing.T) *GameService {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewGameService(client, nil, nil)
+}
+
+// fakeMatchDB stands in for the database tables the completions consumer writes. It records
+// which games are saved as completed and counts the outbox entries added for each, without the
+// unique constraints of the real tables. Statements it does not know are ignored.
+type fakeMatchDB struct {
+	pgx.Tx    // Transactions run on the fake itself; methods the consumer does not use are not implemented
+	completed map[uuid.UUID]bool
+	outbox    map[uuid.UUID]map[string]int
+}
+
+func newFakeMatchDB() *fakeMatchDB {
+	return &fakeMatchDB{completed: map[uuid.UUID]bool{}, outbox: map[uuid.UUID]map[string]int{}}
+}
+
+func (db *fakeMatchDB) Begin(ctx context.Context) (pgx.Tx, error) { return db, nil }
+func (db *fakeMatchDB) Commit(ctx context.Context) error          { return nil }
+func (db *fakeMatchDB) Rollback(ctx context.Context) error        { return nil }
+
+func (db *fakeMatchDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	switch {
+	case strings.Contains(sql, "INSERT INTO game_matches") && strings.Contains(sql, "'completed'"):
+		db.completed[args[0].(uuid.UUID)] = true
+	case strings.Contains(sql, "INSERT INTO game_outbox"):
+		gameID := args[0].(uuid.UUID)
+		if db.outbox[gameID] == nil {
+			db.outbox[gameID] = map[string]int{}
+		}
+		db.outbox[gameID][args[1].(string)]++
+	}
+	return pgconn.CommandTag{}, nil
+}
+
+func (db *fakeMatchDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	if strings.Contains(sql, "status = 'completed' FROM game_matches") {
+		if completed, ok := db.completed[args[0].(uuid.UUID)]; ok {
+			return fakeRow{value: completed}
+		}
+	}
+	return fakeRow{err: pgx.ErrNoRows}
+}
+
+// fakeRow is a single-column row of a fakeMatchDB query
+type fakeRow struct {
+	value bool
+	err   error
+}
+
+func (r fakeRow) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	*dest[0].(*bool) = r.value
+	return nil
+}
+
+// TestConsumeEvents tests reading the game event stream as a consumer group
+func TestConsumeEvents(t *testing.T) {
+	t.Run("Events Saved Before The Group Existed Are Delivered", func(t *testing.T) {
+		s := newTestGameService(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		g, err := s.CreateGame(ctx, game.GameTypeTicTacToe, uuid.New(), "Alice")
+		require.NoError(t, err)
+
+		var received []game.Event
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			s.consumeEvents(ctx, "test", "consumer", func(ctx context.Context, e game.Event) error {
+				received = append(received, e)
+				cancel()
+				return nil
+			})
+		}()
+		<-done
+
+		require.Len(t, received, 1)
+		assert.Equal(t, game.EventCreated, received[0].Type)
+		assert.Equal(t, g.ID, received[0].GameID)
+	})
+
+	t.Run("A Completion Delivered Twice Is Recorded Once", func(t *testing.T) {
+		s := newTestGameService(t)
+		db := newFakeMatchDB()
+		s.gameRepo = repository.NewGameRepository(db)
+		player1 := uuid.New()
+		player2 := uuid.New()
+
+		g, err := s.CreateGameForTournament(context.Background(), uuid.New(), game.GameTypeTicTacToe, player1, "Alice", player2, "Bob", uuid.New(), 1, nil)
+		require.NoError(t, err)
+		g, err = s.MakeMove(context.Background(), g.ID, g.CurrentTurn, game.TicTacToeMove{Row: 1, Col: 1})
+		require.NoError(t, err)
+		g, err = s.Resign(context.Background(), g.ID, g.CurrentTurn)
+		require.NoError(t, err)
+		require.Equal(t, game.GameStatusCompleted, g.Status)
+
+		// A second group replays the whole stream, as a group created on an existing deployment does
+		for _, group := range []string{"completions", "completions-replay"} {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			handled := 0
+			s.consumeEvents(ctx, group, "consumer", func(ctx context.Context, e game.Event) error {
+				if err := s.handleCompletionEvent(ctx, e); err != nil {
+					return err
+				}
+				if e.EndsGame {
+					handled++
+					cancel()
+				}
+				return nil
+			})
+			cancel()
+			require.Equal(t, 1, handled, group)
+		}
+
+		assert.True(t, db.completed[g.ID])
+		assert.Equal(t, map[string]int{outboxStats: 1, outboxTournament: 1, outboxNotifications: 1}, db.outbox[g.ID])
+	})
+}
+
+// TestPausedGameHistory tests that a paused game's replay and events stay hidden like a live game's
+func TestPausedGameHistory(t *testing.T) {
+	s := newTestGameService(t)
+	ctx := context.Background()
+	player1 := uuid.New()
+	player2 := uuid.New()
+
+	g, err := s.CreateGame(ctx, game.GameTypeRockPaperScissors, player1, "Alice")
+	require.NoError(t, err)
+	_, err = s.JoinGame(ctx, g.ID, player2, "Bob")
+	require.NoError(t, err)
+	_, err = s.RequestPause(ctx, g.ID, player1)
+	require.NoError(t, err)
+	g, err = s.AcceptPause(ctx, g.ID, player2)
+	require.NoError(t, err)
+	require.Equal(t, game.GameStatusPaused, g.Status)
+
+	_, _, err = s.GetReplay(ctx, g.ID)
+	assert.Error(t, err)
+	_, err = s.GetGameEvents(ctx, g.ID)
+	assert.Error(t, err)
+}
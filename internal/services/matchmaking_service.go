@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/arenamatch/playforge/internal/domain"
+	"github.com/arenamatch/playforge/internal/game"
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
@@ -24,6 +25,7 @@ const (
 	matchmakingInterval  = 2 * time.Second           // How often to check for matches
 	ratingRange          = 200                       // Initial ELO range for matching
 	ratingRangeIncrease  = 50                        // Increase range every 30 seconds
+	botBackfillAfter     = 45 * time.Second          // Offer a bot opponent after waiting this long
 )
 
 type MatchmakingService struct {
@@ -162,12 +164,13 @@ func (s *MatchmakingService) FindMatches(ctx context.Context, gameType string) e
 		return fmt.Errorf("failed to get queue: %w", err)
 	}
 
-	if len(entries) < 2 {
-		return nil // Not enough players
+	if len(entries) == 0 {
+		return nil
 	}
 
 	// Try to match players
 	matched := make(map[string]bool)
+	var unmatched []*domain.QueueEntry
 
 	for i := 0; i < len(entries); i++ {
 		if matched[entries[i].Member.(string)] {
@@ -224,8 +227,75 @@ func (s *MatchmakingService) FindMatches(ctx context.Context, gameType string) e
 				break
 			}
 		}
+
+		if !matched[entry1.ID.String()] {
+			unmatched = append(unmatched, entry1)
+		}
+	}
+
+	// Backfill players who have waited too long with a bot
+	if game.HasBot(game.GameType(gameType)) {
+		for _, entry := range unmatched {
+			if time.Since(entry.QueuedAt) < botBackfillAfter {
+				continue
+			}
+			if err := s.createBotMatch(ctx, entry); err != nil {
+				fmt.Printf("Failed to create bot match: %v\n", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// botDifficultyForRating picks a bot roughly matching a player's rating
+func botDifficultyForRating(rating int) game.BotDifficulty {
+	switch {
+	case rating < game.BotRating(game.BotMedium)-200:
+		return game.BotEasy
+	case rating < game.BotRating(game.BotHard)-200:
+		return game.BotMedium
+	default:
+		return game.BotHard
+	}
+}
+
+// createBotMatch creates an unrated quick play room against a bot for a player nobody was matched with
+func (s *MatchmakingService) createBotMatch(ctx context.Context, entry *domain.QueueEntry) error {
+	difficulty := botDifficultyForRating(entry.Rating)
+	room, err := s.roomService.CreateRoom(ctx, entry.UserID, entry.Username, domain.CreateRoomRequest{
+		GameType:      entry.GameType,
+		Type:          domain.RoomTypeQuickPlay,
+		MaxPlayers:    2,
+		BotDifficulty: string(difficulty),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create bot room: %w", err)
+	}
+
+	entry.Status = domain.MatchmakingStatusMatched
+	entry.MatchedRoomID = &room.ID
+	entry.MatchedBot = string(difficulty)
+
+	entryJSON, _ := json.Marshal(entry)
+	pipe := s.redisClient.Pipeline()
+	pipe.Set(ctx, queueEntryKey(entry.ID), entryJSON, 5*time.Minute)
+	pipe.ZRem(ctx, queueKey(entry.GameType), entry.ID.String())
+	pipe.Expire(ctx, userQueueKey(entry.UserID), 5*time.Minute)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to update queue entry: %w", err)
 	}
 
+	// Publish match found event (for WebSocket notification)
+	matchFoundData := map[string]interface{}{
+		"entry1_id":      entry.ID.String(),
+		"room_id":        room.ID.String(),
+		"join_code":      room.JoinCode,
+		"bot_difficulty": string(difficulty),
+	}
+	matchFoundJSON, _ := json.Marshal(matchFoundData)
+	s.redisClient.Publish(ctx, "matchmaking:match_found", matchFoundJSON)
+
 	return nil
 }
 
@@ -314,16 +384,14 @@ func (s *MatchmakingService) StartMatchmakingWorker(ctx context.Context) {
 	ticker := time.NewTicker(matchmakingInterval)
 	defer ticker.Stop()
 
-	gameTypes := []string{"tictactoe", "connect4", "rps", "dotsandboxes"}
-
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			// Run matchmaking for each game type
-			for _, gameType := range gameTypes {
-				err := s.FindMatches(ctx, gameType)
+			// Run matchmaking for each registered game type
+			for _, gameType := range game.RegisteredTypes() {
+				err := s.FindMatches(ctx, string(gameType))
 				if err != nil {
 					fmt.Printf("Matchmaking error for %s: %v\n", gameType, err)
 				}
@@ -167,6 +167,39 @@ func (s *NotificationService) NotifyInvitationDeclined(ctx context.Context, invi
 	return err
 }
 
+// NotifyTournamentGameResult tells both players of a tournament game how it ended
+func (s *NotificationService) NotifyTournamentGameResult(ctx context.Context, playerIDs []uuid.UUID, winnerID *uuid.UUID, round int, tournamentID uuid.UUID, gameID uuid.UUID) error {
+	for _, playerID := range playerIDs {
+		title, message := "Tournament Game Drawn", fmt.Sprintf("Your round %d game ended in a draw", round)
+		switch {
+		case winnerID != nil && *winnerID == playerID:
+			title, message = "Tournament Game Won", fmt.Sprintf("You won your round %d game and advance in the bracket", round)
+		case winnerID != nil:
+			title, message = "Tournament Game Lost", fmt.Sprintf("You lost your round %d game and are out of the tournament", round)
+		}
+
+		req := &domain.CreateNotificationRequest{
+			UserID:  playerID,
+			Type:    domain.NotificationTypeGameResult,
+			Title:   title,
+			Message: message,
+			Data: map[string]interface{}{
+				"tournament_id": tournamentID.String(),
+				"game_id":       gameID.String(),
+				"round":         round,
+			},
+		}
+
+		_, err := s.repo.CreateNotification(ctx, req)
+		if err != nil {
+			// Log error but continue notifying the other player
+			continue
+		}
+	}
+
+	return nil
+}
+
 // CleanupOldNotifications removes notifications older than 30 days
 func (s *NotificationService) CleanupOldNotifications(ctx context.Context) error {
 	return s.repo.DeleteOldNotifications(ctx, 30*24*time.Hour)
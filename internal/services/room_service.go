@@ -55,6 +55,29 @@ func (s *RoomService) CreateRoom(ctx context.Context, hostID uuid.UUID, hostUser
 	}
 	
 	fmt.Printf("Final game settings after validation: %+v\n", gameSettings)
+
+	if req.BotDifficulty != "" {
+		if _, err := game.ParseBotDifficulty(req.BotDifficulty); err != nil {
+			return nil, err
+		}
+		if !game.HasBot(game.GameType(req.GameType)) {
+			return nil, fmt.Errorf("%s cannot be played against a bot", req.GameType)
+		}
+		// Bot rooms seat the host and the bot only
+		if def, ok := game.Lookup(game.GameType(req.GameType)); ok && def.MinPlayers > 2 {
+			return nil, fmt.Errorf("%s needs at least %d players and cannot be played against a bot alone", req.GameType, def.MinPlayers)
+		}
+	}
+
+	if req.SeriesBestOf != 0 {
+		if !validSeriesBestOf(req.SeriesBestOf) {
+			return nil, fmt.Errorf("a series must be best of %v", game.SeriesBestOfOptions)
+		}
+		// Seats swap between the games of a series, which needs exactly two players
+		if def, ok := game.Lookup(game.GameType(req.GameType)); ok && def.MinPlayers > 2 {
+			return nil, fmt.Errorf("%s cannot be played as a series", req.GameType)
+		}
+	}
 	
 	room := &domain.Room{
 		ID:           uuid.New(),
@@ -74,11 +97,20 @@ func (s *RoomService) CreateRoom(ctx context.Context, hostID uuid.UUID, hostUser
 				JoinedAt: time.Now(),
 			},
 		},
+		BotDifficulty: req.BotDifficulty,
+		BotRated:      req.BotRated,
+		SeriesBestOf:  req.SeriesBestOf,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 		ExpiresAt: time.Now().Add(roomTTL),
 	}
 
+	// The bot takes the second seat, so the host is the only human and the room is already full
+	if room.BotDifficulty != "" {
+		room.MaxPlayers = 1
+		room.Status = domain.RoomStatusReady
+	}
+
 	// Save room to Redis
 	err := s.saveRoom(ctx, room)
 	if err != nil {
@@ -342,8 +374,12 @@ func (s *RoomService) StartGame(ctx context.Context, roomID uuid.UUID, hostID uu
 		return nil, fmt.Errorf("only host can start the game")
 	}
 
-	// Check if room has enough players
-	if len(room.Participants) < 2 {
+	// Check if room has enough players (a bot fills the second seat)
+	requiredPlayers := 2
+	if room.BotDifficulty != "" {
+		requiredPlayers = 1
+	}
+	if len(room.Participants) < requiredPlayers {
 		return nil, fmt.Errorf("not enough players")
 	}
 
@@ -360,26 +396,70 @@ func (s *RoomService) StartGame(ctx context.Context, roomID uuid.UUID, hostID uu
 		return nil, fmt.Errorf("not all participants are ready")
 	}
 
+	// Multiplayer games seat everyone in the room; two-player games the first two participants
+	seats := 2
+	if def, ok := game.Lookup(game.GameType(room.GameType)); ok && def.MaxPlayers > 2 && room.BotDifficulty == "" {
+		seats = len(room.Participants)
+		if seats < def.MinPlayers || seats > def.MaxPlayers {
+			return nil, fmt.Errorf("%s needs between %d and %d players", def.Name, def.MinPlayers, def.MaxPlayers)
+		}
+		settings := domain.GameSettings{}
+		if room.GameSettings != nil {
+			settings = *room.GameSettings
+		}
+		settings.Players = seats
+		room.GameSettings = &settings
+	}
+
 	// Create the actual game with custom settings
 	player1 := room.Participants[0]
-	player2 := room.Participants[1]
+
+	// A series is played by the two seated players, swapping seats every game
+	var series *game.Series
+	if room.SeriesBestOf != 0 {
+		if seats > 2 {
+			return nil, fmt.Errorf("a series can only be played between two players")
+		}
+		player2ID := game.BotPlayerID(game.BotDifficulty(room.BotDifficulty))
+		if room.BotDifficulty == "" {
+			player2ID = room.Participants[1].UserID
+		}
+		if series, err = game.NewSeries(room.SeriesBestOf, player1.UserID, player2ID); err != nil {
+			return nil, err
+		}
+	}
 	
 	fmt.Printf("StartGame: Creating game with settings: %+v\n", room.GameSettings)
-	game, err := gameService.CreateGameWithSettings(ctx, game.GameType(room.GameType), player1.UserID, player1.Username, room.GameSettings)
+	// Private rooms are casual; quickplay and ranked games count for rating.
+	// Bot games only count when the host asked for a rated game.
+	rated := room.Type != domain.RoomTypePrivate
+	if room.BotDifficulty != "" {
+		rated = room.BotRated
+	}
+	newGame, err := gameService.CreateRoomGame(ctx, room.ID, rated, series, game.GameType(room.GameType), player1.UserID, player1.Username, room.GameSettings)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create game: %w", err)
 	}
 	
-	// Join player 2 to the game
-	_, err = gameService.JoinGame(ctx, game.ID, player2.UserID, player2.Username)
-	if err != nil {
-		return nil, fmt.Errorf("failed to join player 2: %w", err)
+	// Seat the bot or join player 2 to the game
+	if room.BotDifficulty != "" {
+		_, err = gameService.AddBot(ctx, newGame.ID, game.BotDifficulty(room.BotDifficulty))
+		if err != nil {
+			return nil, fmt.Errorf("failed to add bot: %w", err)
+		}
+	} else {
+		for seat, participant := range room.Participants[1:seats] {
+			_, err = gameService.JoinGame(ctx, newGame.ID, participant.UserID, participant.Username)
+			if err != nil {
+				return nil, fmt.Errorf("failed to join player %d: %w", seat+2, err)
+			}
+		}
 	}
 
 	// Update room status and game ID
 	now := time.Now()
 	room.Status = domain.RoomStatusActive
-	room.GameID = &game.ID
+	room.GameID = &newGame.ID
 	room.StartedAt = &now
 	room.UpdatedAt = now
 
@@ -395,6 +475,30 @@ func (s *RoomService) StartGame(ctx context.Context, roomID uuid.UUID, hostID uu
 	return room, nil
 }
 
+// SetCurrentGame points a room at the game now being played in it, such as a rematch or the
+// next game of a series
+func (s *RoomService) SetCurrentGame(ctx context.Context, roomID uuid.UUID, gameID uuid.UUID) error {
+	room, err := s.GetRoom(ctx, roomID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	room.Status = domain.RoomStatusActive
+	room.GameID = &gameID
+	room.UpdatedAt = now
+
+	err = s.saveRoom(ctx, room)
+	if err != nil {
+		return err
+	}
+
+	// Publish game started event
+	s.publishRoomEvent(ctx, "game_started", room)
+
+	return nil
+}
+
 // CloseRoom closes a room
 func (s *RoomService) CloseRoom(ctx context.Context, roomID uuid.UUID) error {
 	room, err := s.GetRoom(ctx, roomID)
@@ -458,59 +562,55 @@ func roomCodeKey(joinCode string) string {
 	return fmt.Sprintf("%s%s", roomCodeKeyPrefix, joinCode)
 }
 
+// validSeriesBestOf reports whether rooms may play a series of the given length
+func validSeriesBestOf(bestOf int) bool {
+	for _, option := range game.SeriesBestOfOptions {
+		if bestOf == option {
+			return true
+		}
+	}
+	return false
+}
+
 // getDefaultGameSettings returns default settings for each game type
 func getDefaultGameSettings(gameType string) *domain.GameSettings {
-	settings := &domain.GameSettings{}
-	
-	switch gameType {
-	case "tictactoe":
-		settings.TicTacToeGridSize = 3
-		settings.TicTacToeWinLength = 3
-	case "connect4":
-		settings.Connect4Rows = 6
-		settings.Connect4Cols = 7
-		settings.Connect4WinLength = 4
-	case "rps":
-		settings.RPSBestOf = 5
-	case "dotsandboxes":
-		settings.DotsGridSize = 5
+	defaults, err := game.DefaultRegistry.DefaultSettings(game.GameType(gameType))
+	if err != nil {
+		return &domain.GameSettings{}
 	}
-	
-	return settings
+	return gameSettingsFromMap(defaults)
 }
 
 // validateAndFillGameSettings validates settings and fills in defaults for missing values
 func validateAndFillGameSettings(gameType string, settings *domain.GameSettings) *domain.GameSettings {
-	defaults := getDefaultGameSettings(gameType)
-	
-	switch gameType {
-	case "tictactoe":
-		if settings.TicTacToeGridSize < 3 || settings.TicTacToeGridSize > 5 {
-			settings.TicTacToeGridSize = defaults.TicTacToeGridSize
-		}
-		if settings.TicTacToeWinLength == 0 || settings.TicTacToeWinLength > settings.TicTacToeGridSize {
-			settings.TicTacToeWinLength = settings.TicTacToeGridSize
-		}
-	case "connect4":
-		if settings.Connect4Rows < 4 || settings.Connect4Rows > 10 {
-			settings.Connect4Rows = defaults.Connect4Rows
-		}
-		if settings.Connect4Cols < 4 || settings.Connect4Cols > 10 {
-			settings.Connect4Cols = defaults.Connect4Cols
-		}
-		if settings.Connect4WinLength < 4 || settings.Connect4WinLength > 6 {
-			settings.Connect4WinLength = defaults.Connect4WinLength
-		}
-	case "rps":
-		if settings.RPSBestOf < 3 || settings.RPSBestOf > 9 || settings.RPSBestOf%2 == 0 {
-			settings.RPSBestOf = defaults.RPSBestOf
-		}
-	case "dotsandboxes":
-		if settings.DotsGridSize < 4 || settings.DotsGridSize > 8 {
-			settings.DotsGridSize = defaults.DotsGridSize
-		}
+	validated, err := game.DefaultRegistry.ValidateSettings(game.GameType(gameType), gameSettingsToMap(settings))
+	if err != nil {
+		return settings
 	}
-	
-	return settings
+	return gameSettingsFromMap(validated)
+}
+
+// gameSettingsToMap converts typed settings to the map form used by the game registry
+func gameSettingsToMap(settings *domain.GameSettings) map[string]interface{} {
+	if settings == nil {
+		return nil
+	}
+	var settingsMap map[string]interface{}
+	settingsJSON, err := json.Marshal(settings)
+	if err != nil {
+		return nil
+	}
+	json.Unmarshal(settingsJSON, &settingsMap)
+	return settingsMap
 }
 
+// gameSettingsFromMap converts registry settings back into typed settings
+func gameSettingsFromMap(settingsMap map[string]interface{}) *domain.GameSettings {
+	settings := &domain.GameSettings{}
+	settingsJSON, err := json.Marshal(settingsMap)
+	if err != nil {
+		return settings
+	}
+	json.Unmarshal(settingsJSON, settings)
+	return settings
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/arenamatch/playforge/internal/game"
 	"github.com/arenamatch/playforge/internal/repository"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
 const (
@@ -35,6 +37,14 @@ func NewStatsService(statsRepo *repository.StatsRepository, userRepo *repository
 	}
 }
 
+// WithTx returns a copy of the service whose updates run in tx, so they commit or roll back with it
+func (s *StatsService) WithTx(tx pgx.Tx) *StatsService {
+	return &StatsService{
+		statsRepo: s.statsRepo.WithTx(tx),
+		userRepo:  s.userRepo.WithTx(tx),
+	}
+}
+
 // UpdateGameStats updates player stats and ELO ratings after a game completes
 func (s *StatsService) UpdateGameStats(ctx context.Context, gameType string, player1ID, player2ID uuid.UUID, winnerID *uuid.UUID) error {
 	return s.updateGameStatsWithContext(ctx, gameType, player1ID, player2ID, winnerID, false, 0)
@@ -135,6 +145,119 @@ func (s *StatsService) updateGameStatsWithContext(ctx context.Context, gameType
 	return nil
 }
 
+// UpdateGameStatsAgainstBot updates the human player's stats and ELO after a rated game against a bot.
+// The bot plays at a fixed rating and its own record is left untouched.
+func (s *StatsService) UpdateGameStatsAgainstBot(ctx context.Context, gameType string, humanID, botID uuid.UUID, botRating int, winnerID *uuid.UUID) error {
+	human, err := s.userRepo.GetByID(ctx, humanID)
+	if err != nil {
+		return fmt.Errorf("failed to get player: %w", err)
+	}
+
+	isDraw := winnerID == nil
+	humanWon := !isDraw && *winnerID == humanID
+	botWon := !isDraw && *winnerID == botID
+
+	newElo, _ := s.calculateEloChange(human.EloRating, botRating, humanWon, botWon, isDraw)
+	if err := s.userRepo.UpdateEloRating(ctx, humanID, newElo); err != nil {
+		return fmt.Errorf("failed to update player ELO: %w", err)
+	}
+
+	if _, err := s.statsRepo.GetOrCreateStats(ctx, humanID, gameType); err != nil {
+		return fmt.Errorf("failed to get/create player stats: %w", err)
+	}
+	if err := s.statsRepo.UpdateStats(ctx, humanID, gameType, humanWon, isDraw); err != nil {
+		return fmt.Errorf("failed to update player stats: %w", err)
+	}
+
+	fmt.Printf("Rated bot game - Stats updated - Player: %d->%d ELO (bot rated %d)\n", human.EloRating, newElo, botRating)
+	return nil
+}
+
+// UpdateMultiplayerGameStats updates stats, placements and ELO ratings after a game with more
+// than two players. standings groups the players by finishing position, best first. A sole first
+// place counts as a win and a shared one as a draw; everyone else records a loss.
+func (s *StatsService) UpdateMultiplayerGameStats(ctx context.Context, gameType string, standings [][]uuid.UUID) error {
+	var playerIDs []uuid.UUID
+	var placements []int
+	position := 1
+	for _, group := range standings {
+		for _, playerID := range group {
+			playerIDs = append(playerIDs, playerID)
+			placements = append(placements, position)
+		}
+		position += len(group)
+	}
+	if len(playerIDs) < 2 {
+		return nil
+	}
+
+	ratings := make([]int, len(playerIDs))
+	for i, playerID := range playerIDs {
+		player, err := s.userRepo.GetByID(ctx, playerID)
+		if err != nil {
+			return fmt.Errorf("failed to get player %s: %w", playerID, err)
+		}
+		ratings[i] = player.EloRating
+	}
+	newRatings := s.calculateMultiplayerEloChange(ratings, placements)
+
+	sharedFirst := len(standings[0]) > 1
+	for i, playerID := range playerIDs {
+		if err := s.userRepo.UpdateEloRating(ctx, playerID, newRatings[i]); err != nil {
+			return fmt.Errorf("failed to update ELO of player %s: %w", playerID, err)
+		}
+		if _, err := s.statsRepo.GetOrCreateStats(ctx, playerID, gameType); err != nil {
+			return fmt.Errorf("failed to get/create stats of player %s: %w", playerID, err)
+		}
+
+		won := placements[i] == 1 && !sharedFirst
+		draw := placements[i] == 1 && sharedFirst
+		if err := s.statsRepo.UpdateStats(ctx, playerID, gameType, won, draw); err != nil {
+			return fmt.Errorf("failed to update stats of player %s: %w", playerID, err)
+		}
+		if err := s.statsRepo.RecordPlacement(ctx, playerID, gameType, placements[i]); err != nil {
+			return fmt.Errorf("failed to record placement of player %s: %w", playerID, err)
+		}
+
+		fmt.Printf("Multiplayer game - Player %s placed %d: %d->%d ELO\n", playerID, placements[i], ratings[i], newRatings[i])
+	}
+
+	return nil
+}
+
+// calculateMultiplayerEloChange scores a game between n players as n-1 head-to-head results per
+// player: finishing ahead of someone is a win against them and sharing a placement is a draw.
+// The K-factor is split across the pairings, so one multiplayer game moves a rating about as
+// far as one two-player game.
+func (s *StatsService) calculateMultiplayerEloChange(ratings, placements []int) []int {
+	k := EloKFactor / float64(len(ratings)-1)
+
+	newRatings := make([]int, len(ratings))
+	for i := range ratings {
+		change := 0.0
+		for j := range ratings {
+			if i == j {
+				continue
+			}
+			expected := 1.0 / (1.0 + math.Pow(10, float64(ratings[j]-ratings[i])/400.0))
+			score := 0.5
+			if placements[i] < placements[j] {
+				score = 1.0
+			} else if placements[i] > placements[j] {
+				score = 0.0
+			}
+			change += k * (score - expected)
+		}
+
+		newRatings[i] = ratings[i] + int(math.Round(change))
+		// Ensure ratings don't go below 100
+		if newRatings[i] < 100 {
+			newRatings[i] = 100
+		}
+	}
+	return newRatings
+}
+
 // calculateEloChange calculates new ELO ratings for both players
 func (s *StatsService) calculateEloChange(player1Elo, player2Elo int, player1Won, player2Won, isDraw bool) (int, int) {
 	// Calculate expected scores
@@ -261,6 +384,24 @@ func getTournamentRoundName(round int) string {
 	}
 }
 
+// GetRatings returns the current rating of each player that could be looked up
+func (s *StatsService) GetRatings(ctx context.Context, playerIDs ...uuid.UUID) map[uuid.UUID]int {
+	ratings := make(map[uuid.UUID]int, len(playerIDs))
+	for _, playerID := range playerIDs {
+		if player, err := s.userRepo.GetByID(ctx, playerID); err == nil {
+			ratings[playerID] = player.EloRating
+		}
+	}
+	return ratings
+}
+
+// RecordFirstMove counts a finished two-player game for the player who moved first, so stats
+// show how often they win with the first move
+func (s *StatsService) RecordFirstMove(ctx context.Context, gameType string, firstPlayerID uuid.UUID, winnerID *uuid.UUID) error {
+	won := winnerID != nil && *winnerID == firstPlayerID
+	return s.statsRepo.RecordFirstMove(ctx, firstPlayerID, gameType, won)
+}
+
 // GetPlayerStats retrieves player statistics for a specific game type
 func (s *StatsService) GetPlayerStats(ctx context.Context, userID uuid.UUID, gameType string) (*repository.PlayerStats, error) {
 	return s.statsRepo.GetOrCreateStats(ctx, userID, gameType)
@@ -268,8 +409,8 @@ func (s *StatsService) GetPlayerStats(ctx context.Context, userID uuid.UUID, gam
 
 // GetAggregatedStats retrieves aggregated player statistics across all game types
 func (s *StatsService) GetAggregatedStats(ctx context.Context, userID uuid.UUID) (*repository.PlayerStats, error) {
-	// Get stats for all game types
-	gameTypes := []string{"tictactoe", "connect4", "rps", "dotsandboxes"}
+	// Get stats for all registered game types
+	gameTypes := game.RegisteredTypes()
 	
 	aggregated := &repository.PlayerStats{
 		ID:            uuid.New(),
@@ -284,7 +425,7 @@ func (s *StatsService) GetAggregatedStats(ctx context.Context, userID uuid.UUID)
 	}
 	
 	for _, gameType := range gameTypes {
-		stats, err := s.statsRepo.GetOrCreateStats(ctx, userID, gameType)
+		stats, err := s.statsRepo.GetOrCreateStats(ctx, userID, string(gameType))
 		if err != nil {
 			continue // Skip if stats don't exist yet
 		}
@@ -293,6 +434,11 @@ func (s *StatsService) GetAggregatedStats(ctx context.Context, userID uuid.UUID)
 		aggregated.Losses += stats.Losses
 		aggregated.Draws += stats.Draws
 		aggregated.TotalGames += stats.TotalGames
+		aggregated.FirstPlaces += stats.FirstPlaces
+		aggregated.SecondPlaces += stats.SecondPlaces
+		aggregated.ThirdPlaces += stats.ThirdPlaces
+		aggregated.FirstMoveGames += stats.FirstMoveGames
+		aggregated.FirstMoveWins += stats.FirstMoveWins
 		
 		// Take the highest streak values
 		if stats.CurrentStreak > aggregated.CurrentStreak {
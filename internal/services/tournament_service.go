@@ -96,6 +96,7 @@ func (s *TournamentService) CreateTournament(ctx context.Context, userID uuid.UU
 		IsPrivate:       req.IsPrivate,
 		JoinCode:        joinCode,
 		CreatedBy:       userID,
+		GameSettings:    room.GameSettings,
 		Participants: []domain.TournamentParticipant{
 			{
 				UserID:       userID,
@@ -482,6 +483,7 @@ func (s *TournamentService) createGamesForReadyMatches(ctx context.Context, tour
 				match.Player2Name,
 				tournament.ID,           // Tournament ID
 				round.RoundNumber,       // Tournament round
+				tournament.GameSettings, // Validated when the tournament room was created
 			)
 				if err != nil {
 					log.Printf("ERROR: Failed to create game for match %d in round %d: %v", match.MatchNumber, round.RoundNumber, err)
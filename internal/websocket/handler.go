@@ -3,10 +3,12 @@ package websocket
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"strings"
 	"time"
 
+	"github.com/arenamatch/playforge/internal/game"
 	"github.com/arenamatch/playforge/internal/services"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/websocket/v2"
@@ -38,13 +40,31 @@ type Handler struct {
 
 // NewHandler creates a new WebSocket handler
 func NewHandler(hub *Hub, authService *services.AuthService, gameService *services.GameService, roomService *services.RoomService, matchmakingService *services.MatchmakingService) *Handler {
-	return &Handler{
+	h := &Handler{
 		hub:                hub,
 		authService:        authService,
 		gameService:        gameService,
 		roomService:        roomService,
 		matchmakingService: matchmakingService,
 	}
+	hub.SetPresenceHandler(h.handlePresence)
+	return h
+}
+
+// handlePresence forwards per-game connection changes to the game service, which starts
+// or cancels the player's reconnection grace window
+func (h *Handler) handlePresence(gameID, userID uuid.UUID, online bool) {
+	ctx := context.Background()
+
+	var err error
+	if online {
+		err = h.gameService.PlayerReconnected(ctx, gameID, userID)
+	} else {
+		err = h.gameService.PlayerDisconnected(ctx, gameID, userID)
+	}
+	if err != nil {
+		log.Printf("Error updating presence of user %s in game %s: %v", userID, gameID, err)
+	}
 }
 
 // HandleConnection handles WebSocket upgrade and client connection
@@ -166,6 +186,14 @@ func (h *Handler) handleMessage(client *Client, msg *Message) {
 	case MessageTypeGameMove:
 		h.handleGameMove(client, msg)
 
+	case MessageTypeGameResign, MessageTypeGameOfferDraw, MessageTypeGameAcceptDraw,
+		MessageTypeGameDeclineDraw, MessageTypeGameAbort, MessageTypeGameOfferRematch,
+		MessageTypeGameAcceptRematch, MessageTypeGameDeclineRematch, MessageTypeGameRequestTakeback,
+		MessageTypeGameAcceptTakeback, MessageTypeGameDeclineTakeback, MessageTypeGameRequestPause,
+		MessageTypeGameAcceptPause, MessageTypeGameDeclinePause, MessageTypeGameRequestResume,
+		MessageTypeGameAcceptResume, MessageTypeGameDeclineResume:
+		h.handleGameAction(client, msg)
+
 	case MessageTypeRoomJoined:
 		h.handleRoomJoin(client, msg)
 
@@ -270,26 +298,127 @@ func (h *Handler) handleGameMove(client *Client, msg *Message) {
 
 	log.Printf("Processing move from client %s (User: %s) for game %s: %v", client.ID, client.Username, gameID, move)
 
-	// Make move
-	g, err := h.gameService.MakeMove(ctx, gameID, playerID, move)
+	// Make move (against the client's last seen version if provided). Moves tagged with a
+	// client_move_id are applied once however often they are resent.
+	var g *game.Game
+	var receipt *services.MoveReceipt
+	clientMoveID, _ := payload["client_move_id"].(string)
+	var expectedVersion *int64
+	if version, ok := payload["version"].(float64); ok {
+		v := int64(version)
+		expectedVersion = &v
+	}
+	switch {
+	case clientMoveID != "":
+		g, receipt, err = h.gameService.MakeMoveWithID(ctx, gameID, playerID, move, expectedVersion, clientMoveID)
+	case expectedVersion != nil:
+		g, err = h.gameService.MakeMoveAtVersion(ctx, gameID, playerID, move, *expectedVersion)
+	default:
+		g, err = h.gameService.MakeMove(ctx, gameID, playerID, move)
+	}
 	if err != nil {
 		log.Printf("Error making move: %v", err)
+		if errors.Is(err, game.ErrVersionConflict) || errors.Is(err, game.ErrDuplicateFrame) {
+			h.sendErrorCode(client, 409, err.Error())
+			return
+		}
 		h.sendError(client, err.Error())
 		return
 	}
 
 	log.Printf("Move successful! New game status: %s", g.Status)
 
+	if receipt != nil {
+		SendMoveAck(client, gameID, receipt)
+	}
+
 	// The move event will be broadcast via Redis pub/sub, 
 	// so we don't need to broadcast here - it's handled by the game handler's Redis listener
 }
 
+// handleGameAction processes resign, draw, abort, takeback, pause and rematch requests from a player
+func (h *Handler) handleGameAction(client *Client, msg *Message) {
+	ctx := context.Background()
+
+	payload, ok := msg.Payload.(map[string]interface{})
+	if !ok {
+		h.sendError(client, "Invalid game action payload")
+		return
+	}
+
+	gameIDStr, ok := payload["game_id"].(string)
+	if !ok {
+		h.sendError(client, "Missing game_id")
+		return
+	}
+
+	gameID, err := uuid.Parse(gameIDStr)
+	if err != nil {
+		h.sendError(client, "Invalid game_id")
+		return
+	}
+
+	// The acting player is always the authenticated user
+	switch msg.Type {
+	case MessageTypeGameResign:
+		_, err = h.gameService.Resign(ctx, gameID, client.UserID)
+	case MessageTypeGameOfferDraw:
+		_, err = h.gameService.OfferDraw(ctx, gameID, client.UserID)
+	case MessageTypeGameAcceptDraw:
+		_, err = h.gameService.AcceptDraw(ctx, gameID, client.UserID)
+	case MessageTypeGameDeclineDraw:
+		_, err = h.gameService.DeclineDraw(ctx, gameID, client.UserID)
+	case MessageTypeGameAbort:
+		_, err = h.gameService.Abort(ctx, gameID, client.UserID)
+	case MessageTypeGameRequestTakeback:
+		_, err = h.gameService.RequestTakeback(ctx, gameID, client.UserID)
+	case MessageTypeGameAcceptTakeback:
+		_, err = h.gameService.AcceptTakeback(ctx, gameID, client.UserID)
+	case MessageTypeGameDeclineTakeback:
+		_, err = h.gameService.DeclineTakeback(ctx, gameID, client.UserID)
+	case MessageTypeGameRequestPause:
+		_, err = h.gameService.RequestPause(ctx, gameID, client.UserID)
+	case MessageTypeGameAcceptPause:
+		_, err = h.gameService.AcceptPause(ctx, gameID, client.UserID)
+	case MessageTypeGameDeclinePause:
+		_, err = h.gameService.DeclinePause(ctx, gameID, client.UserID)
+	case MessageTypeGameRequestResume:
+		_, err = h.gameService.RequestResume(ctx, gameID, client.UserID)
+	case MessageTypeGameAcceptResume:
+		_, err = h.gameService.AcceptResume(ctx, gameID, client.UserID)
+	case MessageTypeGameDeclineResume:
+		_, err = h.gameService.DeclineResume(ctx, gameID, client.UserID)
+	case MessageTypeGameOfferRematch:
+		_, err = h.gameService.OfferRematch(ctx, gameID, client.UserID)
+	case MessageTypeGameAcceptRematch:
+		_, err = h.gameService.AcceptRematch(ctx, gameID, client.UserID)
+	case MessageTypeGameDeclineRematch:
+		_, err = h.gameService.DeclineRematch(ctx, gameID, client.UserID)
+	}
+	if err != nil {
+		log.Printf("Error handling %s from %s for game %s: %v", msg.Type, client.Username, gameID, err)
+		if errors.Is(err, game.ErrVersionConflict) {
+			h.sendErrorCode(client, 409, err.Error())
+			return
+		}
+		h.sendError(client, err.Error())
+		return
+	}
+
+	// The result is broadcast via Redis pub/sub by the game handler's listener
+}
+
 // sendError sends an error message to a client
 func (h *Handler) sendError(client *Client, message string) {
+	h.sendErrorCode(client, 400, message)
+}
+
+// sendErrorCode sends an error message with a specific code to a client
+func (h *Handler) sendErrorCode(client *Client, code int, message string) {
 	msg := Message{
 		Type: MessageTypeError,
 		Payload: ErrorMessage{
-			Code:    400,
+			Code:    code,
 			Message: message,
 		},
 		Timestamp: time.Now(),
@@ -303,6 +432,27 @@ func (h *Handler) sendError(client *Client, message string) {
 	}
 }
 
+// SendMoveAck tells a client what became of a move it sent with a client_move_id
+func SendMoveAck(client *Client, gameID uuid.UUID, receipt *services.MoveReceipt) {
+	msg := Message{
+		Type: MessageTypeGameMoveAck,
+		Payload: GameMoveAckMessage{
+			GameID:       gameID.String(),
+			ClientMoveID: receipt.ClientMoveID,
+			Version:      receipt.Version,
+			Duplicate:    receipt.Duplicate,
+		},
+		Timestamp: time.Now(),
+	}
+
+	if data, err := json.Marshal(msg); err == nil {
+		select {
+		case client.Send <- data:
+		default:
+		}
+	}
+}
+
 // sendPong sends a pong message to a client
 func (h *Handler) sendPong(client *Client) {
 	msg := Message{
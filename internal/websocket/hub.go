@@ -26,17 +26,28 @@ type Hub struct {
 	// Broadcast message to all clients in a game
 	broadcast chan *BroadcastMessage
 
+	// Called when a user's first connection to a game opens or their last one closes
+	onPresence PresenceFunc
+
 	// Mutex for thread-safe operations
 	mu sync.RWMutex
 }
 
+// PresenceFunc is notified when a user comes online in a game or loses their last connection to it
+type PresenceFunc func(gameID, userID uuid.UUID, online bool)
+
 // BroadcastMessage represents a message to broadcast to a game
 type BroadcastMessage struct {
 	GameID  uuid.UUID
 	Message []byte
 	Exclude *uuid.UUID // Exclude this client from broadcast
+	Build   BuildFunc  // When set, builds each user's message instead of sending Message
 }
 
+// BuildFunc builds the message a user receives, for broadcasts that differ per recipient
+// (e.g. game state with the other player's hidden information removed). A nil result skips the user.
+type BuildFunc func(userID uuid.UUID) []byte
+
 // NewHub creates a new Hub instance
 func NewHub() *Hub {
 	return &Hub{
@@ -48,6 +59,30 @@ func NewHub() *Hub {
 	}
 }
 
+// SetPresenceHandler sets the function notified of per-game presence changes
+func (h *Hub) SetPresenceHandler(fn PresenceFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onPresence = fn
+}
+
+// userInGame reports whether a user still has a connection in a game. Callers must hold h.mu.
+func (h *Hub) userInGame(gameID, userID uuid.UUID) bool {
+	for _, client := range h.gameClients[gameID] {
+		if client.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// notifyPresence reports a presence change without blocking the hub. Callers must hold h.mu.
+func (h *Hub) notifyPresence(gameID, userID uuid.UUID, online bool) {
+	if h.onPresence != nil {
+		go h.onPresence(gameID, userID, online)
+	}
+}
+
 // Run starts the hub's main loop
 func (h *Hub) Run() {
 	for {
@@ -108,6 +143,9 @@ func (h *Hub) unregisterClient(client *Client) {
 					delete(h.gameClients, *client.GameID)
 				}
 			}
+			if !h.userInGame(*client.GameID, client.UserID) {
+				h.notifyPresence(*client.GameID, client.UserID, false)
+			}
 		}
 
 		close(client.Send)
@@ -124,14 +162,29 @@ func (h *Hub) broadcastToGame(broadcast *BroadcastMessage) {
 		return
 	}
 
+	// Built messages are shared between a user's connections
+	built := make(map[uuid.UUID][]byte)
+
 	for clientID, client := range gameClients {
 		// Skip excluded client
 		if broadcast.Exclude != nil && clientID == *broadcast.Exclude {
 			continue
 		}
 
+		message := broadcast.Message
+		if broadcast.Build != nil {
+			var ok bool
+			if message, ok = built[client.UserID]; !ok {
+				message = broadcast.Build(client.UserID)
+				built[client.UserID] = message
+			}
+			if message == nil {
+				continue
+			}
+		}
+
 		select {
-		case client.Send <- broadcast.Message:
+		case client.Send <- message:
 		default:
 			// Client's send channel is full, close and remove
 			close(client.Send)
@@ -153,12 +206,22 @@ func (h *Hub) AddClientToGame(clientID, gameID uuid.UUID) {
 
 	// Remove from old game if any
 	if client.GameID != nil {
-		if gameClients, exists := h.gameClients[*client.GameID]; exists {
+		if *client.GameID == gameID {
+			return
+		}
+		oldGameID := *client.GameID
+		if gameClients, exists := h.gameClients[oldGameID]; exists {
 			delete(gameClients, clientID)
 		}
+		if !h.userInGame(oldGameID, client.UserID) {
+			h.notifyPresence(oldGameID, client.UserID, false)
+		}
 	}
 
 	// Add to new game
+	if !h.userInGame(gameID, client.UserID) {
+		h.notifyPresence(gameID, client.UserID, true)
+	}
 	client.GameID = &gameID
 	if _, exists := h.gameClients[gameID]; !exists {
 		h.gameClients[gameID] = make(map[uuid.UUID]*Client)
@@ -181,7 +244,11 @@ func (h *Hub) RemoveClientFromGame(clientID, gameID uuid.UUID) {
 	}
 
 	if client, exists := h.clients[clientID]; exists {
+		wasInGame := client.GameID != nil && *client.GameID == gameID
 		client.GameID = nil
+		if wasInGame && !h.userInGame(gameID, client.UserID) {
+			h.notifyPresence(gameID, client.UserID, false)
+		}
 	}
 
 	log.Printf("Client %s removed from game %s", clientID, gameID)
@@ -222,6 +289,14 @@ func (h *Hub) BroadcastToGame(gameID uuid.UUID, message []byte, exclude *uuid.UU
 	}
 }
 
+// BroadcastToGameFor sends every client in a game the message build returns for its user
+func (h *Hub) BroadcastToGameFor(gameID uuid.UUID, build BuildFunc) {
+	h.broadcast <- &BroadcastMessage{
+		GameID: gameID,
+		Build:  build,
+	}
+}
+
 // SendToClient sends a message to a specific client
 func (h *Hub) SendToClient(clientID uuid.UUID, message []byte) error {
 	h.mu.RLock()
@@ -23,13 +23,65 @@ const (
 	MessageTypeGameJoined  MessageType = "game_joined"
 	MessageTypeGameStarted MessageType = "game_started"
 	MessageTypeGameMove    MessageType = "game_move"
+	MessageTypeGameMoveAck MessageType = "game_move_ack" // Reply to a move sent with a client_move_id
 	MessageTypeGameOver    MessageType = "game_over"
 	MessageTypeGameState   MessageType = "game_state"
 
+	// Game actions (client -> server)
+	MessageTypeGameResign      MessageType = "game_resign"
+	MessageTypeGameOfferDraw   MessageType = "game_offer_draw"
+	MessageTypeGameAcceptDraw  MessageType = "game_accept_draw"
+	MessageTypeGameDeclineDraw MessageType = "game_decline_draw"
+	MessageTypeGameAbort       MessageType = "game_abort"
+
+	// Rematch actions once a game is over (client -> server)
+	MessageTypeGameOfferRematch   MessageType = "game_offer_rematch"
+	MessageTypeGameAcceptRematch  MessageType = "game_accept_rematch"
+	MessageTypeGameDeclineRematch MessageType = "game_decline_rematch"
+
+	// Takeback actions in casual games (client -> server)
+	MessageTypeGameRequestTakeback MessageType = "game_request_takeback"
+	MessageTypeGameAcceptTakeback  MessageType = "game_accept_takeback"
+	MessageTypeGameDeclineTakeback MessageType = "game_decline_takeback"
+
+	// Pause actions in casual games (client -> server)
+	MessageTypeGameRequestPause  MessageType = "game_request_pause"
+	MessageTypeGameAcceptPause   MessageType = "game_accept_pause"
+	MessageTypeGameDeclinePause  MessageType = "game_decline_pause"
+	MessageTypeGameRequestResume MessageType = "game_request_resume"
+	MessageTypeGameAcceptResume  MessageType = "game_accept_resume"
+	MessageTypeGameDeclineResume MessageType = "game_decline_resume"
+
+	// Draw offer notifications (server -> client)
+	MessageTypeDrawOffered  MessageType = "draw_offered"
+	MessageTypeDrawDeclined MessageType = "draw_declined"
+
+	// Rematch notifications (server -> client)
+	MessageTypeRematchOffered  MessageType = "rematch_offered"
+	MessageTypeRematchDeclined MessageType = "rematch_declined"
+	MessageTypeRematchStarted  MessageType = "rematch_started" // Also sent when the next game of a series starts
+
+	// Takeback notifications (server -> client)
+	MessageTypeTakebackRequested MessageType = "takeback_requested"
+	MessageTypeTakebackDeclined  MessageType = "takeback_declined"
+	MessageTypeTakebackAccepted  MessageType = "takeback_accepted" // Followed by the rewound game state
+
+	// Pause notifications (server -> client), also sent when a tournament admin pauses or resumes a game
+	MessageTypePauseRequested  MessageType = "pause_requested"
+	MessageTypePauseDeclined   MessageType = "pause_declined"
+	MessageTypeGamePaused      MessageType = "game_paused"
+	MessageTypeResumeRequested MessageType = "resume_requested"
+	MessageTypeResumeDeclined  MessageType = "resume_declined"
+	MessageTypeGameResumed     MessageType = "game_resumed"
+
 	// Player events
 	MessageTypePlayerJoined MessageType = "player_joined"
 	MessageTypePlayerLeft   MessageType = "player_left"
 
+	// Presence events (server -> client)
+	MessageTypePlayerDisconnected MessageType = "player_disconnected"
+	MessageTypePlayerReconnected  MessageType = "player_reconnected"
+
 	// Game room events
 	MessageTypeJoinGame MessageType = "join_game"
 
@@ -81,9 +133,25 @@ type Message struct {
 
 // GameMoveMessage represents a player move
 type GameMoveMessage struct {
-	GameID   string      `json:"game_id"`
-	PlayerID string      `json:"player_id"`
-	Move     interface{} `json:"move"`
+	GameID       string      `json:"game_id"`
+	PlayerID     string      `json:"player_id"`
+	Move         interface{} `json:"move"`
+	Version      *int64      `json:"version,omitempty"`        // Game version the move was based on (optional)
+	ClientMoveID string      `json:"client_move_id,omitempty"` // Client-chosen ID so a resent move is applied once (optional)
+}
+
+// GameMoveAckMessage confirms a move sent with a client_move_id. A resent copy of a move that was
+// already applied gets the same version with duplicate set.
+type GameMoveAckMessage struct {
+	GameID       string `json:"game_id"`
+	ClientMoveID string `json:"client_move_id"`
+	Version      int64  `json:"version"` // Version of the game the move produced
+	Duplicate    bool   `json:"duplicate,omitempty"`
+}
+
+// GameActionMessage represents a resign, draw, abort, takeback, pause or rematch request for a game
+type GameActionMessage struct {
+	GameID string `json:"game_id"`
 }
 
 // JoinGameMessage represents a request to join a game room
@@ -93,17 +161,24 @@ type JoinGameMessage struct {
 
 // GameStateMessage represents the current game state
 type GameStateMessage struct {
-	GameID       string        `json:"game_id"`
-	GameType     string        `json:"game_type"`
-	State        interface{}   `json:"state"`
-	CurrentTurn  string        `json:"current_turn"`
-	Status       string        `json:"status"`
-	Player1ID    string        `json:"player1_id"`
-	Player2ID    string        `json:"player2_id"`
-	Player1Name  string        `json:"player1_name"`
-	Player2Name  string        `json:"player2_name"`
-	WinnerID     *string       `json:"winner_id,omitempty"`
-	Spectators   []interface{} `json:"spectators,omitempty"`
+	GameID        string           `json:"game_id"`
+	GameType      string           `json:"game_type"`
+	State         interface{}      `json:"state"`
+	CurrentTurn   string           `json:"current_turn"`
+	Status        string           `json:"status"`
+	Player1ID     string           `json:"player1_id"`
+	Player2ID     string           `json:"player2_id"`
+	Player1Name   string           `json:"player1_name"`
+	Player2Name   string           `json:"player2_name"`
+	Seats         interface{}      `json:"seats,omitempty"` // Every player in seat order (multiplayer games)
+	WinnerID      *string          `json:"winner_id,omitempty"`
+	Placements    interface{}      `json:"placements,omitempty"` // Final standings of a multiplayer game
+	Spectators    []interface{}    `json:"spectators,omitempty"`
+	Version       int64            `json:"version"`
+	Series        interface{}      `json:"series,omitempty"`       // Score of the series the game belongs to
+	NextGameID    *string          `json:"next_game_id,omitempty"` // Rematch or next series game, once started
+	RemainingTime map[string]int64 `json:"remaining_time_ms,omitempty"` // Player ID -> clock time left
+	LegalMoves    []interface{}    `json:"legal_moves,omitempty"`       // Moves open to the player to move while the game is active
 }
 
 // ErrorMessage represents an error message
@@ -0,0 +1,9 @@
+-- In-progress games are checkpointed behind their Redis copy so they survive a Redis restart.
+-- The snapshot is the whole game as stored in Redis; snapshot_version keeps an older checkpoint
+-- from overwriting a newer one.
+ALTER TABLE game_matches ADD COLUMN IF NOT EXISTS move_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE game_matches ADD COLUMN IF NOT EXISTS snapshot JSONB;
+ALTER TABLE game_matches ADD COLUMN IF NOT EXISTS snapshot_version BIGINT;
+ALTER TABLE game_matches ADD COLUMN IF NOT EXISTS checkpointed_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_game_matches_active_checkpoints ON game_matches(checkpointed_at) WHERE status = 'active';
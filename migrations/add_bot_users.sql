@@ -0,0 +1,7 @@
+-- Accounts the built-in bots play as (IDs match internal/game/bot.go).
+-- The password hash is not a valid bcrypt hash, so nobody can log in as a bot.
+INSERT INTO users (id, username, email, password_hash, elo_rating) VALUES
+    ('00000000-0000-4000-8000-00000000b001', 'Bot Easy', 'bot-easy@bots.playforge.invalid', '!', 800),
+    ('00000000-0000-4000-8000-00000000b002', 'Bot Medium', 'bot-medium@bots.playforge.invalid', '!', 1200),
+    ('00000000-0000-4000-8000-00000000b003', 'Bot Hard', 'bot-hard@bots.playforge.invalid', '!', 1600)
+ON CONFLICT (id) DO NOTHING;
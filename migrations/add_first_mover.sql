@@ -0,0 +1,6 @@
+-- Who moved first in each game, so the first-move advantage can be measured
+ALTER TABLE game_matches ADD COLUMN IF NOT EXISTS first_player_id UUID REFERENCES users(id);
+
+-- Two-player games each player moved first in, and how many of those they won
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS first_move_games INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS first_move_wins INTEGER NOT NULL DEFAULT 0;
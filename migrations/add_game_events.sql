@@ -0,0 +1,15 @@
+-- Every game's lifecycle events (created, joined, move, resigned, ...), archived from the Redis
+-- streams they are written to during play. Applied in order they rebuild the game. Abandoned
+-- games are never saved to game_matches, so events do not reference it.
+CREATE TABLE IF NOT EXISTS game_events (
+    game_id UUID NOT NULL,
+    version BIGINT NOT NULL,       -- Version of the game the event was saved at
+    event_index INTEGER NOT NULL,  -- Position among the events saved together
+    event_type VARCHAR(50) NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    event JSONB NOT NULL,
+    archived_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (game_id, version, event_index)
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_events_type ON game_events(event_type, occurred_at);
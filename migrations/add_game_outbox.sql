@@ -0,0 +1,28 @@
+-- Completion outbox: the side effects of a finished game (stats, tournament advancement,
+-- notifications), written in the same transaction as the game's match history record and
+-- applied by the outbox dispatcher
+CREATE TABLE IF NOT EXISTS game_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    game_id UUID NOT NULL REFERENCES game_matches(id) ON DELETE CASCADE,
+    kind VARCHAR(50) NOT NULL,                      -- stats, tournament or notifications
+    payload JSONB NOT NULL,                         -- The game as it ended
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',  -- pending, done or dead
+    attempts INTEGER NOT NULL DEFAULT 0,            -- Failed attempts so far
+    last_error TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    processed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (game_id, kind),
+    CONSTRAINT game_outbox_status_check CHECK (status IN ('pending', 'done', 'dead'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_outbox_due ON game_outbox(next_attempt_at) WHERE status = 'pending';
+
+-- Entries that failed too often to be retried. Requeue one once its cause is fixed with:
+-- UPDATE game_outbox SET status = 'pending', attempts = 0, next_attempt_at = CURRENT_TIMESTAMP WHERE id = ...;
+CREATE OR REPLACE VIEW game_outbox_dead_letters AS
+SELECT id, game_id, kind, attempts, last_error, created_at, updated_at AS failed_at
+FROM game_outbox
+WHERE status = 'dead'
+ORDER BY updated_at DESC;
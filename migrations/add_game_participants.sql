@@ -0,0 +1,17 @@
+-- Seat and placement of every player in a game. game_matches keeps seats 0 and 1 in
+-- player1_id/player2_id; games with more than two players store the rest only here.
+CREATE TABLE IF NOT EXISTS game_participants (
+    game_id UUID NOT NULL REFERENCES game_matches(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    seat INTEGER NOT NULL,
+    placement INTEGER, -- 1 for first; tied players share a placement
+    PRIMARY KEY (game_id, seat),
+    UNIQUE (game_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_participants_user_id ON game_participants(user_id);
+
+-- Top-three finishes in multiplayer games
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS first_places INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS second_places INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS third_places INTEGER NOT NULL DEFAULT 0;
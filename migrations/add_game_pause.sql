@@ -0,0 +1,4 @@
+-- Paused games are checkpointed with status 'paused' and kept until they are resumed, however
+-- long that takes. Each player lists their paused games.
+CREATE INDEX IF NOT EXISTS idx_game_matches_paused_player1 ON game_matches(player1_id) WHERE status = 'paused';
+CREATE INDEX IF NOT EXISTS idx_game_matches_paused_player2 ON game_matches(player2_id) WHERE status = 'paused';
@@ -0,0 +1,23 @@
+-- Best-of-N series played as consecutive games between two players. Player 1 and 2 are the
+-- seats of the first game; seats swap every game.
+CREATE TABLE IF NOT EXISTS game_series (
+    id UUID PRIMARY KEY,
+    game_type VARCHAR(50) NOT NULL,
+    best_of INTEGER NOT NULL,
+    games_played INTEGER NOT NULL DEFAULT 0,
+    player1_id UUID NOT NULL REFERENCES users(id),
+    player2_id UUID NOT NULL REFERENCES users(id),
+    player1_wins INTEGER NOT NULL DEFAULT 0,
+    player2_wins INTEGER NOT NULL DEFAULT 0,
+    draws INTEGER NOT NULL DEFAULT 0,
+    winner_id UUID REFERENCES users(id),
+    status VARCHAR(50) NOT NULL DEFAULT 'in_progress', -- in_progress, completed or abandoned
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Games of a series point at it with their number in the series (from 1)
+ALTER TABLE game_matches ADD COLUMN IF NOT EXISTS series_id UUID REFERENCES game_series(id);
+ALTER TABLE game_matches ADD COLUMN IF NOT EXISTS series_game INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_game_matches_series_id ON game_matches(series_id);
@@ -0,0 +1,2 @@
+-- Store the settings each game was created with so replays can rebuild the initial position
+ALTER TABLE game_matches ADD COLUMN IF NOT EXISTS settings JSONB;
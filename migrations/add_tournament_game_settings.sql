@@ -0,0 +1,2 @@
+-- Store tournament game settings (including time controls) so every match uses them
+ALTER TABLE tournaments ADD COLUMN IF NOT EXISTS game_settings JSONB;
@@ -10,13 +10,20 @@ DROP TRIGGER IF EXISTS update_users_updated_at ON users;
 -- Drop function
 DROP FUNCTION IF EXISTS update_updated_at_column();
 
+-- Drop views
+DROP VIEW IF EXISTS game_outbox_dead_letters;
+
 -- Drop tables in reverse order of dependencies
 DROP TABLE IF EXISTS chat_messages CASCADE;
 DROP TABLE IF EXISTS tournament_matches CASCADE;
 DROP TABLE IF EXISTS tournaments CASCADE;
 DROP TABLE IF EXISTS room_participants CASCADE;
 DROP TABLE IF EXISTS rooms CASCADE;
+DROP TABLE IF EXISTS game_outbox CASCADE;
+DROP TABLE IF EXISTS game_events CASCADE;
+DROP TABLE IF EXISTS game_participants CASCADE;
 DROP TABLE IF EXISTS game_matches CASCADE;
+DROP TABLE IF EXISTS game_series CASCADE;
 DROP TABLE IF EXISTS player_stats CASCADE;
 DROP TABLE IF EXISTS users CASCADE;
 